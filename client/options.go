@@ -287,6 +287,18 @@ func WithLoadBalanceP2C() ReferenceOption {
 	}
 }
 
+func WithLoadBalanceMaglev() ReferenceOption {
+	return func(opts *ReferenceOptions) {
+		opts.Reference.Loadbalance = constant.LoadBalanceKeyMaglev
+	}
+}
+
+func WithLoadBalanceLocality() ReferenceOption {
+	return func(opts *ReferenceOptions) {
+		opts.Reference.Loadbalance = constant.LoadBalanceKeyLocality
+	}
+}
+
 func WithLoadBalance(lb string) ReferenceOption {
 	return func(opts *ReferenceOptions) {
 		opts.Reference.Loadbalance = lb
@@ -760,6 +772,18 @@ func WithClientLoadBalanceP2C() ClientOption {
 	}
 }
 
+func WithClientLoadBalanceMaglev() ClientOption {
+	return func(opts *ClientOptions) {
+		opts.overallReference.Loadbalance = constant.LoadBalanceKeyMaglev
+	}
+}
+
+func WithClientLoadBalanceLocality() ClientOption {
+	return func(opts *ClientOptions) {
+		opts.overallReference.Loadbalance = constant.LoadBalanceKeyLocality
+	}
+}
+
 func WithClientLoadBalance(lb string) ClientOption {
 	return func(opts *ClientOptions) {
 		opts.overallReference.Loadbalance = lb
@@ -18,11 +18,20 @@
 // Package base implements invoker for the manipulation of cluster strategy.
 package base
 
+import (
+	"context"
+	"strconv"
+)
+
 import (
 	"github.com/dubbogo/gost/log/logger"
 
 	perrors "github.com/pkg/errors"
 
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
 	"go.uber.org/atomic"
 )
 
@@ -32,7 +41,9 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	dubbotrace "dubbo.apache.org/dubbo-go/v3/otel/trace"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
 
 type BaseClusterInvoker struct {
@@ -162,6 +173,27 @@ func (invoker *BaseClusterInvoker) doSelectInvoker(lb loadbalance.LoadBalance, i
 	return selectedInvoker
 }
 
+// InvokeAttempt invokes the invoker selected by the attempt of the cluster, the attempt starting from 1 is traced
+// as the child span of the invocation with the address of the invoker if the invocation is traced
+func InvokeAttempt(ctx context.Context, cluster string, attempt int, invoker base.Invoker, invocation base.Invocation) result.Result {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return invoker.Invoke(ctx, invocation)
+	}
+	url := invoker.GetURL()
+	attrs := []attribute.KeyValue{
+		dubbotrace.ClusterKey.String(cluster),
+		dubbotrace.ClusterAttemptKey.Int(attempt),
+		semconv.ServerAddress(url.Ip),
+	}
+	if port, err := strconv.Atoi(url.Port); err == nil {
+		attrs = append(attrs, semconv.ServerPort(port))
+	}
+	ctx, span := dubbotrace.StartSpan(ctx, dubbotrace.SpanClusterAttempt, attrs...)
+	res := invoker.Invoke(ctx, invocation)
+	dubbotrace.EndSpan(span, res.Error())
+	return res
+}
+
 func isInvoked(selectedInvoker base.Invoker, invoked []base.Invoker) bool {
 	for _, i := range invoked {
 		if i == selectedInvoker {
@@ -85,7 +85,7 @@ func (invoker *failoverClusterInvoker) Invoke(ctx context.Context, invocation pr
 		}
 		invoked = append(invoked, ivk)
 		// DO INVOKE
-		res = ivk.Invoke(ctx, invocation)
+		res = base.InvokeAttempt(ctx, constant.ClusterKeyFailover, i+1, ivk, invocation)
 		if res.Error() != nil && !isBizError(res.Error()) {
 			providers = append(providers, ivk.GetURL().Key())
 			continue
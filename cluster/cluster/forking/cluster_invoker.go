@@ -72,13 +72,13 @@ func (invoker *forkingClusterInvoker) Invoke(ctx context.Context, invocation pro
 	}
 
 	resultQ := queue.New(1)
-	for _, ivk := range selected {
-		go func(k protocolbase.Invoker) {
-			result := k.Invoke(ctx, invocation)
+	for i, ivk := range selected {
+		go func(attempt int, k protocolbase.Invoker) {
+			result := base.InvokeAttempt(ctx, constant.ClusterKeyForking, attempt, k, invocation)
 			if err := resultQ.Put(result); err != nil {
 				logger.Errorf("resultQ put failed with exception: %v.\n", err)
 			}
-		}(ivk)
+		}(i+1, ivk)
 	}
 
 	rsps, err := resultQ.Poll(1, time.Millisecond*time.Duration(timeouts))
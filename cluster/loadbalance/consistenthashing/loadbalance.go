@@ -20,7 +20,7 @@ package consistenthashing
 import (
 	"encoding/json"
 	"hash/crc32"
-	"regexp"
+	"sync"
 )
 
 import (
@@ -32,14 +32,14 @@ import (
 
 const (
 	// HashNodes hash nodes
-	HashNodes = "hash.nodes"
+	HashNodes = constant.HashNodesKey
 	// HashArguments key of hash arguments in url
-	HashArguments = "hash.arguments"
+	HashArguments = constant.HashArgumentsKey
 )
 
 var (
-	selectors = make(map[string]*selector)
-	re        = regexp.MustCompile(constant.CommaSplitPattern)
+	selectors     = make(map[string]*selector)
+	selectorsLock sync.RWMutex
 )
 
 func init() {
@@ -51,7 +51,12 @@ type conshashLoadBalance struct{}
 
 // newConshashLoadBalance creates NewConsistentHashLoadBalance
 //
-// The same parameters of the request is always sent to the same provider.
+// The same parameters of the request is always sent to the same provider. The hash key is made of the attachments
+// listed by hash.attachments or the arguments listed by hash.arguments, see loadbalance.HashKey. If
+// hash.balance-factor is set, the loads are bounded: a provider whose active requests exceed the factor times
+// the average is skipped for the next one on the ring, so a hot key doesn't overload its provider. The active
+// requests are counted by the active filter, which isn't one of the default filters, so it must be added to the
+// filters of the reference, e.g. filter: active, otherwise hash.balance-factor takes no effect with a warning.
 func newConshashLoadBalance() loadbalance.LoadBalance {
 	return &conshashLoadBalance{}
 }
@@ -71,10 +76,14 @@ func (lb *conshashLoadBalance) Select(invokers []base.Invoker, invocation base.I
 		bs = append(bs, b...)
 	}
 	hashCode := crc32.ChecksumIEEE(bs)
+	selectorsLock.RLock()
 	selector, ok := selectors[key]
+	selectorsLock.RUnlock()
 	if !ok || selector.hashCode != hashCode {
-		selectors[key] = newSelector(invokers, methodName, hashCode)
-		selector = selectors[key]
+		selector = newSelector(invokers, methodName, hashCode)
+		selectorsLock.Lock()
+		selectors[key] = selector
+		selectorsLock.Unlock()
 	}
 	return selector.Select(invocation)
 }
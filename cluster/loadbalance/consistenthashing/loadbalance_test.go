@@ -29,6 +29,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/cluster/loadbalance"
 	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 )
@@ -62,9 +63,34 @@ func (s *consistentHashSelectorSuite) SetupTest() {
 	s.selector = newSelector(invokers, "echo", 999944)
 }
 
-func (s *consistentHashSelectorSuite) TestToKey() {
-	result := s.selector.toKey([]any{"username", "age"})
-	s.Equal(result, "usernameage")
+func (s *consistentHashSelectorSuite) TestBoundedLoads() {
+	url1, _ := common.NewURL(url8080Short)
+	url2, _ := common.NewURL(url8081Short)
+	invoker1, invoker2 := base.NewBaseInvoker(url1), base.NewBaseInvoker(url2)
+	s.selector.invokers = []base.Invoker{invoker1, invoker2}
+	s.selector.methodName = "bounded"
+	s.selector.virtualInvokers = map[uint32]base.Invoker{99874: invoker1, 9999945: invoker2}
+	s.selector.keys = []uint32{99874, 9999945}
+	s.selector.balanceFactor = 1.25
+	defer base.CleanAllStatus()
+
+	s.Equal(invoker1, s.selector.selectForKey(1))
+	// capacity is ceil(1.25 * (2 + 1) / 2) = 2, the invoker at capacity is skipped
+	base.BeginCount(url1, "bounded")
+	base.BeginCount(url1, "bounded")
+	s.Equal(invoker2, s.selector.selectForKey(1))
+	// capacity is ceil(1.25 * (4 + 1) / 2) = 4
+	base.BeginCount(url2, "bounded")
+	base.BeginCount(url2, "bounded")
+	s.Equal(invoker1, s.selector.selectForKey(1))
+}
+
+func (s *consistentHashSelectorSuite) TestBalanceFactorWithoutActiveFilter() {
+	url, _ := common.NewURL(url8080 + "&hash.balance-factor=1.25")
+	s.Zero(newSelector([]base.Invoker{base.NewBaseInvoker(url)}, "echo", 1).balanceFactor)
+
+	url.SetParam(constant.ReferenceFilterKey, "echo, active")
+	s.Equal(1.25, newSelector([]base.Invoker{base.NewBaseInvoker(url)}, "echo", 1).balanceFactor)
 }
 
 func (s *consistentHashSelectorSuite) TestSelectForKey() {
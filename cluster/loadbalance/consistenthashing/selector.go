@@ -19,17 +19,21 @@ package consistenthashing
 
 import (
 	"crypto/md5"
-	"fmt"
+	"math"
 	"sort"
 	"strconv"
 	"strings"
 )
 
 import (
+	"github.com/dubbogo/gost/log/logger"
 	gxsort "github.com/dubbogo/gost/sort"
 )
 
 import (
+	"dubbo.apache.org/dubbo-go/v3/cluster/loadbalance"
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 )
 
@@ -39,7 +43,10 @@ type selector struct {
 	replicaNum      int
 	virtualInvokers map[uint32]base.Invoker
 	keys            gxsort.Uint32Slice
-	argumentIndex   []int
+	invokers        []base.Invoker
+	methodName      string
+	// balanceFactor bounds the loads of the invokers, 0 means unbounded
+	balanceFactor float64
 }
 
 func newSelector(invokers []base.Invoker, methodName string,
@@ -48,15 +55,21 @@ func newSelector(invokers []base.Invoker, methodName string,
 	selector := &selector{}
 	selector.virtualInvokers = make(map[uint32]base.Invoker)
 	selector.hashCode = hashCode
+	selector.invokers = invokers
+	selector.methodName = methodName
 	url := invokers[0].GetURL()
-	selector.replicaNum = url.GetMethodParamIntValue(methodName, HashNodes, 160)
-	indices := re.Split(url.GetMethodParam(methodName, HashArguments, "0"), -1)
-	for _, index := range indices {
-		i, err := strconv.Atoi(index)
-		if err != nil {
-			return nil
+	selector.replicaNum = url.GetMethodParamIntValue(methodName, HashNodes, int(url.GetParamInt(HashNodes, 160)))
+	factor := url.GetMethodParam(methodName, constant.HashBalanceFactorKey, url.GetParam(constant.HashBalanceFactorKey, ""))
+	if f, err := strconv.ParseFloat(factor, 64); err == nil && f >= 1 {
+		if hasActiveFilter(url) {
+			selector.balanceFactor = f
+		} else {
+			logger.Warnf("[ConsistentHash] %s takes no effect since the active requests aren't counted, "+
+				"please add the %s filter to the references", constant.HashBalanceFactorKey, constant.ActiveFilterKey)
 		}
-		selector.argumentIndex = append(selector.argumentIndex, i)
+	} else if factor != "" {
+		logger.Warnf("[ConsistentHash] invalid %s %q, it should be a number not less than 1, the loads are unbounded",
+			constant.HashBalanceFactorKey, factor)
 	}
 	for _, invoker := range invokers {
 		u := invoker.GetURL()
@@ -76,21 +89,13 @@ func newSelector(invokers []base.Invoker, methodName string,
 
 // Select gets invoker based on load balancing strategy
 func (c *selector) Select(invocation base.Invocation) base.Invoker {
-	key := c.toKey(invocation.Arguments())
+	key := loadbalance.HashKey(c.invokers[0].GetURL(), invocation)
 	digest := md5.Sum([]byte(key))
 	return c.selectForKey(c.hash(digest, 0))
 }
 
-func (c *selector) toKey(args []any) string {
-	var sb strings.Builder
-	for i := range c.argumentIndex {
-		if i >= 0 && i < len(args) {
-			_, _ = fmt.Fprint(&sb, args[i].(string))
-		}
-	}
-	return sb.String()
-}
-
+// selectForKey returns the first invoker clockwise from the hash on the ring. With the bounded loads, the invokers
+// whose active requests reach the capacity are skipped, so the keys of a hot invoker overflow to its successors.
 func (c *selector) selectForKey(hash uint32) base.Invoker {
 	idx := sort.Search(len(c.keys), func(i int) bool {
 		return c.keys[i] >= hash
@@ -98,9 +103,45 @@ func (c *selector) selectForKey(hash uint32) base.Invoker {
 	if idx == len(c.keys) {
 		idx = 0
 	}
+	if c.balanceFactor <= 0 {
+		return c.virtualInvokers[c.keys[idx]]
+	}
+
+	capacity := c.capacity()
+	for i := 0; i < len(c.keys); i++ {
+		invoker := c.virtualInvokers[c.keys[(idx+i)%len(c.keys)]]
+		if c.active(invoker) < capacity {
+			return invoker
+		}
+	}
 	return c.virtualInvokers[c.keys[idx]]
 }
 
+// capacity returns the max active requests of an invoker, which is the balance factor times the average active
+// requests including the one being selected, so there is always an invoker below it
+func (c *selector) capacity() int32 {
+	var total int64
+	for _, invoker := range c.invokers {
+		total += int64(c.active(invoker))
+	}
+	return int32(math.Ceil(c.balanceFactor * float64(total+1) / float64(len(c.invokers))))
+}
+
+// active returns the active requests of the invoker, they are counted by the active filter
+func (c *selector) active(invoker base.Invoker) int32 {
+	return base.GetMethodStatus(invoker.GetURL(), c.methodName).GetActive()
+}
+
+// hasActiveFilter returns true if the active filter counting the active requests is in the filters of the reference
+func hasActiveFilter(url *common.URL) bool {
+	for _, name := range strings.Split(url.GetParam(constant.ReferenceFilterKey, ""), ",") {
+		if strings.TrimSpace(name) == constant.ActiveFilterKey {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *selector) hash(digest [16]byte, i int) uint32 {
 	return (uint32(digest[3+i*4]&0xFF) << 24) | (uint32(digest[2+i*4]&0xFF) << 16) |
 		(uint32(digest[1+i*4]&0xFF) << 8) | uint32(digest[i*4]&0xFF)&0xFFFFFFF
@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+)
+
+// HashKey returns the key of the invocation hashed by the hash load balances. It's composed of the values of the
+// attachments listed by hash.attachments if any of them is present, otherwise of the arguments at the indexes
+// listed by hash.arguments, which is the first argument by default.
+func HashKey(url *common.URL, invocation base.Invocation) string {
+	methodName := invocation.MethodName()
+	if keys := url.GetMethodParam(methodName, constant.HashAttachmentsKey, url.GetParam(constant.HashAttachmentsKey, "")); keys != "" {
+		var sb strings.Builder
+		found := false
+		for _, key := range splitParams(keys) {
+			value, ok := invocation.GetAttachment(key)
+			if !ok {
+				// the headers of triple are lower-cased
+				value, ok = invocation.GetAttachment(strings.ToLower(key))
+			}
+			if ok {
+				found = true
+				sb.WriteString(value)
+			}
+		}
+		if found {
+			return sb.String()
+		}
+	}
+
+	args := invocation.Arguments()
+	var sb strings.Builder
+	for _, index := range splitParams(url.GetMethodParam(methodName, constant.HashArgumentsKey, url.GetParam(constant.HashArgumentsKey, "0"))) {
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 || i >= len(args) {
+			continue
+		}
+		if str, ok := args[i].(string); ok {
+			sb.WriteString(str)
+		} else {
+			_, _ = fmt.Fprint(&sb, args[i])
+		}
+	}
+	return sb.String()
+}
+
+func splitParams(value string) []string {
+	var params []string
+	for _, param := range strings.Split(value, constant.CommaSeparator) {
+		if param = strings.TrimSpace(param); param != "" {
+			params = append(params, param)
+		}
+	}
+	return params
+}
@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package loadbalance
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+)
+
+func TestHashKey(t *testing.T) {
+	url, _ := common.NewURL("dubbo://192.168.1.0:20000/org.apache.demo.HelloService?" +
+		"methods.echo.hash.arguments=0,2&hash.attachments=X-User-Id,region")
+
+	// the attachments present are hashed, the headers of triple are lower-cased and wrapped by arrays
+	inv := invocation.NewRPCInvocation("echo", []any{"name", "password", 18},
+		map[string]any{"x-user-id": []string{"1001"}, "region": "hz"})
+	assert.Equal(t, "1001hz", HashKey(url, inv))
+
+	// the arguments are hashed without the attachments
+	inv = invocation.NewRPCInvocation("echo", []any{"name", "password", 18}, nil)
+	assert.Equal(t, "name18", HashKey(url, inv))
+
+	// the first argument is hashed by default
+	inv = invocation.NewRPCInvocation("greet", []any{"name", "password"}, nil)
+	assert.Equal(t, "name", HashKey(url, inv))
+	assert.Equal(t, "", HashKey(url, invocation.NewRPCInvocation("greet", nil, nil)))
+}
@@ -28,6 +28,7 @@ import (
 	_ "dubbo.apache.org/dubbo-go/v3/cluster/loadbalance/consistenthashing"
 	_ "dubbo.apache.org/dubbo-go/v3/cluster/loadbalance/iwrr"
 	_ "dubbo.apache.org/dubbo-go/v3/cluster/loadbalance/leastactive"
+	_ "dubbo.apache.org/dubbo-go/v3/cluster/loadbalance/maglev"
 	_ "dubbo.apache.org/dubbo-go/v3/cluster/loadbalance/p2c"
 	_ "dubbo.apache.org/dubbo-go/v3/cluster/loadbalance/random"
 	_ "dubbo.apache.org/dubbo-go/v3/cluster/loadbalance/roundrobin"
@@ -39,9 +40,14 @@ import (
 )
 
 func Generate() []base.Invoker {
+	return GenerateWithParams("")
+}
+
+// GenerateWithParams generates the invokers whose urls have the params like hash.balance-factor=1.25
+func GenerateWithParams(params string) []base.Invoker {
 	var invokers []base.Invoker
 	for i := 1; i < 256; i++ {
-		url, _ := common.NewURL(fmt.Sprintf("dubbo://192.168.1.%v:20000/org.apache.demo.HelloService", i))
+		url, _ := common.NewURL(fmt.Sprintf("dubbo://192.168.1.%v:20000/org.apache.demo.HelloService?%s", i, params))
 		invokers = append(invokers, base.NewBaseInvoker(url))
 	}
 	return invokers
@@ -84,3 +90,35 @@ func BenchmarkRandomLoadbalance(b *testing.B) {
 func BenchmarkAliasMethodLoadbalance(b *testing.B) {
 	Benchloadbalance(b, extension.GetLoadbalance(constant.LoadBalanceKeyAliasMethod))
 }
+
+// BenchHashLoadbalance benchmarks the hash load balances with the invocations of different hash keys
+func BenchHashLoadbalance(b *testing.B, lb loadbalance.LoadBalance, params string) {
+	b.Helper()
+	invokers := GenerateWithParams(params)
+	invocations := make([]base.Invocation, 1024)
+	for i := range invocations {
+		invocations[i] = invocation.NewRPCInvocation("echo", []any{fmt.Sprintf("key-%d", i)}, nil)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lb.Select(invokers, invocations[i%len(invocations)])
+	}
+}
+
+func BenchmarkConsistenthashingLoadbalanceByKey(b *testing.B) {
+	BenchHashLoadbalance(b, extension.GetLoadbalance(constant.LoadBalanceKeyConsistentHashing), "")
+}
+
+func BenchmarkBoundedLoadConsistenthashingLoadbalance(b *testing.B) {
+	BenchHashLoadbalance(b, extension.GetLoadbalance(constant.LoadBalanceKeyConsistentHashing),
+		constant.HashBalanceFactorKey+"=1.25")
+}
+
+func BenchmarkMaglevLoadbalance(b *testing.B) {
+	Benchloadbalance(b, extension.GetLoadbalance(constant.LoadBalanceKeyMaglev))
+}
+
+func BenchmarkMaglevLoadbalanceByKey(b *testing.B) {
+	BenchHashLoadbalance(b, extension.GetLoadbalance(constant.LoadBalanceKeyMaglev), "")
+}
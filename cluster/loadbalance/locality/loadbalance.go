@@ -0,0 +1,182 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package locality
+
+import (
+	"math/rand"
+	"strconv"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/cluster/loadbalance"
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsRpc "dubbo.apache.org/dubbo-go/v3/metrics/rpc"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+)
+
+// defaultThreshold is the ratio of the healthy providers, at and above which a locality takes all its traffic
+const defaultThreshold = 0.7
+
+// The localities of the providers relative to the consumer, from the nearest to the farthest
+const (
+	localityLocal = iota
+	localityRegion
+	localityRemote
+	localityCount
+)
+
+var localityNames = [localityCount]string{"local", "region", "remote"}
+
+func init() {
+	extension.SetLoadbalance(constant.LoadBalanceKeyLocality, newLocalityLoadBalance)
+}
+
+type localityLoadBalance struct{}
+
+// newLocalityLoadBalance returns a locality-aware load balance.
+//
+// The zone and the region of the consumer are got from locality.zone and locality.region of the reference, or the
+// environment variables DUBBO_ZONE and DUBBO_REGION, and the ones of the providers from their params zone and region.
+// The providers in the chosen locality are selected by the load balance of locality.loadbalance.
+func newLocalityLoadBalance() loadbalance.LoadBalance {
+	return &localityLoadBalance{}
+}
+
+// group is the providers of a locality
+type group struct {
+	total   int
+	healthy []base.Invoker
+}
+
+// Select gets invoker based on locality-aware load balancing strategy
+func (lb *localityLoadBalance) Select(invokers []base.Invoker, invocation base.Invocation) base.Invoker {
+	count := len(invokers)
+	if count == 0 {
+		return nil
+	}
+	if count == 1 {
+		return invokers[0]
+	}
+
+	url := invokers[0].GetURL()
+	delegateKey := url.GetParam(constant.LocalityLoadBalanceKey, constant.LoadBalanceKeyRandom)
+	if delegateKey == constant.LoadBalanceKeyLocality {
+		delegateKey = constant.LoadBalanceKeyRandom
+	}
+	delegate := extension.GetLoadbalance(delegateKey)
+	zone := url.GetParam(constant.LocalityZoneKey, common.GetLocalZone())
+	region := url.GetParam(constant.LocalityRegionKey, common.GetLocalRegion())
+	if zone == "" && region == "" {
+		return delegate.Select(invokers, invocation)
+	}
+
+	var groups [localityCount]group
+	for _, invoker := range invokers {
+		g := &groups[localityOf(invoker.GetURL(), zone, region)]
+		g.total++
+		if invoker.IsAvailable() && base.GetInvokerHealthyStatus(invoker) {
+			g.healthy = append(g.healthy, invoker)
+		}
+	}
+	loads := distribute(groups, threshold(url))
+
+	locality := -1
+	for r, i := rand.Float64(), 0; i < localityCount; i++ {
+		if loads[i] == 0 {
+			continue
+		}
+		locality = i
+		if r -= loads[i]; r < 0 {
+			break
+		}
+	}
+	if locality < 0 {
+		// none of the providers is healthy, leave it to the delegate
+		return delegate.Select(invokers, invocation)
+	}
+
+	selected := delegate.Select(groups[locality].healthy, invocation)
+	if selected != nil {
+		metrics.Publish(metricsRpc.NewLocalitySelectedEvent(selected, invocation,
+			selected.GetURL().GetParam(constant.ZoneKey, ""), localityNames[locality]))
+	}
+	return selected
+}
+
+// localityOf returns the locality of the provider relative to the consumer in the zone of the region
+func localityOf(url *common.URL, zone, region string) int {
+	providerRegion := url.GetParam(constant.RegionKey, "")
+	if region != "" && providerRegion != "" && region != providerRegion {
+		return localityRemote
+	}
+	if zone != "" && url.GetParam(constant.ZoneKey, "") == zone {
+		return localityLocal
+	}
+	if region != "" && providerRegion == region {
+		return localityRegion
+	}
+	return localityRemote
+}
+
+// distribute returns the shares of the traffic of the localities. Each locality takes the traffic in proportion
+// to its healthy ratio over the threshold, up to all the traffic left by the nearer ones. If the healthy providers
+// of all the localities can't take all the traffic, the shares are scaled up in proportion.
+func distribute(groups [localityCount]group, threshold float64) [localityCount]float64 {
+	var loads [localityCount]float64
+	left, sum := 1.0, 0.0
+	for i, g := range groups {
+		if g.total == 0 || left <= 0 {
+			continue
+		}
+		load := float64(len(g.healthy)) / float64(g.total) / threshold
+		if load > left {
+			load = left
+		}
+		loads[i] = load
+		left -= load
+		sum += load
+	}
+	if sum > 0 && sum < 1 {
+		for i := range loads {
+			loads[i] /= sum
+		}
+	}
+	return loads
+}
+
+// threshold returns the threshold of the healthy ratio in (0, 1]
+func threshold(url *common.URL) float64 {
+	value := url.GetParam(constant.LocalityThresholdKey, "")
+	if value == "" {
+		return defaultThreshold
+	}
+	t, err := strconv.ParseFloat(value, 64)
+	if err != nil || t <= 0 || t > 1 {
+		logger.Warnf("[Locality LoadBalance] invalid %s %q, it should be in (0, 1], use %v instead",
+			constant.LocalityThresholdKey, value, defaultThreshold)
+		return defaultThreshold
+	}
+	return t
+}
@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package locality
+
+import (
+	"fmt"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	_ "dubbo.apache.org/dubbo-go/v3/cluster/loadbalance/random"
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+)
+
+// generateInvokers generates the invokers in the zone of the region, the consumer is in zone a of region r1
+func generateInvokers(zone, region string, count int, from int) []base.Invoker {
+	var invokers []base.Invoker
+	for i := from; i < from+count; i++ {
+		url, _ := common.NewURL(fmt.Sprintf("dubbo://192.168.1.%v:20000/org.apache.demo.HelloService?"+
+			"zone=%s&region=%s&locality.zone=a&locality.region=r1", i, zone, region))
+		invokers = append(invokers, base.NewBaseInvoker(url))
+	}
+	return invokers
+}
+
+func countZones(invokers []base.Invoker, times int) map[string]int {
+	lb := newLocalityLoadBalance()
+	counts := make(map[string]int)
+	for i := 0; i < times; i++ {
+		selected := lb.Select(invokers, invocation.NewRPCInvocation("echo", nil, nil))
+		counts[selected.GetURL().GetParam(constant.ZoneKey, "")]++
+	}
+	return counts
+}
+
+func TestLocalitySelect(t *testing.T) {
+	local := generateInvokers("a", "r1", 4, 1)
+	region := generateInvokers("b", "r1", 4, 11)
+	remote := generateInvokers("c", "r2", 4, 21)
+	invokers := append(append(append([]base.Invoker{}, local...), region...), remote...)
+	defer base.CleanAllStatus()
+
+	// the traffic stays in the local zone while it's healthy
+	counts := countZones(invokers, 1000)
+	assert.Equal(t, 1000, counts["a"])
+
+	// 2 of 4 are healthy, the local zone takes 0.5 / 0.7 of the traffic and the rest spills to zone b
+	base.SetInvokerUnhealthyStatus(local[0])
+	base.SetInvokerUnhealthyStatus(local[1])
+	counts = countZones(invokers, 10000)
+	assert.InDelta(t, 7143, counts["a"], 300)
+	assert.InDelta(t, 2857, counts["b"], 300)
+	assert.Zero(t, counts["c"])
+
+	// the zones of the region are unhealthy, the traffic goes to the remote region
+	for _, invoker := range append(append([]base.Invoker{}, local...), region...) {
+		base.SetInvokerUnhealthyStatus(invoker)
+	}
+	counts = countZones(invokers, 1000)
+	assert.Equal(t, 1000, counts["c"])
+}
+
+func TestLocalityOf(t *testing.T) {
+	url, _ := common.NewURL("dubbo://192.168.1.1:20000/org.apache.demo.HelloService?zone=a&region=r1")
+	assert.Equal(t, localityLocal, localityOf(url, "a", "r1"))
+	assert.Equal(t, localityLocal, localityOf(url, "a", ""))
+	assert.Equal(t, localityRegion, localityOf(url, "b", "r1"))
+	assert.Equal(t, localityRemote, localityOf(url, "a", "r2"))
+	assert.Equal(t, localityRemote, localityOf(url, "b", ""))
+}
+
+func TestDistribute(t *testing.T) {
+	groups := [localityCount]group{
+		{total: 4, healthy: make([]base.Invoker, 1)},
+		{total: 2, healthy: make([]base.Invoker, 0)},
+		{total: 4, healthy: make([]base.Invoker, 1)},
+	}
+	// 0.25 / 0.5 = 0.5 for each of the local and the remote
+	loads := distribute(groups, 0.5)
+	assert.InDelta(t, 0.5, loads[localityLocal], 1e-9)
+	assert.InDelta(t, 0, loads[localityRegion], 1e-9)
+	assert.InDelta(t, 0.5, loads[localityRemote], 1e-9)
+
+	// the shares are scaled up if the healthy ones can't take all the traffic
+	groups[localityRemote].healthy = nil
+	loads = distribute(groups, 0.5)
+	assert.InDelta(t, 1, loads[localityLocal], 1e-9)
+}
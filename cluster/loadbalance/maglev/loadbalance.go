@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package maglev
+
+import (
+	"sync"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/cluster/loadbalance"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+)
+
+// defaultTableSize is the default size of the lookup table, which is a prime about 100 times the invokers of
+// the large services, the more entries per invoker the more even the loads are
+const defaultTableSize = 65537
+
+// tables are the lookup tables of the services, which are shared by the load balances created per invocation
+var tables sync.Map
+
+func init() {
+	extension.SetLoadbalance(constant.LoadBalanceKeyMaglev, newMaglevLoadBalance)
+}
+
+// maglevLoadBalance sends the same hash key to the same invoker, the tables are cached by the service keys
+type maglevLoadBalance struct{}
+
+// newMaglevLoadBalance returns a maglev load balance.
+//
+// The hash key is made of the attachments listed by hash.attachments or the arguments listed by hash.arguments,
+// see loadbalance.HashKey. The size of the lookup table is set by maglev.table-size.
+func newMaglevLoadBalance() loadbalance.LoadBalance {
+	return &maglevLoadBalance{}
+}
+
+// Select gets invoker based on maglev load balancing strategy
+func (lb *maglevLoadBalance) Select(invokers []base.Invoker, invocation base.Invocation) base.Invoker {
+	count := len(invokers)
+	if count == 0 {
+		return nil
+	}
+	if count == 1 {
+		return invokers[0]
+	}
+
+	url := invokers[0].GetURL()
+	serviceKey := url.ServiceKey()
+	var t *table
+	if cached, ok := tables.Load(serviceKey); ok && cached.(*table).builtFrom(invokers) {
+		t = cached.(*table)
+	} else {
+		size := int(url.GetParamInt(constant.MaglevTableSizeKey, defaultTableSize))
+		if size < count {
+			size = count
+		}
+		t = newTable(invokers, nextPrime(size))
+		tables.Store(serviceKey, t)
+	}
+	return t.lookup(loadbalance.HashKey(url, invocation))
+}
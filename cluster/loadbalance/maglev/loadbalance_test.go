@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package maglev
+
+import (
+	"fmt"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+)
+
+func generateInvokers(from, to int) []base.Invoker {
+	var invokers []base.Invoker
+	for i := from; i < to; i++ {
+		url, _ := common.NewURL(fmt.Sprintf("dubbo://192.168.1.%v:20000/org.apache.demo.HelloService?maglev.table-size=1000", i))
+		invokers = append(invokers, base.NewBaseInvoker(url))
+	}
+	return invokers
+}
+
+func TestMaglevSelect(t *testing.T) {
+	lb := newMaglevLoadBalance()
+	invokers := generateInvokers(0, 10)
+
+	inv := invocation.NewRPCInvocation("echo", []any{"key"}, nil)
+	selected := lb.Select(invokers, inv)
+	assert.NotNil(t, selected)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, selected, lb.Select(invokers, inv))
+	}
+	// the invokers in another order are mapped to the same table
+	reversed := make([]base.Invoker, len(invokers))
+	for i, invoker := range invokers {
+		reversed[len(invokers)-1-i] = invoker
+	}
+	assert.Equal(t, selected, lb.Select(reversed, inv))
+
+	assert.Nil(t, lb.Select(nil, inv))
+	assert.Equal(t, invokers[0], lb.Select(invokers[:1], inv))
+}
+
+func TestMaglevTable(t *testing.T) {
+	invokers := generateInvokers(0, 10)
+	size := nextPrime(1000)
+	assert.Equal(t, 1009, size)
+	tbl := newTable(invokers, size)
+
+	// each invoker owns about M/N entries
+	owned := make(map[int32]int)
+	for _, entry := range tbl.entries {
+		owned[entry]++
+	}
+	assert.Len(t, owned, len(invokers))
+	for _, count := range owned {
+		assert.InDelta(t, size/len(invokers), count, 2)
+	}
+
+	// the keys of the remaining invokers are mostly kept when an invoker is removed
+	removed := newTable(invokers[1:], size)
+	moved := 0
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		before, after := tbl.lookup(key), removed.lookup(key)
+		if before != invokers[0] && before != after {
+			moved++
+		}
+	}
+	assert.Less(t, moved, 100)
+
+	assert.True(t, tbl.builtFrom(invokers))
+	assert.False(t, tbl.builtFrom(invokers[1:]))
+	assert.False(t, tbl.builtFrom(generateInvokers(0, 10)))
+}
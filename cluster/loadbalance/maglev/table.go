@@ -0,0 +1,136 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package maglev
+
+import (
+	"hash/fnv"
+	"sort"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+)
+
+// table is the lookup table of maglev, each entry is the index of the invoker which the hash keys falling into it
+// are sent to. Each invoker owns about M/N entries for a table of size M and N invokers.
+type table struct {
+	// source is the invokers the table is built from, which is compared to find the changes of the invokers
+	source   []base.Invoker
+	invokers []base.Invoker
+	entries  []int32
+}
+
+// newTable builds the table of the size, which should be a prime larger than the number of the invokers
+func newTable(invokers []base.Invoker, size int) *table {
+	t := &table{
+		source:   invokers,
+		invokers: make([]base.Invoker, len(invokers)),
+		entries:  make([]int32, size),
+	}
+	// the invokers are sorted by their addresses, so the tables built from the same invokers in any order are the same
+	copy(t.invokers, invokers)
+	sort.Slice(t.invokers, func(i, j int) bool {
+		return address(t.invokers[i]) < address(t.invokers[j])
+	})
+
+	m := uint64(size)
+	offsets := make([]uint64, len(t.invokers))
+	skips := make([]uint64, len(t.invokers))
+	for i, invoker := range t.invokers {
+		addr := address(invoker)
+		offsets[i] = hash64a(addr) % m
+		skips[i] = hash64(addr)%(m-1) + 1
+	}
+	for i := range t.entries {
+		t.entries[i] = -1
+	}
+	// the invokers fill their preferred entries in turn, the preferences of each invoker are a permutation of the
+	// table determined by its offset and skip
+	next := make([]uint64, len(t.invokers))
+	for filled := 0; ; {
+		for i := range t.invokers {
+			entry := (offsets[i] + next[i]*skips[i]) % m
+			for t.entries[entry] >= 0 {
+				next[i]++
+				entry = (offsets[i] + next[i]*skips[i]) % m
+			}
+			t.entries[entry] = int32(i)
+			next[i]++
+			if filled++; filled == size {
+				return t
+			}
+		}
+	}
+}
+
+// lookup returns the invoker of the hash key
+func (t *table) lookup(key string) base.Invoker {
+	return t.invokers[t.entries[hash64a(key)%uint64(len(t.entries))]]
+}
+
+// builtFrom returns true if the table is built from the invokers
+func (t *table) builtFrom(invokers []base.Invoker) bool {
+	if len(t.source) != len(invokers) {
+		return false
+	}
+	for i, invoker := range invokers {
+		if t.source[i] != invoker {
+			return false
+		}
+	}
+	return true
+}
+
+func address(invoker base.Invoker) string {
+	url := invoker.GetURL()
+	return url.Ip + ":" + url.Port
+}
+
+func hash64a(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+func hash64(s string) uint64 {
+	h := fnv.New64()
+	_, _ = h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// nextPrime returns the smallest prime not less than n
+func nextPrime(n int) int {
+	if n <= 2 {
+		return 2
+	}
+	if n%2 == 0 {
+		n++
+	}
+	for ; ; n += 2 {
+		isPrime := true
+		for i := 3; i*i <= n; i += 2 {
+			if n%i == 0 {
+				isPrime = false
+				break
+			}
+		}
+		if isPrime {
+			return n
+		}
+	}
+}
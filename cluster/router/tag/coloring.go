@@ -0,0 +1,122 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tag
+
+import (
+	"context"
+	"strconv"
+)
+
+import (
+	"go.opentelemetry.io/otel/baggage"
+)
+
+import (
+	clusterpkg "dubbo.apache.org/dubbo-go/v3/cluster/cluster"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+)
+
+// coloringInterceptorKey is the name of the cluster interceptor carrying the tags to the downstream calls
+const coloringInterceptorKey = "tag.coloring"
+
+func init() {
+	clusterpkg.SetClusterInterceptor(coloringInterceptorKey, newColoringInterceptor)
+}
+
+type coloringKey struct{}
+
+type coloring struct {
+	tag   string
+	force bool
+}
+
+// WithTag returns the context colored by the tag. The calls made with the context and the contexts derived from it
+// are routed to the providers serving the tag, or to the providers without any tags if there are none. The tag is
+// carried to the providers, whose downstream calls made with the contexts passed to them are colored by it too.
+func WithTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, coloringKey{}, &coloring{tag: tag})
+}
+
+// WithForceTag returns the context colored by the tag like WithTag, but the calls fail if no provider serves the tag
+func WithForceTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, coloringKey{}, &coloring{tag: tag, force: true})
+}
+
+// TagFromContext returns the tag coloring the context and whether it's forced. The tag is got from, in order,
+// WithTag or WithForceTag, the attachments of the invocation the context is passed to by the provider,
+// and the member dubbo.tag of the OpenTelemetry baggage, which is set by the edge services outside dubbo.
+func TagFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	if c, ok := ctx.Value(coloringKey{}).(*coloring); ok {
+		return c.tag, c.force
+	}
+	if tag := attachmentValue(ctx, constant.Tagkey); tag != "" {
+		force, _ := strconv.ParseBool(attachmentValue(ctx, constant.ForceUseTag))
+		return tag, force
+	}
+	bags := baggage.FromContext(ctx)
+	if tag := bags.Member(constant.Tagkey).Value(); tag != "" {
+		force, _ := strconv.ParseBool(bags.Member(constant.ForceUseTag).Value())
+		return tag, force
+	}
+	return "", false
+}
+
+// attachmentValue returns the value of the attachments in ctx, which are the attachments set by the users
+// on the consumer side or the ones of the invocation on the provider side
+func attachmentValue(ctx context.Context, key string) string {
+	switch attachments := ctx.Value(constant.AttachmentKey).(type) {
+	case map[string]string:
+		return attachments[key]
+	case map[string]any:
+		switch value := attachments[key].(type) {
+		case string:
+			return value
+		case []string:
+			// the attachments of triple are wrapped by arrays
+			if len(value) > 0 {
+				return value[0]
+			}
+		}
+	}
+	return ""
+}
+
+// coloringInterceptor attaches the tag coloring the context to the invocation before it's routed,
+// unless the invocation has been attached a tag
+type coloringInterceptor struct{}
+
+func newColoringInterceptor() clusterpkg.Interceptor {
+	return &coloringInterceptor{}
+}
+
+func (c *coloringInterceptor) Invoke(ctx context.Context, invoker base.Invoker, invocation base.Invocation) result.Result {
+	if _, ok := invocation.GetAttachment(constant.Tagkey); !ok {
+		if tag, force := TagFromContext(ctx); tag != "" {
+			invocation.SetAttachment(constant.Tagkey, tag)
+			if force {
+				invocation.SetAttachment(constant.ForceUseTag, "true")
+			}
+		}
+	}
+	return invoker.Invoke(ctx, invocation)
+}
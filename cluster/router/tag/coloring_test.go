@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tag
+
+import (
+	"context"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+import (
+	clusterpkg "dubbo.apache.org/dubbo-go/v3/cluster/cluster"
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+)
+
+func TestTagFromContext(t *testing.T) {
+	tag, force := TagFromContext(context.Background())
+	assert.Empty(t, tag)
+	assert.False(t, force)
+
+	tag, force = TagFromContext(WithForceTag(context.Background(), "gray"))
+	assert.Equal(t, "gray", tag)
+	assert.True(t, force)
+
+	// the attachments of the invocation passed to the provider
+	ctx := context.WithValue(context.Background(), constant.AttachmentKey, map[string]any{
+		constant.Tagkey:      []string{"feature-x"},
+		constant.ForceUseTag: []string{"true"},
+	})
+	tag, force = TagFromContext(ctx)
+	assert.Equal(t, "feature-x", tag)
+	assert.True(t, force)
+
+	// WithTag overrides the tag of the attachments
+	tag, force = TagFromContext(WithTag(ctx, "gray"))
+	assert.Equal(t, "gray", tag)
+	assert.False(t, force)
+
+	// the baggage set by the edge services
+	member, err := baggage.NewMember(constant.Tagkey, "feature-y")
+	assert.Nil(t, err)
+	bags, err := baggage.New(member)
+	assert.Nil(t, err)
+	tag, force = TagFromContext(baggage.ContextWithBaggage(context.Background(), bags))
+	assert.Equal(t, "feature-y", tag)
+	assert.False(t, force)
+}
+
+type tagCaptureInvoker struct {
+	base.BaseInvoker
+	tag   string
+	force string
+}
+
+func (i *tagCaptureInvoker) Invoke(_ context.Context, inv base.Invocation) result.Result {
+	i.tag = inv.GetAttachmentWithDefaultValue(constant.Tagkey, "")
+	i.force = inv.GetAttachmentWithDefaultValue(constant.ForceUseTag, "")
+	return &result.RPCResult{}
+}
+
+func TestColoringInterceptor(t *testing.T) {
+	u, _ := common.NewURL("dubbo://127.0.0.1:20000/com.xxx.xxx.UserProvider")
+	next := &tagCaptureInvoker{BaseInvoker: *base.NewBaseInvoker(u)}
+	interceptor := clusterpkg.GetClusterInterceptor(coloringInterceptorKey)
+
+	interceptor.Invoke(WithForceTag(context.Background(), "gray"), next, invocation.NewRPCInvocation("GetUser", nil, nil))
+	assert.Equal(t, "gray", next.tag)
+	assert.Equal(t, "true", next.force)
+
+	// the tag attached by the users is kept
+	inv := invocation.NewRPCInvocation("GetUser", nil, map[string]any{constant.Tagkey: "feature-x"})
+	interceptor.Invoke(WithForceTag(context.Background(), "gray"), next, inv)
+	assert.Equal(t, "feature-x", next.tag)
+	assert.Empty(t, next.force)
+
+	interceptor.Invoke(context.Background(), next, invocation.NewRPCInvocation("GetUser", nil, nil))
+	assert.Empty(t, next.tag)
+}
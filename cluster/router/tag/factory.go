@@ -29,6 +29,8 @@ func init() {
 		Tag router is not imported in dubbo-go/imports/imports.go, because it relies on config center,
 		and cause warning if config center is empty.
 		User can import this package and config config center to use tag router.
+		The package also carries the tags coloring the contexts to the downstream calls, see WithTag,
+		so it's imported by every service of the link to color the traffic.
 	*/
 	extension.SetRouterFactory(constant.TagRouterFactoryKey, NewTagRouterFactory)
 }
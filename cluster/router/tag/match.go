@@ -19,6 +19,7 @@ package tag
 
 import (
 	"strconv"
+	"strings"
 )
 
 import (
@@ -44,15 +45,23 @@ func staticTag(invokers []base.Invoker, url *common.URL, invocation base.Invocat
 	if tag, ok = invocation.GetAttachment(constant.Tagkey); !ok {
 		tag = url.GetParam(constant.Tagkey, "")
 	}
+	force := requestIsForce(url, invocation)
 	if tag != "" {
 		// match dynamic tag
-		result = filterInvokers(invokers, tag, func(invoker base.Invoker, tag any) bool {
-			return invoker.GetURL().GetParam(constant.Tagkey, "") != tag
-		})
+		result = filterInvokers(invokers, tag, notServingTag)
+		// match the fallback tags of the consumer in order
+		if len(result) == 0 && !force {
+			for _, fallback := range splitTags(url.GetParam(constant.TagFallbackKey, "")) {
+				if result = filterInvokers(invokers, fallback, notServingTag); len(result) != 0 {
+					logger.Debugf("[tag router] fallback from tag %s to %s", tag, fallback)
+					return result
+				}
+			}
+		}
 	}
 
 	// match empty tag
-	if (len(result) == 0 && !requestIsForce(url, invocation)) || tag == "" {
+	if (len(result) == 0 && !force) || tag == "" {
 		result = filterInvokers(invokers, tag, func(invoker base.Invoker, tag any) bool {
 			return invoker.GetURL().GetParam(constant.Tagkey, "") != ""
 		})
@@ -122,9 +131,7 @@ func requestTag(invokers []base.Invoker, url *common.URL, invocation base.Invoca
 	} else {
 		if len(addresses) == 0 {
 			// filter tag does not match
-			result = filterInvokers(invokers, tag, func(invoker base.Invoker, tag any) bool {
-				return invoker.GetURL().GetParam(constant.Tagkey, "") != tag
-			})
+			result = filterInvokers(invokers, tag, notServingTag)
 			logger.Debugf("[tag router] filter dynamic tag, tag=%s, invokers=%+v", tag, result)
 		} else {
 			// filter address does not match
@@ -164,6 +171,27 @@ func filterInvokers(invokers []base.Invoker, param any, predicate predicate) []b
 	return result
 }
 
+// notServingTag returns true if the invoker doesn't serve the tag, the providers declare the tags they serve
+// by the comma separated list like gray,feature-x
+func notServingTag(invoker base.Invoker, tag any) bool {
+	for _, served := range splitTags(invoker.GetURL().GetParam(constant.Tagkey, "")) {
+		if served == tag {
+			return false
+		}
+	}
+	return true
+}
+
+func splitTags(tags string) []string {
+	var result []string
+	for _, tag := range strings.Split(tags, constant.CommaSeparator) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
 func requestIsForce(url *common.URL, invocation base.Invocation) bool {
 	force := invocation.GetAttachmentWithDefaultValue(constant.ForceUseTag, url.GetParam(constant.ForceUseTag, "false"))
 	ok, err := strconv.ParseBool(force)
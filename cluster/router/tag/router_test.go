@@ -335,6 +335,42 @@ func TestRouter(t *testing.T) {
 	})
 }
 
+func TestStaticTagColoring(t *testing.T) {
+	initUrl()
+	url2.SetParam(constant.Tagkey, "gray, feature-x")
+	url3.SetParam(constant.Tagkey, "feature-y")
+	invokerList := []base.Invoker{base.NewBaseInvoker(url1), base.NewBaseInvoker(url2), base.NewBaseInvoker(url3)}
+	p, err := NewTagPriorityRouter()
+	assert.Nil(t, err)
+
+	t.Run("providerServesTags", func(t *testing.T) {
+		for _, tag := range []string{"gray", "feature-x"} {
+			attachments := map[string]any{constant.Tagkey: tag}
+			result := p.Route(invokerList, consumerUrl, invocation.NewRPCInvocation("GetUser", nil, attachments))
+			assert.Equal(t, []base.Invoker{invokerList[1]}, result)
+		}
+	})
+	t.Run("fallbackTags", func(t *testing.T) {
+		u := consumerUrl.Clone()
+		u.SetParam(constant.TagFallbackKey, "feature-z,feature-y,gray")
+		attachments := map[string]any{constant.Tagkey: "feature-w"}
+		result := p.Route(invokerList, u, invocation.NewRPCInvocation("GetUser", nil, attachments))
+		assert.Equal(t, []base.Invoker{invokerList[2]}, result)
+
+		// no fallback if the tag is forced
+		attachments[constant.ForceUseTag] = "true"
+		result = p.Route(invokerList, u, invocation.NewRPCInvocation("GetUser", nil, attachments))
+		assert.Empty(t, result)
+	})
+	t.Run("fallbackToEmptyTag", func(t *testing.T) {
+		u := consumerUrl.Clone()
+		u.SetParam(constant.TagFallbackKey, "feature-z")
+		attachments := map[string]any{constant.Tagkey: "feature-w"}
+		result := p.Route(invokerList, u, invocation.NewRPCInvocation("GetUser", nil, attachments))
+		assert.Equal(t, []base.Invoker{invokerList[0]}, result)
+	})
+}
+
 func TestNotify(t *testing.T) {
 	t.Run("normal", func(t *testing.T) {
 		p, err := NewTagPriorityRouter()
@@ -33,4 +33,12 @@ const (
 	DubboIpToRegistryKey       = "DUBBO_IP_TO_REGISTRY"
 	DubboPortToRegistryKey     = "DUBBO_PORT_TO_REGISTRY"
 	DubboDefaultPortToRegistry = "80"
+
+	ZoneEnvKey   = "DUBBO_ZONE"    // key of environment variable of the zone of the process
+	RegionEnvKey = "DUBBO_REGION"  // key of environment variable of the region of the process
+	HostIdEnvKey = "DUBBO_HOST_ID" // key of environment variable of the identity of the host sharing the unix sockets
+
+	ConfigEnvPrefix    = "DUBBO_" // prefix of environment variables overriding the configuration
+	ConfigArgPrefix    = "-D"     // prefix of command-line arguments overriding the configuration
+	ConfigOverrideRoot = "dubbo"  // root key of command-line overrides, e.g. -Ddubbo.application.name=demo
 )
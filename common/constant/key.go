@@ -103,6 +103,7 @@ const (
 	AuthProviderFilterKey                = "auth"
 	EchoFilterKey                        = "echo"
 	ExecuteLimitFilterKey                = "execute"
+	ExecutorFilterKey                    = "executor"
 	GenericFilterKey                     = "generic"
 	GenericServiceFilterKey              = "generic_service"
 	GracefulShutdownProviderFilterKey    = "pshutdown"
@@ -117,6 +118,7 @@ const (
 	TokenFilterKey                       = "token"
 	TpsLimitFilterKey                    = "tps"
 	TracingFilterKey                     = "tracing"
+	TrafficRecordFilterKey               = "traffic_record"
 	XdsCircuitBreakerKey                 = "xds_circuit_reaker"
 	OTELServerTraceKey                   = "otelServerTrace"
 	OTELClientTraceKey                   = "otelClientTrace"
@@ -146,6 +148,17 @@ const (
 	ExecuteLimitKey                    = "execute.limit"
 	DefaultExecuteLimit                = "-1"
 	ExecuteRejectedExecutionHandlerKey = "execute.limit.rejected.handler"
+	ThreadPoolKey                      = "threadpool"
+	ThreadsKey                         = "threads"
+	CoreThreadsKey                     = "corethreads"
+	QueuesKey                          = "queues"
+	ThreadPoolRejectedHandlerKey       = "threadpool.rejected.handler"
+	TrafficRecordSinkKey               = "traffic.record.sink"
+	DefaultTrafficRecordSink           = "file"
+	TrafficRecordPathKey               = "traffic.record.path"
+	DefaultTrafficRecordPath           = "traffic-record.jsonl"
+	TrafficRecordSampleRateKey         = "traffic.record.sample.rate"
+	TrafficRecordExcludeKey            = "traffic.record.exclude.attachments"
 	SerializationKey                   = "serialization"
 	PIDKey                             = "pid"
 	SyncReportKey                      = "sync.report"
@@ -172,6 +185,19 @@ const (
 	DubboGoCtxKey = DubboCtxKey("dubbogo-ctx")
 )
 
+// dubbo protocol stream keys, streams are a dubbo-go extension of the dubbo protocol which dubbo java doesn't support
+const (
+	StreamVersionKey    = "dubbo.stream.version" // version of the stream extension, sent by both sides of a stream
+	StreamVersion       = "1"
+	StreamTypeKey       = "dubbo.stream.type"   // call type of the request opening a stream
+	StreamIDKey         = "dubbo.stream.id"     // id of the request opening the stream a frame belongs to
+	StreamFrameKey      = "dubbo.stream.frame"  // type of the stream frame
+	StreamWindowKey     = "dubbo.stream.window" // flow control window, counted by messages
+	StreamSeqKey        = "dubbo.stream.seq"    // sequence of data and end frames, frames may be handled out of order
+	StreamKey           = "dubbo-stream"        // attribute key of the stream in invocation at the server side
+	DefaultStreamWindow = 64
+)
+
 // metadata report keys
 const (
 	MetadataReportNamespaceKey = "metadata-report.namespace"
@@ -206,6 +232,52 @@ const (
 	RegistryTypeAll         = "all"
 )
 
+// The keys of the locality, the providers declare their zones and regions by the url params or the metadata of
+// the service instances, which default to the environment variables DUBBO_ZONE and DUBBO_REGION
+const (
+	ZoneKey   = "zone"
+	RegionKey = "region"
+	// LocalityZoneKey and LocalityRegionKey override the locality of the consumer in the reference params
+	LocalityZoneKey   = "locality.zone"
+	LocalityRegionKey = "locality.region"
+	// LocalityThresholdKey is the ratio of the healthy providers, below which the traffic spills to the next
+	// locality in proportion, it's 0.7 by default
+	LocalityThresholdKey = "locality.threshold"
+	// LocalityLoadBalanceKey is the load balance selecting the providers in the chosen locality, it's random by default
+	LocalityLoadBalanceKey = "locality.loadbalance"
+)
+
+// The keys of the unix domain socket transport, the providers register the unix domain sockets of the protocols with
+// the identities of their hosts, the consumers on the same hosts dial the sockets instead of the tcp addresses
+const (
+	UnixSocketKey    = "unix-socket"
+	UnixSocketPrefix = "unix://"
+	// HostIdKey is the identity of the host, which is set by the environment variable DUBBO_HOST_ID and defaults
+	// to the hostname
+	HostIdKey = "host-id"
+)
+
+// Use for the protection of the addresses subscribed by consumers
+const (
+	RegistryEmptyProtectionKey      = "registry.empty.protection"
+	RegistryEmptyProtectionRatioKey = "registry.empty.protection.ratio"
+	RegistryAddressCacheKey         = "registry.address.cache"
+	RegistryAddressCacheFileKey     = "registry.address.cache.file"
+	DefaultRegistryCacheName        = "dubbo.registry"
+	DefaultRegistryCacheFileName    = "dubbo.registry."
+	DefaultRegistryCacheEntrySize   = 1000
+)
+
+// Use for the registry migration of consumers whose registry type is all
+const (
+	MigrationRuleSuffix       = ".migration"
+	MigrationRuleGroup        = "MIGRATION"
+	MigrationStepKey          = "migration.step"
+	MigrationThresholdKey     = "migration.threshold"
+	DefaultMigrationStep      = "APPLICATION_FIRST"
+	DefaultMigrationThreshold = 1.0
+)
+
 const (
 	ApplicationKey         = "application"
 	ApplicationTagKey      = "application.tag"
@@ -340,7 +412,8 @@ const (
 	MeshRouteSuffix                   = ".MESHAPPRULE"      // Specify mesh router suffix
 	ForceUseTag                       = "dubbo.force.tag"   // the tag in attachment
 	ForceUseCondition                 = "dubbo.force.condition"
-	Tagkey                            = "dubbo.tag" // key of tag
+	Tagkey                            = "dubbo.tag"          // key of tag
+	TagFallbackKey                    = "dubbo.tag.fallback" // the tags tried in order if no provider serves the tag
 	ConditionKey                      = "dubbo.condition"
 	AttachmentKey                     = DubboCtxKey("attachment") // key in context in invoker
 	TagRouterFactoryKey               = "tag"
@@ -375,6 +448,44 @@ const (
 	Consumer                    = "consumer"          // consumer
 	AccessKeyIDKey              = ".accessKeyId"      // key of access key id
 	SecretAccessKeyKey          = ".secretAccessKey"  // key of secret access key
+	RequestNonceKey             = "nonce"             // key of request nonce
+	SignatureVersionKey         = "signature.version" // key of the version of request signature, v1 if it's absent
+
+	AuthTimestampWindowKey     = "auth.timestamp.window"  // key of how far the request timestamp may be from now
+	AuthNonceKey               = "auth.nonce"             // key whether the requests carry the nonces rejected once they are reused
+	AuthNonceCacheSizeKey      = "auth.nonce.cache.size"  // key of the max number of the nonces kept by the provider
+	AuthSignatureVersionKey    = "auth.signature.version" // key of the version consumers sign with and the min version providers accept
+	SignatureV1                = "v1"                     // signature of key#method#secret#timestamp
+	SignatureV2                = "v2"                     // signature of v2#key#method#consumer#timestamp#nonce
+	DefaultAuthTimestampWindow = "5m"
+	DefaultAuthNonceCacheSize  = 100000
+)
+
+// JWT authenticator
+const (
+	JWTAuthenticator      = "jwt"                  // name of the authenticator of the bearer tokens
+	AuthorizationKey      = "authorization"        // key of the attachment carrying the bearer token
+	TokenSourceKey        = "token.source"         // key of the token source of consumers
+	DefaultTokenSource    = "static"               // name of the token source reading the token from url
+	OAuth2TokenSource     = "oauth2"               // name of the token source of oauth2 client credentials
+	JWTTokenKey           = "jwt.token"            // key of the static token
+	JWTSecretKey          = "jwt.secret"           // key of the secret verifying HS256/HS384/HS512 tokens
+	JWTPublicKeyKey       = "jwt.public.key"       // key of the PEM file of the public key verifying tokens
+	JWTJWKSFileKey        = "jwt.jwks.file"        // key of the local JWKS file verifying tokens
+	JWTIssuerKey          = "jwt.issuer"           // key of the expected issuer
+	JWTAudienceKey        = "jwt.audience"         // key of the expected audiences, separated by comma
+	JWTClockSkewKey       = "jwt.clock.skew"       // key of the tolerated clock skew of exp, nbf and iat
+	RequiredScopesKey     = "scopes"               // key of the scopes required by methods, separated by comma
+	OAuth2TokenURLKey     = "oauth2.token.url"     // key of the token endpoint of oauth2
+	OAuth2ClientIDKey     = "oauth2.client.id"     // key of the oauth2 client id
+	OAuth2ClientSecretKey = "oauth2.client.secret" // key of the oauth2 client secret
+	OAuth2ScopesKey       = "oauth2.scopes"        // key of the scopes requested by the oauth2 client, separated by comma
+)
+
+// Secret providers
+const (
+	FileSecretProvider = "file" // name of the secret provider reading files, e.g. ${file:/run/secrets/password}
+	EnvSecretProvider  = "env"  // name of the secret provider reading environment variables, e.g. ${secret:env:PASSWORD}
 )
 
 // metadata report
@@ -480,6 +591,9 @@ const (
 	DefaultMetaCacheName = "dubbo.meta"
 	DefaultMetaFileName  = "dubbo.metadata."
 	DefaultEntrySize     = 100
+	// DefaultMetaCacheTTL is the time to live of the revisions in the meta cache, the ones of the live instances
+	// are renewed on each notification
+	DefaultMetaCacheTTL = "24h"
 )
 
 // priority
@@ -498,3 +612,14 @@ const (
 	MinNacosWeight     = 0.0     // Minimum allowed weight (Nacos range starts at 0)
 	MaxNacosWeight     = 10000.0 // Maximum allowed weight (Nacos range ends at 10000)
 )
+
+// names of the graceful shutdown phases, which are reported by the readiness endpoint
+const (
+	ShutdownPhaseRunning        = "running"
+	ShutdownPhasePreStop        = "pre-stop"
+	ShutdownPhaseReadinessDrop  = "readiness-drop"
+	ShutdownPhaseUnregister     = "unregister"
+	ShutdownPhaseDrain          = "drain"
+	ShutdownPhaseCloseListeners = "close-listeners"
+	ShutdownPhaseTerminated     = "terminated"
+)
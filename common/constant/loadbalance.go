@@ -26,4 +26,22 @@ const (
 	LoadXDSRingHash                             = "xdsringhash"
 	LoadBalanceKeyInterleavedWeightedRoundRobin = "interleavedweightedroundrobin"
 	LoadBalanceKeyAliasMethod                   = "aliasmethod"
+	LoadBalanceKeyMaglev                        = "maglev"
+	LoadBalanceKeyLocality                      = "locality"
+)
+
+// The keys of the url params of the hash load balances, they are either method params or service params
+const (
+	// HashArgumentsKey is the comma separated indexes of the arguments hashed, it's 0 by default
+	HashArgumentsKey = "hash.arguments"
+	// HashAttachmentsKey is the comma separated keys of the attachments hashed, e.g. the headers of triple.
+	// The arguments are hashed if none of the attachments is present.
+	HashAttachmentsKey = "hash.attachments"
+	// HashNodesKey is the number of the virtual nodes of each invoker on the consistent hashing ring
+	HashNodesKey = "hash.nodes"
+	// HashBalanceFactorKey is the factor of the bounded loads of the consistent hashing, e.g. 1.25. The invoker
+	// of a key is skipped if its active requests exceed the factor times the average, 0 disables the bounded loads.
+	HashBalanceFactorKey = "hash.balance-factor"
+	// MaglevTableSizeKey is the size of the lookup table of maglev, it's rounded up to a prime
+	MaglevTableSizeKey = "maglev.table-size"
 )
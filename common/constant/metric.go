@@ -41,6 +41,11 @@ const (
 	TagGroup              = "group"
 	TagVersion            = "version"
 	TagErrorCode          = "error"
+	TagReason             = "reason"
+	TagZone               = "zone"
+	TagLocality           = "locality"
+	TagAddress            = "address"
+	TagMode               = "mode"
 )
 const (
 	MetricNamespace                     = "dubbo"
@@ -28,6 +28,7 @@ import (
 var (
 	authenticators    = make(map[string]func() filter.Authenticator)
 	accessKeyStorages = make(map[string]func() filter.AccessKeyStorage)
+	tokenSources      = make(map[string]func() filter.TokenSource)
 )
 
 // SetAuthenticator puts the @fcn into map with name
@@ -58,3 +59,17 @@ func GetAccessKeyStorages(name string) (filter.AccessKeyStorage, error) {
 	}
 	return f(), nil
 }
+
+// SetTokenSource sets the @fcn into map with this name
+func SetTokenSource(name string, fcn func() filter.TokenSource) {
+	tokenSources[name] = fcn
+}
+
+// GetTokenSource finds the token source with the @name
+func GetTokenSource(name string) (filter.TokenSource, error) {
+	f := tokenSources[name]
+	if f == nil {
+		return nil, errors.New("tokenSource for " + name + " is not existing, make sure you have import the package.")
+	}
+	return f(), nil
+}
@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package extension
+
+import (
+	"errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/config/interfaces"
+)
+
+var secretProviders = make(map[string]func() interfaces.SecretProvider)
+
+// SetSecretProvider sets the SecretProvider creator with @name, which is referenced by ${secret:@name:ref}
+func SetSecretProvider(name string, creator func() interfaces.SecretProvider) {
+	secretProviders[name] = creator
+}
+
+// GetSecretProvider creates the SecretProvider with @name
+func GetSecretProvider(name string) (interfaces.SecretProvider, error) {
+	creator, ok := secretProviders[name]
+	if !ok {
+		return nil, errors.New("SecretProvider for " + name + " is not existing, make sure you have import the package " +
+			"and you have register it by invoking extension.SetSecretProvider.")
+	}
+	return creator(), nil
+}
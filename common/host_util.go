@@ -18,10 +18,15 @@
 package common
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 import (
@@ -60,6 +65,103 @@ func GetLocalHostName() string {
 	return localHostname
 }
 
+// GetLocalZone returns the zone of the process set by the environment variable DUBBO_ZONE
+func GetLocalZone() string {
+	return os.Getenv(constant.ZoneEnvKey)
+}
+
+// GetLocalRegion returns the region of the process set by the environment variable DUBBO_REGION
+func GetLocalRegion() string {
+	return os.Getenv(constant.RegionEnvKey)
+}
+
+// SetLocalityParams sets the zone and the region of the process to the params of the provider url,
+// unless they are set by the users
+func SetLocalityParams(params url.Values) {
+	if zone := GetLocalZone(); zone != "" && params.Get(constant.ZoneKey) == "" {
+		params.Set(constant.ZoneKey, zone)
+	}
+	if region := GetLocalRegion(); region != "" && params.Get(constant.RegionKey) == "" {
+		params.Set(constant.RegionKey, region)
+	}
+}
+
+// GetLocalHostId returns the identity of the host set by the environment variable DUBBO_HOST_ID, which defaults to
+// the hostname. The processes sharing the unix domain sockets, e.g. the containers of a pod, have the same identity.
+func GetLocalHostId() string {
+	if hostId := os.Getenv(constant.HostIdEnvKey); hostId != "" {
+		return hostId
+	}
+	return GetLocalHostName()
+}
+
+// ParseUnixSocket returns the path of the unix domain socket address, which is unix:///path/to/socket or
+// /path/to/socket
+func ParseUnixSocket(addr string) (string, bool) {
+	path := strings.TrimPrefix(addr, constant.UnixSocketPrefix)
+	if !strings.HasPrefix(path, "/") {
+		return "", false
+	}
+	return path, true
+}
+
+// RemoveStaleUnixSocket removes the unix domain socket left by the former process, which nobody listens on anymore.
+// It returns an error if the path is not a socket or the socket is still being served.
+func RemoveStaleUnixSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return perrors.WithMessagef(err, "stat the unix socket %s", path)
+	}
+	if info.Mode().Type() != fs.ModeSocket {
+		return perrors.Errorf("%s exists but it isn't a unix socket", path)
+	}
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		_ = conn.Close()
+		return perrors.Errorf("the unix socket %s is being served by another process", path)
+	}
+	if err = os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return perrors.WithMessagef(err, "remove the stale unix socket %s", path)
+	}
+	return nil
+}
+
+// SetUnixSocketParams sets the unix domain socket to be served by the protocol and the identity of the host to the
+// params of the provider url, the protocol removes them if it fails to serve the socket. The triple protocol and the
+// dubbo protocol serve the unix domain sockets.
+func SetUnixSocketParams(url *URL, socket string) {
+	if socket == "" {
+		return
+	}
+	path, ok := ParseUnixSocket(socket)
+	if !ok {
+		logger.Warnf("The unix socket %s of the protocol %s is invalid, it should be unix:///path/to/socket", socket, url.Protocol)
+		return
+	}
+	if url.Protocol != constant.TriProtocol && url.Protocol != constant.DubboProtocol {
+		logger.Errorf("The protocol %s doesn't support the unix socket %s, only the tcp address is exported, "+
+			"use the triple protocol or the dubbo protocol to serve the unix socket", url.Protocol, socket)
+		return
+	}
+	url.SetParam(constant.UnixSocketKey, constant.UnixSocketPrefix+path)
+	url.SetParam(constant.HostIdKey, GetLocalHostId())
+}
+
+// UnixSocketOf returns the unix domain socket registered by the provider, which is only available to the consumers
+// on the same host as the provider and is preferred over the tcp address
+func UnixSocketOf(url *URL) string {
+	path, ok := ParseUnixSocket(url.GetParam(constant.UnixSocketKey, ""))
+	if !ok || url.GetParam(constant.HostIdKey, "") != GetLocalHostId() {
+		return ""
+	}
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
 func HandleRegisterIPAndPort(url *URL) {
 	// if developer define registry port and ip, use it first.
 	if ipToRegistry := os.Getenv(constant.DubboIpToRegistryKey); len(ipToRegistry) > 0 {
@@ -18,7 +18,9 @@
 package common
 
 import (
+	"net"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -70,6 +72,59 @@ func TestHandleRegisterIPAndPortWithEnvInvalidPort(t *testing.T) {
 	assert.Equal(t, constant.DubboDefaultPortToRegistry, url.Port)
 }
 
+func TestGetLocalHostId(t *testing.T) {
+	assert.Equal(t, GetLocalHostName(), GetLocalHostId())
+	t.Setenv(constant.HostIdEnvKey, "node-1")
+	assert.Equal(t, "node-1", GetLocalHostId())
+}
+
+func TestSetUnixSocketParams(t *testing.T) {
+	t.Setenv(constant.HostIdEnvKey, "node-1")
+
+	url := NewURLWithOptions(WithProtocol(constant.TriProtocol))
+	SetUnixSocketParams(url, "/tmp/tri.sock")
+	assert.Equal(t, "unix:///tmp/tri.sock", url.GetParam(constant.UnixSocketKey, ""))
+	assert.Equal(t, "node-1", url.GetParam(constant.HostIdKey, ""))
+
+	url = NewURLWithOptions(WithProtocol(constant.TriProtocol))
+	SetUnixSocketParams(url, "unix://tmp/tri.sock")
+	assert.Empty(t, url.GetParam(constant.UnixSocketKey, ""))
+
+	url = NewURLWithOptions(WithProtocol(constant.DubboProtocol))
+	SetUnixSocketParams(url, "unix:///tmp/dubbo.sock")
+	assert.Equal(t, "unix:///tmp/dubbo.sock", url.GetParam(constant.UnixSocketKey, ""))
+	assert.Equal(t, "node-1", url.GetParam(constant.HostIdKey, ""))
+
+	url = NewURLWithOptions(WithProtocol(constant.JSONRPCProtocol))
+	SetUnixSocketParams(url, "unix:///tmp/jsonrpc.sock")
+	assert.Empty(t, url.GetParam(constant.UnixSocketKey, ""))
+	assert.Empty(t, url.GetParam(constant.HostIdKey, ""))
+}
+
+func TestRemoveStaleUnixSocket(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, RemoveStaleUnixSocket(filepath.Join(dir, "absent.sock")))
+
+	// the file which isn't a socket is kept
+	file := filepath.Join(dir, "file")
+	assert.NoError(t, os.WriteFile(file, nil, 0o600))
+	assert.Error(t, RemoveStaleUnixSocket(file))
+	assert.FileExists(t, file)
+
+	// the socket being served is kept
+	path := filepath.Join(dir, "served.sock")
+	lis, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	assert.NoError(t, err)
+	assert.Error(t, RemoveStaleUnixSocket(path))
+	assert.FileExists(t, path)
+
+	// the socket nobody listens on is removed
+	lis.SetUnlinkOnClose(false)
+	assert.NoError(t, lis.Close())
+	assert.NoError(t, RemoveStaleUnixSocket(path))
+	assert.NoFileExists(t, path)
+}
+
 func TestIsValidPort(t *testing.T) {
 	assert.Equal(t, false, isValidPort(""))
 	assert.Equal(t, false, isValidPort("abc"))
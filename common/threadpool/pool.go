@@ -0,0 +1,212 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package threadpool provides the pools bounding the invocations of the providers, they are named thread pools
+// after the executors of dubbo java. Unlike the executors, the invocations run in the goroutines of the transports
+// rather than being handed off to other goroutines, since the transports run each request in its own goroutine
+// already, so the pools limit the concurrency rather than own the threads.
+package threadpool
+
+import (
+	"container/list"
+	"context"
+	"math"
+	"sync"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+const (
+	// Fixed runs at most the threads invocations concurrently, the others wait in the queue
+	Fixed = "fixed"
+	// Cached runs the invocations without bound unless the threads are configured, the invocations never wait
+	Cached = "cached"
+	// Eager runs at most the threads invocations concurrently, the others wait in the queue. It's the same as
+	// fixed since no thread is started eagerly here, and it's kept for the configurations of dubbo java.
+	Eager = "eager"
+
+	// DefaultThreads is the threads of the fixed thread pool if it isn't configured
+	DefaultThreads = 200
+)
+
+var (
+	// ErrRejected is returned if all the threads are busy and the queue is full
+	ErrRejected = perrors.New("thread pool is exhausted")
+	// ErrClosed is returned if the pool is shut down
+	ErrClosed = perrors.New("thread pool is closed")
+)
+
+// Options are the options of a Pool
+type Options struct {
+	// MaxThreads is the max number of the invocations running concurrently
+	MaxThreads int
+	// Queues is the max number of the invocations waiting for a thread, 0 means the invocations are rejected
+	// instead of waiting if all the threads are busy
+	Queues int
+}
+
+// Pool bounds the invocations running concurrently by MaxThreads, an invocation beyond it waits in the queue
+// until a running one is done, and it's rejected if the queue is full.
+type Pool struct {
+	opts Options
+
+	lock    sync.Mutex
+	running int
+	// waiters are the invocations waiting in the queue in order
+	waiters list.List
+	closed  bool
+}
+
+type waiter struct {
+	// ready is closed once the thread is granted or the pool is shut down
+	ready   chan struct{}
+	granted bool
+}
+
+// New returns the thread pool of the name, the threads and the queues which are not positive are defaulted
+// by the kind of the pool
+func New(name string, threads, queues int) (*Pool, error) {
+	if queues < 0 {
+		queues = 0
+	}
+	switch name {
+	case Fixed, Eager:
+		if threads <= 0 {
+			threads = DefaultThreads
+		}
+		return NewPool(Options{MaxThreads: threads, Queues: queues}), nil
+	case Cached:
+		if threads <= 0 {
+			threads = math.MaxInt32
+		}
+		return NewPool(Options{MaxThreads: threads}), nil
+	default:
+		return nil, perrors.Errorf("unknown thread pool %q", name)
+	}
+}
+
+// Supported returns true if the thread pool of the name is supported by New
+func Supported(name string) bool {
+	return name == Fixed || name == Cached || name == Eager
+}
+
+// NewPool returns the Pool of the options
+func NewPool(opts Options) *Pool {
+	if opts.MaxThreads <= 0 {
+		opts.MaxThreads = 1
+	}
+	if opts.Queues < 0 {
+		opts.Queues = 0
+	}
+	return &Pool{opts: opts}
+}
+
+// Acquire takes a thread for the invocation, it waits in the queue if all the threads are busy. ErrRejected is
+// returned if the queue is full, and the error of ctx if it's done while waiting. The release returned must be
+// called once the invocation is done.
+func (p *Pool) Acquire(ctx context.Context) (release func(), err error) {
+	p.lock.Lock()
+	if p.closed {
+		p.lock.Unlock()
+		return nil, ErrClosed
+	}
+	if p.running < p.opts.MaxThreads {
+		p.running++
+		p.lock.Unlock()
+		return p.releaseFunc(), nil
+	}
+	if p.waiters.Len() >= p.opts.Queues {
+		p.lock.Unlock()
+		return nil, ErrRejected
+	}
+	w := &waiter{ready: make(chan struct{})}
+	elem := p.waiters.PushBack(w)
+	p.lock.Unlock()
+
+	select {
+	case <-w.ready:
+	case <-ctx.Done():
+		p.lock.Lock()
+		if !w.granted && !p.closed {
+			p.waiters.Remove(elem)
+			p.lock.Unlock()
+			return nil, ctx.Err()
+		}
+		p.lock.Unlock()
+		<-w.ready
+	}
+	if !w.granted {
+		return nil, ErrClosed
+	}
+	release = p.releaseFunc()
+	if err = ctx.Err(); err != nil {
+		// the thread is granted just when ctx is done
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+func (p *Pool) releaseFunc() func() {
+	var once sync.Once
+	return func() {
+		once.Do(p.release)
+	}
+}
+
+// release hands the thread to the first waiting invocation, or returns it to the pool if there isn't any
+func (p *Pool) release() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if front := p.waiters.Front(); front != nil {
+		w := p.waiters.Remove(front).(*waiter)
+		w.granted = true
+		close(w.ready)
+		return
+	}
+	p.running--
+}
+
+// Threads returns the number of the invocations running
+func (p *Pool) Threads() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.running
+}
+
+// Queued returns the number of the invocations waiting for a thread
+func (p *Pool) Queued() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.waiters.Len()
+}
+
+// Shutdown stops the pool from accepting invocations, the waiting ones are rejected by ErrClosed
+// and the running ones are not affected
+func (p *Pool) Shutdown() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+	for front := p.waiters.Front(); front != nil; front = p.waiters.Front() {
+		close(p.waiters.Remove(front).(*waiter).ready)
+	}
+}
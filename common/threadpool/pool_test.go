@@ -0,0 +1,116 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package threadpool
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	p, err := New(Fixed, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, Options{MaxThreads: DefaultThreads, Queues: 10}, p.opts)
+
+	p, err = New(Cached, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, p.opts.Queues)
+
+	p, err = New(Eager, 4, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, Options{MaxThreads: 4, Queues: 10}, p.opts)
+
+	_, err = New("unknown", 0, 0)
+	assert.Error(t, err)
+}
+
+func TestAcquire(t *testing.T) {
+	p := NewPool(Options{MaxThreads: 2, Queues: 1})
+	defer p.Shutdown()
+
+	release1, err := p.Acquire(context.Background())
+	require.NoError(t, err)
+	release2, err := p.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, p.Threads())
+
+	// the invocation waits in the queue until a thread is released
+	acquired := make(chan error, 1)
+	go func() {
+		release, err := p.Acquire(context.Background())
+		if err == nil {
+			defer release()
+		}
+		acquired <- err
+	}()
+	assert.Eventually(t, func() bool { return p.Queued() == 1 }, time.Second, time.Millisecond)
+	_, err = p.Acquire(context.Background())
+	assert.ErrorIs(t, err, ErrRejected)
+
+	release1()
+	// the release is idempotent
+	release1()
+	assert.NoError(t, <-acquired)
+	release2()
+	assert.Eventually(t, func() bool { return p.Threads() == 0 }, time.Second, time.Millisecond)
+	assert.Equal(t, 0, p.Queued())
+}
+
+func TestAcquireCanceled(t *testing.T) {
+	p := NewPool(Options{MaxThreads: 1, Queues: 1})
+	defer p.Shutdown()
+	release, err := p.Acquire(context.Background())
+	require.NoError(t, err)
+
+	// the caller has gone while the invocation is queued
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = p.Acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, p.Queued())
+
+	release()
+	assert.Equal(t, 0, p.Threads())
+}
+
+func TestShutdown(t *testing.T) {
+	p := NewPool(Options{MaxThreads: 1, Queues: 1})
+	release, err := p.Acquire(context.Background())
+	require.NoError(t, err)
+
+	acquired := make(chan error, 1)
+	go func() {
+		_, err := p.Acquire(context.Background())
+		acquired <- err
+	}()
+	assert.Eventually(t, func() bool { return p.Queued() == 1 }, time.Second, time.Millisecond)
+
+	// the waiting invocations are rejected, and the running ones are not affected
+	p.Shutdown()
+	assert.ErrorIs(t, <-acquired, ErrClosed)
+	_, err = p.Acquire(context.Background())
+	assert.ErrorIs(t, err, ErrClosed)
+	release()
+	assert.Equal(t, 0, p.Threads())
+}
@@ -83,13 +83,19 @@ func compatProtocolConfig(c *global.ProtocolConfig) *config.ProtocolConfig {
 		return nil
 	}
 	return &config.ProtocolConfig{
-		Name:                 c.Name,
-		Ip:                   c.Ip,
-		Port:                 c.Port,
-		Params:               c.Params,
-		TripleConfig:         compatTripleConfig(c.TripleConfig),
-		MaxServerSendMsgSize: c.MaxServerSendMsgSize,
-		MaxServerRecvMsgSize: c.MaxServerRecvMsgSize,
+		Name:                      c.Name,
+		Ip:                        c.Ip,
+		Port:                      c.Port,
+		Params:                    c.Params,
+		ThreadPool:                c.ThreadPool,
+		Threads:                   c.Threads,
+		CoreThreads:               c.CoreThreads,
+		Queues:                    c.Queues,
+		ThreadPoolRejectedHandler: c.ThreadPoolRejectedHandler,
+		UnixSocket:                c.UnixSocket,
+		TripleConfig:              compatTripleConfig(c.TripleConfig),
+		MaxServerSendMsgSize:      c.MaxServerSendMsgSize,
+		MaxServerRecvMsgSize:      c.MaxServerRecvMsgSize,
 	}
 }
 
@@ -105,6 +111,20 @@ func compatTripleConfig(c *global.TripleConfig) *config.TripleConfig {
 
 		KeepAliveInterval: c.KeepAliveInterval,
 		KeepAliveTimeout:  c.KeepAliveTimeout,
+		ConnPool:          compatTripleConnPoolConfig(c.ConnPool),
+	}
+}
+
+// just for compat
+func compatTripleConnPoolConfig(c *global.TripleConnPoolConfig) *config.TripleConnPoolConfig {
+	if c == nil {
+		return nil
+	}
+	return &config.TripleConnPoolConfig{
+		MaxConnections:       c.MaxConnections,
+		MaxConcurrentStreams: c.MaxConcurrentStreams,
+		IdleTimeout:          c.IdleTimeout,
+		Warmup:               c.Warmup,
 	}
 }
 
@@ -147,6 +167,10 @@ func compatCenterConfig(c *global.CenterConfig) *config.CenterConfig {
 	if c == nil {
 		return nil
 	}
+	var fallbacks []*config.CenterConfig
+	for _, fallback := range c.Fallbacks {
+		fallbacks = append(fallbacks, compatCenterConfig(fallback))
+	}
 	return &config.CenterConfig{
 		Protocol:      c.Protocol,
 		Address:       c.Address,
@@ -160,6 +184,8 @@ func compatCenterConfig(c *global.CenterConfig) *config.CenterConfig {
 		Timeout:       c.Timeout,
 		Params:        c.Params,
 		FileExtension: c.FileExtension,
+		Fallbacks:     fallbacks,
+		SnapshotDir:   c.SnapshotDir,
 	}
 }
 
@@ -241,6 +267,11 @@ func compatServiceConfig(c *global.ServiceConfig) *config.ServiceConfig {
 		TpsLimitRejectedHandler:     c.TpsLimitRejectedHandler,
 		ExecuteLimit:                c.ExecuteLimit,
 		ExecuteLimitRejectedHandler: c.ExecuteLimitRejectedHandler,
+		ThreadPool:                  c.ThreadPool,
+		Threads:                     c.Threads,
+		CoreThreads:                 c.CoreThreads,
+		Queues:                      c.Queues,
+		ThreadPoolRejectedHandler:   c.ThreadPoolRejectedHandler,
 		Auth:                        c.Auth,
 		NotRegister:                 c.NotRegister,
 		ParamSign:                   c.ParamSign,
@@ -268,6 +299,11 @@ func compatMethodConfig(c *global.MethodConfig) *config.MethodConfig {
 		TpsLimitStrategy:            c.TpsLimitStrategy,
 		ExecuteLimit:                c.ExecuteLimit,
 		ExecuteLimitRejectedHandler: c.ExecuteLimitRejectedHandler,
+		ThreadPool:                  c.ThreadPool,
+		Threads:                     c.Threads,
+		CoreThreads:                 c.CoreThreads,
+		Queues:                      c.Queues,
+		ThreadPoolRejectedHandler:   c.ThreadPoolRejectedHandler,
 		Sticky:                      c.Sticky,
 		RequestTimeout:              c.RequestTimeout,
 	}
@@ -341,6 +377,12 @@ func compatMethod(m []*global.MethodConfig) []*config.MethodConfig {
 			TpsLimitStrategy:            method.TpsLimitStrategy,
 			ExecuteLimit:                method.ExecuteLimit,
 			ExecuteLimitRejectedHandler: method.ExecuteLimitRejectedHandler,
+			ThreadPool:                  method.ThreadPool,
+			Threads:                     method.Threads,
+			CoreThreads:                 method.CoreThreads,
+			Queues:                      method.Queues,
+			ThreadPoolRejectedHandler:   method.ThreadPoolRejectedHandler,
+			Scopes:                      method.Scopes,
 			Sticky:                      method.Sticky,
 			RequestTimeout:              method.RequestTimeout,
 		})
@@ -430,6 +472,8 @@ func compatShutdownConfig(c *global.ShutdownConfig) *config.ShutdownConfig {
 		RejectRequestHandler:        c.RejectRequestHandler,
 		InternalSignal:              c.InternalSignal,
 		OfflineRequestWindowTimeout: c.OfflineRequestWindowTimeout,
+		ReadinessPort:               c.ReadinessPort,
+		ReadinessPath:               c.ReadinessPath,
 		RejectRequest:               atomic.Bool{},
 	}
 	cfg.RejectRequest.Store(c.RejectRequest.Load())
@@ -536,13 +580,19 @@ func compatGlobalProtocolConfig(c *config.ProtocolConfig) *global.ProtocolConfig
 		return nil
 	}
 	return &global.ProtocolConfig{
-		Name:                 c.Name,
-		Ip:                   c.Ip,
-		Port:                 c.Port,
-		Params:               c.Params,
-		TripleConfig:         compatGlobalTripleConfig(c.TripleConfig),
-		MaxServerSendMsgSize: c.MaxServerSendMsgSize,
-		MaxServerRecvMsgSize: c.MaxServerRecvMsgSize,
+		Name:                      c.Name,
+		Ip:                        c.Ip,
+		Port:                      c.Port,
+		Params:                    c.Params,
+		ThreadPool:                c.ThreadPool,
+		Threads:                   c.Threads,
+		CoreThreads:               c.CoreThreads,
+		Queues:                    c.Queues,
+		ThreadPoolRejectedHandler: c.ThreadPoolRejectedHandler,
+		UnixSocket:                c.UnixSocket,
+		TripleConfig:              compatGlobalTripleConfig(c.TripleConfig),
+		MaxServerSendMsgSize:      c.MaxServerSendMsgSize,
+		MaxServerRecvMsgSize:      c.MaxServerRecvMsgSize,
 	}
 }
 
@@ -555,6 +605,7 @@ func compatGlobalTripleConfig(c *config.TripleConfig) *global.TripleConfig {
 		KeepAliveInterval: c.KeepAliveInterval,
 		KeepAliveTimeout:  c.KeepAliveTimeout,
 		Http3:             compatGlobalHttp3Config(c.Http3),
+		ConnPool:          compatGlobalTripleConnPoolConfig(c.ConnPool),
 
 		MaxServerSendMsgSize: c.MaxServerSendMsgSize,
 		MaxServerRecvMsgSize: c.MaxServerRecvMsgSize,
@@ -572,6 +623,19 @@ func compatGlobalHttp3Config(c *config.Http3Config) *global.Http3Config {
 	}
 }
 
+// just for compat
+func compatGlobalTripleConnPoolConfig(c *config.TripleConnPoolConfig) *global.TripleConnPoolConfig {
+	if c == nil {
+		return nil
+	}
+	return &global.TripleConnPoolConfig{
+		MaxConnections:       c.MaxConnections,
+		MaxConcurrentStreams: c.MaxConcurrentStreams,
+		IdleTimeout:          c.IdleTimeout,
+		Warmup:               c.Warmup,
+	}
+}
+
 func compatGlobalRegistryConfig(c *config.RegistryConfig) *global.RegistryConfig {
 	if c == nil {
 		return nil
@@ -619,6 +683,10 @@ func compatGlobalCenterConfig(c *config.CenterConfig) *global.CenterConfig {
 	if c == nil {
 		return nil
 	}
+	var fallbacks []*global.CenterConfig
+	for _, fallback := range c.Fallbacks {
+		fallbacks = append(fallbacks, compatGlobalCenterConfig(fallback))
+	}
 	return &global.CenterConfig{
 		Protocol:      c.Protocol,
 		Address:       c.Address,
@@ -632,6 +700,8 @@ func compatGlobalCenterConfig(c *config.CenterConfig) *global.CenterConfig {
 		Timeout:       c.Timeout,
 		Params:        c.Params,
 		FileExtension: c.FileExtension,
+		Fallbacks:     fallbacks,
+		SnapshotDir:   c.SnapshotDir,
 	}
 }
 
@@ -713,6 +783,11 @@ func compatGlobalServiceConfig(c *config.ServiceConfig) *global.ServiceConfig {
 		TpsLimitRejectedHandler:     c.TpsLimitRejectedHandler,
 		ExecuteLimit:                c.ExecuteLimit,
 		ExecuteLimitRejectedHandler: c.ExecuteLimitRejectedHandler,
+		ThreadPool:                  c.ThreadPool,
+		Threads:                     c.Threads,
+		CoreThreads:                 c.CoreThreads,
+		Queues:                      c.Queues,
+		ThreadPoolRejectedHandler:   c.ThreadPoolRejectedHandler,
 		Auth:                        c.Auth,
 		NotRegister:                 c.NotRegister,
 		ParamSign:                   c.ParamSign,
@@ -740,6 +815,11 @@ func compatGlobalMethodConfig(c *config.MethodConfig) *global.MethodConfig {
 		TpsLimitStrategy:            c.TpsLimitStrategy,
 		ExecuteLimit:                c.ExecuteLimit,
 		ExecuteLimitRejectedHandler: c.ExecuteLimitRejectedHandler,
+		ThreadPool:                  c.ThreadPool,
+		Threads:                     c.Threads,
+		CoreThreads:                 c.CoreThreads,
+		Queues:                      c.Queues,
+		ThreadPoolRejectedHandler:   c.ThreadPoolRejectedHandler,
 		Sticky:                      c.Sticky,
 		RequestTimeout:              c.RequestTimeout,
 	}
@@ -813,6 +893,12 @@ func compatGlobalMethod(m []*config.MethodConfig) []*global.MethodConfig {
 			TpsLimitStrategy:            method.TpsLimitStrategy,
 			ExecuteLimit:                method.ExecuteLimit,
 			ExecuteLimitRejectedHandler: method.ExecuteLimitRejectedHandler,
+			ThreadPool:                  method.ThreadPool,
+			Threads:                     method.Threads,
+			CoreThreads:                 method.CoreThreads,
+			Queues:                      method.Queues,
+			ThreadPoolRejectedHandler:   method.ThreadPoolRejectedHandler,
+			Scopes:                      method.Scopes,
 			Sticky:                      method.Sticky,
 			RequestTimeout:              method.RequestTimeout,
 		})
@@ -946,6 +1032,8 @@ func compatGlobalShutdownConfig(c *config.ShutdownConfig) *global.ShutdownConfig
 		RejectRequestHandler:        c.RejectRequestHandler,
 		InternalSignal:              c.InternalSignal,
 		OfflineRequestWindowTimeout: c.OfflineRequestWindowTimeout,
+		ReadinessPort:               c.ReadinessPort,
+		ReadinessPath:               c.ReadinessPath,
 		RejectRequest:               atomic.Bool{},
 	}
 	cfg.RejectRequest.Store(c.RejectRequest.Load())
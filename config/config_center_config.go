@@ -18,6 +18,7 @@
 package config
 
 import (
+	"context"
 	"net/url"
 	"strings"
 )
@@ -38,8 +39,10 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/composite"
 	"dubbo.apache.org/dubbo-go/v3/metrics"
 	metricsConfigCenter "dubbo.apache.org/dubbo-go/v3/metrics/config_center"
+	"dubbo.apache.org/dubbo-go/v3/otel/trace"
 	"dubbo.apache.org/dubbo-go/v3/remoting"
 )
 
@@ -65,6 +68,11 @@ type CenterConfig struct {
 
 	//FileExtension the suffix of config dataId, also the file extension of config content
 	FileExtension string `default:"yaml" yaml:"file-extension" json:"file-extension" `
+
+	// Fallbacks are the config centers in priority order, the configs not found in the ones before are got from them
+	Fallbacks []*CenterConfig `yaml:"fallbacks" json:"fallbacks,omitempty"`
+	// SnapshotDir persists the configs got last time, they are used when all the config centers are unavailable
+	SnapshotDir string `yaml:"snapshot-dir" json:"snapshot-dir,omitempty"`
 }
 
 // Prefix dubbo.config-center
@@ -77,6 +85,11 @@ func (c *CenterConfig) check() error {
 		return err
 	}
 	c.translateConfigAddress()
+	for _, fallback := range c.Fallbacks {
+		if err := fallback.check(); err != nil {
+			return err
+		}
+	}
 	return verify(c)
 }
 
@@ -144,7 +157,12 @@ func startConfigCenter(rc *RootConfig) error {
 		return err
 	}
 
+	_, span := trace.StartSpan(context.Background(), trace.SpanConfigCenterFetch,
+		trace.ConfigCenterKey.String(cc.Protocol),
+		trace.ConfigCenterGroupKey.String(cc.Group),
+		trace.ConfigCenterDataKey.String(cc.DataId))
 	strConf, err := dynamicConfig.GetProperties(cc.DataId, config_center.WithGroup(cc.Group))
+	trace.EndSpan(span, err)
 	if err != nil {
 		logger.Warnf("[Config Center] Dynamic config center has started, but config may not be initialized, because: %s", err)
 		return nil
@@ -156,16 +174,44 @@ func startConfigCenter(rc *RootConfig) error {
 		return nil
 	}
 	config := NewLoaderConf(WithDelim("."), WithGenre(cc.FileExtension), WithBytes([]byte(strConf)))
-	koan := GetConfigResolver(config)
+	koan, err := getConfigResolver(config)
+	if err != nil {
+		return err
+	}
 	if err = koan.UnmarshalWithConf(rc.Prefix(), rc, koanf.UnmarshalConf{Tag: "yaml"}); err != nil {
 		return err
 	}
+	setCenterConfig(koan.All())
 
 	dynamicConfig.AddListener(cc.DataId, rc, config_center.WithGroup(cc.Group))
 	return nil
 }
 
+// CreateDynamicConfiguration creates the config center, it's the composite of the config center and the fallbacks
+// if any fallback or the snapshot directory is configured. The config centers failing to start are skipped by the
+// composite one, since the configs may be got from the others or the snapshot.
 func (c *CenterConfig) CreateDynamicConfiguration() (config_center.DynamicConfiguration, error) {
+	if len(c.Fallbacks) == 0 && len(c.SnapshotDir) == 0 {
+		return c.createDynamicConfiguration()
+	}
+	var lastErr error
+	configurations := make([]config_center.DynamicConfiguration, 0, len(c.Fallbacks)+1)
+	for _, cc := range append([]*CenterConfig{c}, c.Fallbacks...) {
+		dynamicConfig, err := cc.createDynamicConfiguration()
+		if err != nil {
+			logger.Warnf("[Config Center] Start config center %s://%s error, it's skipped: %v", cc.Protocol, cc.Address, err)
+			lastErr = err
+			continue
+		}
+		configurations = append(configurations, dynamicConfig)
+	}
+	if len(configurations) == 0 && len(c.SnapshotDir) == 0 {
+		return nil, lastErr
+	}
+	return composite.NewCompositeDynamicConfiguration(c.SnapshotDir, configurations...), nil
+}
+
+func (c *CenterConfig) createDynamicConfiguration() (config_center.DynamicConfiguration, error) {
 	configCenterUrl, err := c.toURL()
 	if err != nil {
 		return nil, err
@@ -233,6 +279,16 @@ func (ccb *ConfigCenterConfigBuilder) SetGroup(group string) *ConfigCenterConfig
 	return ccb
 }
 
+func (ccb *ConfigCenterConfigBuilder) AddFallback(fallback *CenterConfig) *ConfigCenterConfigBuilder {
+	ccb.configCenterConfig.Fallbacks = append(ccb.configCenterConfig.Fallbacks, fallback)
+	return ccb
+}
+
+func (ccb *ConfigCenterConfigBuilder) SetSnapshotDir(dir string) *ConfigCenterConfigBuilder {
+	ccb.configCenterConfig.SnapshotDir = dir
+	return ccb
+}
+
 func (ccb *ConfigCenterConfigBuilder) Build() *CenterConfig {
 	return ccb.configCenterConfig
 }
@@ -0,0 +1,474 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+import (
+	"github.com/pkg/errors"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Diagnostic is a problem of the configuration found by Lint
+type Diagnostic struct {
+	File    string
+	Line    int
+	Column  int
+	Key     string
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s", d.File, d.Line, d.Column, d.Key, d.Message)
+}
+
+type lintOptions struct {
+	profile string
+	schema  []byte
+}
+
+// LintOption configures Lint
+type LintOption func(*lintOptions)
+
+// WithLintProfile sets the active profile to merge, it overrides dubbo.profiles.active in the config file
+func WithLintProfile(profile string) LintOption {
+	return func(opts *lintOptions) {
+		opts.profile = profile
+	}
+}
+
+// WithLintSchema validates the configuration against the JSON schema as well,
+// e.g. tools/dubbo-go-schema/dubbo-go.json
+func WithLintSchema(schema []byte) LintOption {
+	return func(opts *lintOptions) {
+		opts.schema = schema
+	}
+}
+
+// lintNode is a value of the configuration with the file it comes from, the mappings of the active profile
+// are merged into the ones of the config file like MergeConfig.
+type lintNode struct {
+	file     string
+	key      *yaml.Node
+	value    *yaml.Node
+	children map[string]*lintNode
+	keys     []string
+	items    []*lintNode
+}
+
+// Lint loads the yaml or json config file with the active profile merged, and reports the unknown keys,
+// the values not matching the types of RootConfig, the missing required values, and the registry and protocol
+// ids referenced but not configured. The diagnostics are sorted by their positions.
+func Lint(path string, opts ...LintOption) ([]Diagnostic, error) {
+	options := &lintOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	root, err := loadLintNode(path)
+	if err != nil {
+		return nil, err
+	}
+	profile := options.profile
+	if profile == "" {
+		if active := root.lookup("dubbo", "profiles", "active"); active != nil {
+			profile = active.value.Value
+		}
+	}
+	if getLegalActive(profile) != defaultActive {
+		ext := filepath.Ext(path)
+		profilePath := strings.TrimSuffix(path, ext) + "-" + profile + ext
+		if pathExists(profilePath) {
+			active, err := loadLintNode(profilePath)
+			if err != nil {
+				return nil, err
+			}
+			root.merge(active)
+		}
+	}
+
+	var diagnostics []Diagnostic
+	report := func(n *lintNode, key string, format string, args ...any) {
+		pos := n.value
+		if n.key != nil {
+			pos = n.key
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			File: n.file, Line: pos.Line, Column: pos.Column, Key: key, Message: fmt.Sprintf(format, args...),
+		})
+	}
+	rc := &RootConfig{}
+	for _, key := range root.keys {
+		if key != rc.Prefix() {
+			report(root.children[key], key, "unknown key")
+		}
+	}
+	if dubbo := root.children[rc.Prefix()]; dubbo != nil {
+		lintValue(reflect.TypeOf(rc), dubbo, rc.Prefix(), report)
+		lintIDs(dubbo, rc.Prefix(), report)
+	}
+	if len(options.schema) > 0 {
+		schema, err := newLintSchema(options.schema)
+		if err != nil {
+			return nil, err
+		}
+		reported := make(map[string]bool, len(diagnostics))
+		for _, d := range diagnostics {
+			reported[d.Key] = true
+		}
+		schema.validate(schema.root, root, "", func(n *lintNode, key string, format string, args ...any) {
+			if !reported[key] {
+				report(n, key, format, args...)
+			}
+		})
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i], diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return diagnostics, nil
+}
+
+type lintReporter func(n *lintNode, key string, format string, args ...any)
+
+func loadLintNode(path string) (*lintNode, error) {
+	switch ext := strings.TrimPrefix(filepath.Ext(path), "."); ext {
+	case "yaml", "yml", "json":
+	default:
+		return nil, errors.Errorf("lint doesn't support the config file suffix: %s", ext)
+	}
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err = yaml.Unmarshal(bytes, &doc); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", path)
+	}
+	if len(doc.Content) == 0 {
+		return &lintNode{file: path, value: &yaml.Node{Kind: yaml.MappingNode}, children: map[string]*lintNode{}}, nil
+	}
+	return newLintNode(path, nil, doc.Content[0]), nil
+}
+
+func newLintNode(file string, key, value *yaml.Node) *lintNode {
+	for value.Kind == yaml.AliasNode {
+		value = value.Alias
+	}
+	n := &lintNode{file: file, key: key, value: value}
+	switch value.Kind {
+	case yaml.MappingNode:
+		n.children = make(map[string]*lintNode, len(value.Content)/2)
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			k := value.Content[i].Value
+			if _, ok := n.children[k]; !ok {
+				n.keys = append(n.keys, k)
+			}
+			n.children[k] = newLintNode(file, value.Content[i], value.Content[i+1])
+		}
+	case yaml.SequenceNode:
+		for _, item := range value.Content {
+			n.items = append(n.items, newLintNode(file, nil, item))
+		}
+	}
+	return n
+}
+
+// merge merges the mappings of other into n recursively, the other values replace the ones of n
+func (n *lintNode) merge(other *lintNode) {
+	for _, k := range other.keys {
+		child := other.children[k]
+		if old, ok := n.children[k]; ok && old.children != nil && child.children != nil {
+			old.merge(child)
+			continue
+		}
+		if _, ok := n.children[k]; !ok {
+			n.keys = append(n.keys, k)
+		}
+		n.children[k] = child
+	}
+}
+
+func (n *lintNode) lookup(keys ...string) *lintNode {
+	for _, k := range keys {
+		if n == nil || n.children == nil {
+			return nil
+		}
+		n = n.children[k]
+	}
+	return n
+}
+
+func (n *lintNode) isNull() bool {
+	return n.value.Kind == yaml.ScalarNode && n.value.Tag == "!!null"
+}
+
+// lintValue checks the node against the type of the configuration
+func lintValue(t reflect.Type, n *lintNode, key string, report lintReporter) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if n.isNull() {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if n.children == nil {
+			report(n, key, "expects a mapping")
+			return
+		}
+		fields := make(map[string]reflect.StructField, t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			if name := yamlName(t.Field(i)); name != "" {
+				fields[strings.ToLower(name)] = t.Field(i)
+			}
+		}
+		lintFields(fields, n, key, "", report)
+		lintRequired(t, n, key, report)
+	case reflect.Map:
+		if n.children == nil {
+			report(n, key, "expects a mapping")
+			return
+		}
+		if t.Elem().Kind() == reflect.Interface {
+			return
+		}
+		for _, k := range n.keys {
+			lintValue(t.Elem(), n.children[k], key+"."+k, report)
+		}
+	case reflect.Slice:
+		if n.value.Kind == yaml.ScalarNode && isOverlayLeaf(t) {
+			// a single value is regarded as the slice of it, e.g. registry-ids: zk
+			lintValue(t.Elem(), n, key, report)
+			return
+		}
+		if n.value.Kind != yaml.SequenceNode {
+			report(n, key, "expects a sequence")
+			return
+		}
+		for i, item := range n.items {
+			lintValue(t.Elem(), item, fmt.Sprintf("%s[%d]", key, i), report)
+		}
+	case reflect.Interface:
+	default:
+		if n.value.Kind != yaml.ScalarNode {
+			report(n, key, "expects a scalar value")
+			return
+		}
+		if err := lintScalar(t, n.value.Value); err != nil {
+			report(n, key, "invalid value %q: %v", n.value.Value, err)
+		}
+	}
+}
+
+// lintFields checks the keys of the mapping against the fields. The fields with dots in their names, e.g.
+// tps.limit.rate, can be written in nested mappings as well since the keys are flattened by koanf.
+func lintFields(fields map[string]reflect.StructField, n *lintNode, key, prefix string, report lintReporter) {
+	for _, k := range n.keys {
+		child := n.children[k]
+		name := strings.ToLower(prefix + k)
+		if field, ok := fields[name]; ok {
+			lintValue(field.Type, child, key+"."+k, report)
+			continue
+		}
+		if child.children != nil && hasLintFieldPrefix(fields, name+".") {
+			lintFields(fields, child, key+"."+k, name+".", report)
+			continue
+		}
+		if suggestion := closestLintKey(prefix+k, fields); suggestion != "" {
+			report(child, key+"."+k, "unknown key, did you mean %q?", suggestion)
+		} else {
+			report(child, key+"."+k, "unknown key")
+		}
+	}
+}
+
+func hasLintFieldPrefix(fields map[string]reflect.StructField, prefix string) bool {
+	for name := range fields {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// lintScalar checks if the value can be converted to the type, placeholders are resolved at runtime
+func lintScalar(t reflect.Type, value string) error {
+	if strings.Contains(value, "${") {
+		return nil
+	}
+	var err error
+	switch t.Kind() {
+	case reflect.Bool:
+		_, err = strconv.ParseBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if t == reflect.TypeOf(time.Duration(0)) {
+			_, err = time.ParseDuration(value)
+		} else {
+			_, err = strconv.ParseInt(value, 10, t.Bits())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		_, err = strconv.ParseUint(value, 10, t.Bits())
+	case reflect.Float32, reflect.Float64:
+		_, err = strconv.ParseFloat(value, t.Bits())
+	}
+	if err != nil {
+		return errors.Errorf("expects a %s", t.Kind())
+	}
+	return nil
+}
+
+// lintRequired reports the scalar fields required by the validate tags and without defaults. The protocol is
+// regarded as set if the address has a scheme, e.g. zookeeper://127.0.0.1:2181.
+func lintRequired(t reflect.Type, n *lintNode, key string, report lintReporter) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := yamlName(field)
+		if name == "" || !strings.Contains(field.Tag.Get("validate"), "required") || field.Tag.Get("default") != "" ||
+			!isOverlayLeaf(field.Type) || field.Type.Kind() == reflect.Slice {
+			continue
+		}
+		if child := n.lookupFold(name); child != nil && !child.isNull() && child.value.Value != "" {
+			continue
+		}
+		if address := n.lookupFold("address"); name == "protocol" && address != nil &&
+			strings.Contains(address.value.Value, "://") {
+			continue
+		}
+		report(n, key, "%s is required", name)
+	}
+}
+
+func (n *lintNode) lookupFold(key string) *lintNode {
+	for _, k := range n.keys {
+		if strings.EqualFold(k, key) {
+			return n.children[k]
+		}
+	}
+	return nil
+}
+
+// lintIDs reports the registry and protocol ids referenced by the provider, consumer, services and references
+// but not configured
+func lintIDs(dubbo *lintNode, key string, report lintReporter) {
+	idKeys := []struct{ key, configured, kind string }{
+		{"registry-ids", "registries", "registry"},
+		{"protocol-ids", "protocols", "protocol"},
+	}
+	check := func(n *lintNode, key string) {
+		for _, idKey := range idKeys {
+			ids := n.lookup(idKey.key)
+			if ids == nil {
+				continue
+			}
+			configured := dubbo.lookup(idKey.configured)
+			for _, id := range lintIDValues(ids) {
+				if configured == nil || configured.children == nil || configured.children[id.value.Value] == nil {
+					report(id, key+"."+idKey.key, "%s %q is not configured in dubbo.%s",
+						idKey.kind, id.value.Value, idKey.configured)
+				}
+			}
+		}
+	}
+	for _, side := range []struct{ key, entries string }{{"provider", "services"}, {"consumer", "references"}} {
+		n := dubbo.lookup(side.key)
+		if n == nil || n.children == nil {
+			continue
+		}
+		check(n, key+"."+side.key)
+		if entries := n.lookup(side.entries); entries != nil {
+			for _, id := range entries.keys {
+				if entry := entries.children[id]; entry.children != nil {
+					check(entry, key+"."+side.key+"."+side.entries+"."+id)
+				}
+			}
+		}
+	}
+}
+
+// lintIDValues splits the ids in a sequence or a comma separated value, the positions of the ids are kept
+func lintIDValues(n *lintNode) []*lintNode {
+	var ids []*lintNode
+	values := n.items
+	if n.value.Kind == yaml.ScalarNode {
+		values = []*lintNode{n}
+	}
+	for _, v := range values {
+		if v.value.Kind != yaml.ScalarNode || strings.Contains(v.value.Value, "${") {
+			continue
+		}
+		for _, id := range strings.Split(v.value.Value, ",") {
+			if id = strings.TrimSpace(id); id == "" {
+				continue
+			}
+			value := *v.value
+			value.Value = id
+			ids = append(ids, &lintNode{file: v.file, value: &value})
+		}
+	}
+	return ids
+}
+
+// closestLintKey returns the known key closest to the unknown one, it's likely a typo
+func closestLintKey(key string, fields map[string]reflect.StructField) string {
+	best, bestDistance := "", 3
+	for _, field := range fields {
+		name := yamlName(field)
+		d := levenshtein(strings.ToLower(key), strings.ToLower(name))
+		if d < bestDistance || (d == bestDistance && best != "" && name < best) {
+			best, bestDistance = name, d
+		}
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,203 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+import (
+	"github.com/pkg/errors"
+
+	"gopkg.in/yaml.v3"
+)
+
+// lintSchema validates the configuration against the subset of JSON schema used by tools/dubbo-go-schema:
+// type, enum, properties, patternProperties, additionalProperties, required, items and the local $ref.
+type lintSchema struct {
+	root     map[string]any
+	patterns map[string]*regexp.Regexp
+}
+
+func newLintSchema(bytes []byte) (*lintSchema, error) {
+	s := &lintSchema{patterns: make(map[string]*regexp.Regexp)}
+	if err := json.Unmarshal(bytes, &s.root); err != nil {
+		return nil, errors.Wrap(err, "invalid JSON schema")
+	}
+	return s, nil
+}
+
+// resolve follows the $ref like #/definitions/registry
+func (s *lintSchema) resolve(schema map[string]any) map[string]any {
+	for i := 0; schema != nil && i < 32; i++ {
+		ref, ok := schema["$ref"].(string)
+		if !ok {
+			return schema
+		}
+		var target any = s.root
+		for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			m, _ := target.(map[string]any)
+			target = m[token]
+		}
+		schema, _ = target.(map[string]any)
+	}
+	return schema
+}
+
+func (s *lintSchema) pattern(expr string) *regexp.Regexp {
+	if re, ok := s.patterns[expr]; ok {
+		return re
+	}
+	re, _ := regexp.Compile(expr)
+	s.patterns[expr] = re
+	return re
+}
+
+func (s *lintSchema) validate(schema map[string]any, n *lintNode, key string, report lintReporter) {
+	schema = s.resolve(schema)
+	if schema == nil || n.isNull() {
+		return
+	}
+	if typ, ok := schema["type"]; ok && !lintSchemaType(typ, n.value) {
+		report(n, key, "expects %v by the schema", typ)
+		return
+	}
+	if enum, ok := schema["enum"].([]any); ok && n.value.Kind == yaml.ScalarNode && !strings.Contains(n.value.Value, "${") {
+		matched := false
+		for _, e := range enum {
+			matched = matched || fmt.Sprint(e) == n.value.Value
+		}
+		if !matched {
+			report(n, key, "%q is not one of %v by the schema", n.value.Value, enum)
+		}
+	}
+
+	switch n.value.Kind {
+	case yaml.MappingNode:
+		s.validateProperties(schema, n, key, "", report)
+		required, _ := schema["required"].([]any)
+		for _, r := range required {
+			if name, ok := r.(string); ok && n.children[name] == nil {
+				report(n, key, "%s is required by the schema", name)
+			}
+		}
+	case yaml.SequenceNode:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range n.items {
+				s.validate(items, item, fmt.Sprintf("%s[%d]", key, i), report)
+			}
+		}
+	}
+}
+
+// validateProperties validates the keys of the mapping, the properties with dots in their names, e.g.
+// tps.limit.rate, can be written in nested mappings as well since the keys are flattened by koanf.
+func (s *lintSchema) validateProperties(schema map[string]any, n *lintNode, key, prefix string, report lintReporter) {
+	properties, _ := schema["properties"].(map[string]any)
+	patternProperties, _ := schema["patternProperties"].(map[string]any)
+	patterns := make([]string, 0, len(patternProperties))
+	for pattern := range patternProperties {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, k := range n.keys {
+		child, childKey, name := n.children[k], lintSchemaKey(key, k), prefix+k
+		if property, ok := properties[name].(map[string]any); ok {
+			s.validate(property, child, childKey, report)
+			continue
+		}
+		if child.children != nil && hasSchemaPropertyPrefix(properties, name+".") {
+			s.validateProperties(schema, child, childKey, name+".", report)
+			continue
+		}
+		matched := false
+		for _, pattern := range patterns {
+			if re := s.pattern(pattern); re != nil && re.MatchString(name) {
+				matched = true
+				property, _ := patternProperties[pattern].(map[string]any)
+				s.validate(property, child, childKey, report)
+			}
+		}
+		if matched {
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				report(child, childKey, "unknown key by the schema")
+			}
+		case map[string]any:
+			s.validate(additional, child, childKey, report)
+		}
+	}
+}
+
+func hasSchemaPropertyPrefix(properties map[string]any, prefix string) bool {
+	for name := range properties {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func lintSchemaKey(key, k string) string {
+	if key == "" {
+		return k
+	}
+	return key + "." + k
+}
+
+// lintSchemaType checks the type of the value leniently, since the scalars are converted by koanf,
+// e.g. 3 is a valid string, and a single value is a valid array.
+func lintSchemaType(typ any, value *yaml.Node) bool {
+	if types, ok := typ.([]any); ok {
+		for _, t := range types {
+			if lintSchemaType(t, value) {
+				return true
+			}
+		}
+		return false
+	}
+	placeholder := strings.Contains(value.Value, "${")
+	switch typ {
+	case "object":
+		return value.Kind == yaml.MappingNode
+	case "array":
+		return value.Kind == yaml.SequenceNode || value.Kind == yaml.ScalarNode
+	case "string":
+		return value.Kind == yaml.ScalarNode
+	case "integer":
+		_, err := strconv.ParseInt(value.Value, 10, 64)
+		return value.Kind == yaml.ScalarNode && (err == nil || placeholder)
+	case "number":
+		_, err := strconv.ParseFloat(value.Value, 64)
+		return value.Kind == yaml.ScalarNode && (err == nil || placeholder)
+	case "boolean":
+		_, err := strconv.ParseBool(value.Value)
+		return value.Kind == yaml.ScalarNode && (err == nil || placeholder)
+	case "null":
+		return value.Tag == "!!null"
+	}
+	return true
+}
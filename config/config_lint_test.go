@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	schema "dubbo.apache.org/dubbo-go/v3/tools/dubbo-go-schema"
+)
+
+func lintResults(diagnostics []Diagnostic) []string {
+	results := make([]string, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		d.File = filepath.Base(d.File)
+		results = append(results, d.String())
+	}
+	return results
+}
+
+func TestLint(t *testing.T) {
+	diagnostics, err := Lint("./testdata/config/lint/application.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`application-dev.yaml:6:5: dubbo.consumer.check: invalid value "notabool": expects a bool`,
+		`application-dev.yaml:9:9: dubbo.consumer.references.GreeterClient.loadbalence: unknown key, did you mean "loadbalance"?`,
+		`application.yaml:9:5: dubbo.registries.nacos: protocol is required`,
+		`application.yaml:10:7: dubbo.registries.nacos.timout: unknown key, did you mean "timeout"?`,
+		`application.yaml:19:23: dubbo.provider.services.GreeterProvider.registry-ids: registry "etcd" is not configured in dubbo.registries`,
+		`application.yaml:22:13: dubbo.provider.services.GreeterProvider.protocol-ids: protocol "dubbo" is not configured in dubbo.protocols`,
+		`application.yaml:31:1: custom-key: unknown key`,
+	}, lintResults(diagnostics))
+
+	// the address of nacos is missing without the dev profile
+	diagnostics, err = Lint("./testdata/config/lint/application.yaml", WithLintProfile("prod"))
+	require.NoError(t, err)
+	assert.Len(t, diagnostics, 6)
+	assert.Contains(t, lintResults(diagnostics), `application.yaml:9:5: dubbo.registries.nacos: address is required`)
+
+	_, err = Lint("./testdata/config/overlay/application.toml")
+	assert.Error(t, err)
+}
+
+func TestLintSchema(t *testing.T) {
+	diagnostics, err := Lint("./testdata/config/lint/application.yaml", WithLintSchema(schema.Schema))
+	require.NoError(t, err)
+	assert.Contains(t, lintResults(diagnostics),
+		`application.yaml:14:7: dubbo.protocols.triple.port: expects number by the schema`)
+	// the keys reported already are not reported by the schema again
+	assert.Len(t, diagnostics, 8)
+
+	_, err = Lint("./testdata/config/lint/application.yaml", WithLintSchema([]byte("{")))
+	assert.Error(t, err)
+}
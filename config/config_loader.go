@@ -25,11 +25,14 @@ import (
 	"github.com/dubbogo/gost/log/logger"
 
 	"github.com/knadh/koanf"
+	"github.com/knadh/koanf/providers/confmap"
 )
 
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/config/secret"
 	_ "dubbo.apache.org/dubbo-go/v3/logger/core/logrus"
+	_ "dubbo.apache.org/dubbo-go/v3/logger/core/slog"
 	"dubbo.apache.org/dubbo-go/v3/logger/core/zap"
 )
 
@@ -55,13 +58,38 @@ func Load(opts ...LoaderConfOption) error {
 	} else {
 		rootConfig = conf.rc
 	}
+	if err := ApplyOverlays(rootConfig); err != nil {
+		return err
+	}
 
 	if err := rootConfig.Init(); err != nil {
 		return err
 	}
+	secret.Watch(conf.secrets, DynamicUpdateSecrets)
 	return nil
 }
 
+// DynamicUpdateSecrets dynamically updates the root config by the rotated secrets, values are the
+// flattened keys like dubbo.registries.nacos.password and the new secrets.
+func DynamicUpdateSecrets(values map[string]string) {
+	flattened := make(map[string]any, len(values))
+	for k, v := range values {
+		flattened[k] = v
+	}
+	koan := koanf.New(".")
+	if err := koan.Load(confmap.Provider(flattened, "."), nil); err != nil {
+		logger.Errorf("[Secret] failed to load the rotated secrets: %v", err)
+		return
+	}
+	updateRootConfig := &RootConfig{}
+	if err := koan.UnmarshalWithConf(rootConfig.Prefix(),
+		updateRootConfig, koanf.UnmarshalConf{Tag: "yaml"}); err != nil {
+		logger.Errorf("[Secret] failed to unmarshal the rotated secrets: %v", err)
+		return
+	}
+	rootConfig.DynamicUpdateProperties(updateRootConfig)
+}
+
 func check() error {
 	if rootConfig == nil {
 		return errors.New("execute the config.Load() method first")
@@ -38,12 +38,13 @@ import (
 )
 
 type loaderConf struct {
-	suffix string      // loaderConf file extension default yaml
-	path   string      // loaderConf file path default ./conf/dubbogo.yaml
-	delim  string      // loaderConf file delim default .
-	bytes  []byte      // config bytes
-	rc     *RootConfig // user provide rootConfig built by config api
-	name   string      // config file name
+	suffix  string            // loaderConf file extension default yaml
+	path    string            // loaderConf file path default ./conf/dubbogo.yaml
+	delim   string            // loaderConf file delim default .
+	bytes   []byte            // config bytes
+	rc      *RootConfig       // user provide rootConfig built by config api
+	name    string            // config file name
+	secrets map[string]string // raw values of the keys with secret placeholders
 }
 
 func NewLoaderConf(opts ...LoaderConfOption) *loaderConf {
@@ -209,6 +210,16 @@ func (conf *loaderConf) MergeConfig(koan *koanf.Koanf) *koanf.Koanf {
 		if err := koan.Merge(activeKoan); err != nil {
 			logger.Debugf("Config merge err %s", err)
 		}
+		// the secrets overridden by the active profile are replaced
+		for _, k := range activeKoan.Keys() {
+			delete(conf.secrets, k)
+		}
+		if len(activeConf.secrets) > 0 && conf.secrets == nil {
+			conf.secrets = make(map[string]string, len(activeConf.secrets))
+		}
+		for k, v := range activeConf.secrets {
+			conf.secrets[k] = v
+		}
 	}
 	return koan
 }
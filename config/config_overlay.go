@@ -0,0 +1,313 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	"github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config/secret"
+)
+
+// overlay is a single override of the configuration from the environment or the command line
+type overlay struct {
+	source string
+	tokens []string
+	value  string
+	// env is true if the tokens come from an environment variable, which are upper-cased
+	// and separated by underscores
+	env bool
+}
+
+// ApplyOverlays overrides the configuration root, e.g. RootConfig or dubbo.InstanceOptions, by the environment
+// variables and the command-line arguments. The configuration is layered in the following order, the latter
+// takes precedence over the former:
+//
+//  1. the config file in yaml, json, toml or properties, or the options built by API
+//  2. the config file of the active profile, e.g. dubbogo-dev.yaml
+//  3. the environment variables prefixed with DUBBO_, e.g. DUBBO_REGISTRIES_DEMO_ADDRESS=127.0.0.1:2181
+//  4. the command-line arguments in -D style, e.g. -Ddubbo.registries.demo.address=127.0.0.1:2181
+//
+// The keys are matched against the yaml tags of the configuration case-insensitively, ignoring '-', '_' and '.'.
+// Slices are split by commas, and the keys of params like maps keep the rest of the key, for example
+// DUBBO_REGISTRIES_DEMO_PARAMS_REGISTRY_TIMEOUT sets the param registry.timeout of the registry demo.
+func ApplyOverlays(root any) error {
+	return applyOverlays(root, os.Environ(), os.Args[1:])
+}
+
+func applyOverlays(root any, environ, args []string) error {
+	v := reflect.ValueOf(root)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return errors.Errorf("the root of configuration must be a pointer to struct, got %T", root)
+	}
+	for _, o := range append(envOverlays(environ), argOverlays(args)...) {
+		path, ok := resolveOverlay(v.Type(), v, o.tokens, o.env)
+		if !ok {
+			if o.env {
+				logger.Debugf("[Config Overlay] no configuration matches the environment variable %s", o.source)
+			} else {
+				logger.Warnf("[Config Overlay] no configuration matches the argument %s", o.source)
+			}
+			continue
+		}
+		value, err := secret.Resolve(o.value)
+		if err != nil {
+			return errors.Wrapf(err, "failed to override the configuration by %s", o.source)
+		}
+		if err := setOverlay(v, path, value); err != nil {
+			return errors.Wrapf(err, "failed to override the configuration by %s", o.source)
+		}
+		logger.Infof("[Config Overlay] override %s by %s", strings.Join(path, "."), o.source)
+	}
+	return nil
+}
+
+// envOverlays collects the environment variables like DUBBO_APPLICATION_NAME=demo
+func envOverlays(environ []string) []overlay {
+	var overlays []overlay
+	for _, kv := range environ {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(key, constant.ConfigEnvPrefix) || key == constant.ConfigFileEnvKey {
+			continue
+		}
+		tokens := strings.Split(strings.TrimPrefix(key, constant.ConfigEnvPrefix), "_")
+		overlays = append(overlays, overlay{source: key, tokens: tokens, value: value, env: true})
+	}
+	return overlays
+}
+
+// argOverlays collects the command-line arguments like -Ddubbo.application.name=demo
+func argOverlays(args []string) []overlay {
+	var overlays []overlay
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, constant.ConfigArgPrefix) {
+			continue
+		}
+		key, value, ok := strings.Cut(strings.TrimPrefix(arg, constant.ConfigArgPrefix), "=")
+		if !ok || !strings.HasPrefix(key, constant.ConfigOverrideRoot+".") {
+			continue
+		}
+		tokens := strings.Split(strings.TrimPrefix(key, constant.ConfigOverrideRoot+"."), ".")
+		overlays = append(overlays, overlay{source: constant.ConfigArgPrefix + key, tokens: tokens, value: value})
+	}
+	return overlays
+}
+
+// resolveOverlay finds the path of yaml names matching the tokens, v is invalid if the value doesn't exist yet
+func resolveOverlay(t reflect.Type, v reflect.Value, tokens []string, env bool) ([]string, bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		if v.IsValid() {
+			v = v.Elem()
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, isOverlayLeaf(t)
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			name := yamlName(t.Field(i))
+			if name == "" {
+				continue
+			}
+			var fv reflect.Value
+			if v.IsValid() {
+				fv = v.Field(i)
+			}
+			for n := len(tokens); n > 0; n-- {
+				if normalizeOverlayKey(name) != normalizeOverlayKey(strings.Join(tokens[:n], "")) {
+					continue
+				}
+				if path, ok := resolveOverlay(t.Field(i).Type, fv, tokens[n:], env); ok {
+					return append([]string{name}, path...), true
+				}
+			}
+		}
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, false
+		}
+		if isOverlayLeaf(t.Elem()) {
+			return []string{overlayMapKey(v, tokens, env, ".")}, true
+		}
+		for n := 1; n < len(tokens); n++ {
+			key := overlayMapKey(v, tokens[:n], env, "_")
+			var ev reflect.Value
+			if v.IsValid() && !v.IsNil() {
+				ev = v.MapIndex(reflect.ValueOf(key).Convert(t.Key()))
+			}
+			if path, ok := resolveOverlay(t.Elem(), ev, tokens[n:], env); ok {
+				return append([]string{key}, path...), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// overlayMapKey returns the existing key of the map matching the tokens, or builds a new one
+func overlayMapKey(m reflect.Value, tokens []string, env bool, sep string) string {
+	if m.IsValid() && !m.IsNil() {
+		expected := normalizeOverlayKey(strings.Join(tokens, ""))
+		for _, key := range m.MapKeys() {
+			if normalizeOverlayKey(key.String()) == expected {
+				return key.String()
+			}
+		}
+	}
+	if !env {
+		return strings.Join(tokens, ".")
+	}
+	return strings.ToLower(strings.Join(tokens, sep))
+}
+
+// setOverlay sets the value of the path, the nil pointers and maps on the way are created
+func setOverlay(v reflect.Value, path []string, value string) error {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setOverlay(v.Elem(), path, value)
+	}
+	if len(path) == 0 {
+		return setOverlayValue(v, value)
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if yamlName(v.Type().Field(i)) == path[0] {
+				return setOverlay(v.Field(i), path[1:], value)
+			}
+		}
+		return errors.Errorf("unknown field %s of %s", path[0], v.Type())
+	case reflect.Map:
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		key := reflect.ValueOf(path[0]).Convert(v.Type().Key())
+		// map elements are not addressable, modify a copy and put it back
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if old := v.MapIndex(key); old.IsValid() {
+			elem.Set(old)
+		}
+		if err := setOverlay(elem, path[1:], value); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+	}
+	return errors.Errorf("unsupported type %s", v.Type())
+}
+
+func setOverlayValue(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Type() == reflect.TypeOf(time.Duration(0)) {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return err
+			}
+			v.SetInt(int64(d))
+			return nil
+		}
+		i, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Interface:
+		v.Set(reflect.ValueOf(value))
+	case reflect.Slice:
+		items := strings.Split(value, ",")
+		s := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setOverlayValue(s.Index(i), strings.TrimSpace(item)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	default:
+		return errors.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// isOverlayLeaf returns true if the type can be set by a string
+func isOverlayLeaf(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Interface,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	case reflect.Slice:
+		return isOverlayLeaf(t.Elem()) && t.Elem().Kind() != reflect.Slice
+	}
+	return false
+}
+
+// yamlName returns the yaml name of the exported field, or empty if the field is ignored
+func yamlName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return strings.ToLower(f.Name)
+	}
+	return name
+}
+
+func normalizeOverlayKey(key string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", "_", "", ".", "").Replace(key))
+}
@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/knadh/koanf"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFileSuffix(t *testing.T) {
+	for _, path := range []string{
+		"./testdata/config/overlay/application.properties",
+		"./testdata/config/overlay/application.toml",
+	} {
+		koan := GetConfigResolver(NewLoaderConf(WithPath(path)))
+		rc := NewRootConfigBuilder().Build()
+		require.NoError(t, koan.UnmarshalWithConf(rc.Prefix(), rc, koanf.UnmarshalConf{Tag: "yaml"}), path)
+		assert.Equal(t, "overlay-demo", rc.Application.Name, path)
+		assert.Equal(t, "zookeeper", rc.Registries["zk"].Protocol, path)
+		assert.Equal(t, "127.0.0.1:2181", rc.Registries["zk"].Address, path)
+		assert.Equal(t, "3s", rc.Registries["zk"].Timeout, path)
+	}
+}
+
+func TestApplyOverlays(t *testing.T) {
+	rc := NewRootConfigBuilder().
+		AddRegistry("zk", &RegistryConfig{Protocol: "zookeeper", Address: "127.0.0.1:2181", Timeout: "3s"}).
+		Build()
+	err := applyOverlays(rc, []string{
+		"DUBBO_REGISTRIES_ZK_ADDRESS=10.0.0.1:2181",
+		"DUBBO_REGISTRIES_ZK_PARAMS_REGISTRY_EMPTY_PROTECTION=true",
+		"DUBBO_REGISTRIES_NACOS_REGISTRY_TYPE=service",
+		"DUBBO_CONFIG_CENTER_TIMEOUT=20s",
+		"DUBBO_CONSUMER_REGISTRY_IDS=zk,nacos",
+		"DUBBO_GO_CONFIG_PATH=../conf/dubbogo.yaml",
+		"DUBBO_IP_TO_REGISTRY=127.0.0.1",
+		"HOME=/root",
+	}, []string{
+		"-Ddubbo.registries.zk.address=10.0.0.2:2181",
+		"-Ddubbo.application.name=demo",
+		"-Dother.key=value",
+		"--verbose",
+	})
+	require.NoError(t, err)
+	// command-line arguments take precedence over environment variables
+	assert.Equal(t, "10.0.0.2:2181", rc.Registries["zk"].Address)
+	assert.Equal(t, "zookeeper", rc.Registries["zk"].Protocol)
+	assert.Equal(t, "true", rc.Registries["zk"].Params["registry.empty.protection"])
+	assert.Equal(t, "service", rc.Registries["nacos"].RegistryType)
+	assert.Equal(t, "20s", rc.ConfigCenter.Timeout)
+	assert.Equal(t, []string{"zk", "nacos"}, rc.Consumer.RegistryIDs)
+	assert.Equal(t, "demo", rc.Application.Name)
+
+	err = applyOverlays(rc, nil, []string{"-Ddubbo.registries.zk.weight=heavy"})
+	assert.Error(t, err)
+}
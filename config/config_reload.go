@@ -0,0 +1,279 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	_ "dubbo.apache.org/dubbo-go/v3/config_center/configurator"
+)
+
+// ReloadChange is a change of the application configuration found by reloading it from the config center
+type ReloadChange struct {
+	Key string
+	Old any
+	New any
+	// Applied is true if the change takes effect at runtime, otherwise it takes effect after a restart
+	Applied bool
+}
+
+// reloadableParams are the keys of references, services and methods which take effect at runtime,
+// they are the same as the url params.
+var reloadableParams = map[string]bool{
+	constant.TimeoutKey:                         true,
+	constant.RetriesKey:                         true,
+	constant.LoadbalanceKey:                     true,
+	constant.TPSLimiterKey:                      true,
+	constant.TPSLimitStrategyKey:                true,
+	constant.TPSLimitIntervalKey:                true,
+	constant.TPSLimitRateKey:                    true,
+	constant.TPSRejectedExecutionHandlerKey:     true,
+	constant.ExecuteLimitKey:                    true,
+	constant.ExecuteRejectedExecutionHandlerKey: true,
+	constant.ThreadPoolKey:                      true,
+	constant.ThreadsKey:                         true,
+	constant.CoreThreadsKey:                     true,
+	constant.QueuesKey:                          true,
+	constant.ThreadPoolRejectedHandlerKey:       true,
+}
+
+var (
+	reloadLock sync.Mutex
+	// centerConfig is the flattened configuration got from the config center last time
+	centerConfig map[string]any
+	// reloadedParams are the params overridden by the reloads so far, side/serviceKey -> params
+	reloadedParams = make(map[string]url.Values)
+)
+
+// reloadTarget is a reference or a service whose params are overridden by a reload
+type reloadTarget struct {
+	side          string
+	serviceKey    string
+	interfaceName string
+	params        url.Values
+}
+
+// setCenterConfig keeps the configuration got from the config center at startup to diff the later changes with
+func setCenterConfig(values map[string]any) {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+	centerConfig = values
+}
+
+// reload diffs the configuration got from the config center with the last one, the safe changes of references and
+// services are applied by overriding their urls, the others are logged as they need a restart.
+// The properties updated by DynamicUpdateProperties are regarded as applied.
+func (rc *RootConfig) reload(values map[string]any) []ReloadChange {
+	reloadLock.Lock()
+	defer reloadLock.Unlock()
+	old := centerConfig
+	centerConfig = values
+
+	keys := make([]string, 0, len(old)+len(values))
+	for k := range old {
+		keys = append(keys, k)
+	}
+	for k := range values {
+		if _, ok := old[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var changes []ReloadChange
+	targets := make(map[string]*reloadTarget)
+	for _, key := range keys {
+		if reflect.DeepEqual(old[key], values[key]) {
+			continue
+		}
+		changes = append(changes, rc.reloadChange(ReloadChange{Key: key, Old: old[key], New: values[key]}, targets)...)
+	}
+
+	for key, target := range targets {
+		params, ok := reloadedParams[key]
+		if !ok {
+			params = url.Values{}
+			reloadedParams[key] = params
+		}
+		for k := range target.params {
+			params.Set(k, target.params.Get(k))
+		}
+		overrideUrl, err := common.NewURL(fmt.Sprintf("%s://%s:0/%s?%s", constant.OverrideProtocol,
+			constant.AnyHostValue, target.interfaceName, params.Encode()))
+		if err != nil {
+			logger.Errorf("[Config Reload] failed to override %s: %v", target.serviceKey, err)
+			continue
+		}
+		config_center.SetAppConfigurator(target.side, target.serviceKey, extension.GetDefaultConfigurator(overrideUrl))
+	}
+
+	for _, change := range changes {
+		oldValue, newValue := reloadLogValue(change.Key, change.Old), reloadLogValue(change.Key, change.New)
+		if change.Applied {
+			logger.Infof("[Config Reload] %s is changed from %v to %v", change.Key, oldValue, newValue)
+		} else {
+			logger.Warnf("[Config Reload] %s is changed from %v to %v, it takes effect after a restart",
+				change.Key, oldValue, newValue)
+		}
+	}
+	return changes
+}
+
+// reloadChange classifies the change, and collects the params to override for references and services
+func (rc *RootConfig) reloadChange(change ReloadChange, targets map[string]*reloadTarget) []ReloadChange {
+	path := strings.Split(strings.TrimPrefix(change.Key, rc.Prefix()+"."), ".")
+	switch {
+	case len(path) == 2 && path[0] == "consumer" && path[1] == "request-timeout",
+		len(path) == 2 && path[0] == "logger" && path[1] == "level",
+		len(path) == 3 && path[0] == "registries" && (path[2] == "timeout" || path[2] == "username" || path[2] == "password"):
+		// updated by DynamicUpdateProperties, the removed ones keep the current values
+		change.Applied = change.New != nil
+	case len(path) > 3 && path[0] == "consumer" && path[1] == "references":
+		if rc.Consumer == nil || rc.Consumer.References[path[2]] == nil {
+			break
+		}
+		ref := rc.Consumer.References[path[2]]
+		target := getReloadTarget(targets, common.DubboRole[common.CONSUMER], ref.InterfaceName, ref.Group, ref.Version)
+		return target.override(change, path[3:])
+	case len(path) > 3 && path[0] == "provider" && path[1] == "services":
+		if rc.Provider == nil || rc.Provider.Services[path[2]] == nil {
+			break
+		}
+		svc := rc.Provider.Services[path[2]]
+		target := getReloadTarget(targets, common.DubboRole[common.PROVIDER], svc.Interface, svc.Group, svc.Version)
+		return target.override(change, path[3:])
+	}
+	return []ReloadChange{change}
+}
+
+func getReloadTarget(targets map[string]*reloadTarget, side, interfaceName, group, version string) *reloadTarget {
+	serviceKey := common.ServiceKey(interfaceName, group, version)
+	key := side + "/" + serviceKey
+	if target, ok := targets[key]; ok {
+		return target
+	}
+	target := &reloadTarget{side: side, serviceKey: serviceKey, interfaceName: interfaceName, params: url.Values{}}
+	targets[key] = target
+	return target
+}
+
+// override collects the param of the change if it's reloadable, path is the key relative to the reference or service
+func (t *reloadTarget) override(change ReloadChange, path []string) []ReloadChange {
+	key := strings.Join(path, ".")
+	switch {
+	case key == constant.MethodsKey:
+		return t.overrideMethods(change)
+	case change.New == nil:
+		// the removed ones keep the current values
+	case reloadableParams[key]:
+		t.params.Set(key, fmt.Sprint(change.New))
+		change.Applied = true
+	case path[0] == "params" && len(path) > 1:
+		t.params.Set(strings.Join(path[1:], "."), fmt.Sprint(change.New))
+		change.Applied = true
+	}
+	return []ReloadChange{change}
+}
+
+// overrideMethods diffs the method configs by their names, the reloadable keys of methods are overridden by the
+// params like methods.Greet.timeout
+func (t *reloadTarget) overrideMethods(change ReloadChange) []ReloadChange {
+	oldMethods, newMethods := methodConfigs(change.Old), methodConfigs(change.New)
+	names := make([]string, 0, len(oldMethods)+len(newMethods))
+	for name := range oldMethods {
+		names = append(names, name)
+	}
+	for name := range newMethods {
+		if _, ok := oldMethods[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []ReloadChange
+	for _, name := range names {
+		oldMethod, newMethod := oldMethods[name], newMethods[name]
+		keys := make([]string, 0, len(oldMethod)+len(newMethod))
+		for k := range oldMethod {
+			keys = append(keys, k)
+		}
+		for k := range newMethod {
+			if _, ok := oldMethod[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if k == "name" || reflect.DeepEqual(oldMethod[k], newMethod[k]) {
+				continue
+			}
+			methodChange := ReloadChange{
+				Key: change.Key + "." + name + "." + k,
+				Old: oldMethod[k],
+				New: newMethod[k],
+			}
+			if reloadableParams[k] && methodChange.New != nil {
+				t.params.Set(constant.MethodsKey+"."+name+"."+k, fmt.Sprint(methodChange.New))
+				methodChange.Applied = true
+			}
+			changes = append(changes, methodChange)
+		}
+	}
+	return changes
+}
+
+// methodConfigs indexes the method configs in the flattened configuration by their names
+func methodConfigs(value any) map[string]map[string]any {
+	methods := make(map[string]map[string]any)
+	items, _ := value.([]any)
+	for _, item := range items {
+		method, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		if name, ok := method["name"].(string); ok {
+			methods[name] = method
+		}
+	}
+	return methods
+}
+
+// reloadLogValue hides the values of credentials in logs
+func reloadLogValue(key string, value any) any {
+	lower := strings.ToLower(key)
+	if value != nil && (strings.Contains(lower, "password") || strings.Contains(lower, "secret") ||
+		strings.Contains(lower, "token")) {
+		return "******"
+	}
+	return value
+}
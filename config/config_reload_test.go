@@ -0,0 +1,158 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+const reloadBaseConfig = `
+dubbo:
+  application:
+    name: reload-demo
+  consumer:
+    request-timeout: 3s
+    references:
+      greeter:
+        timeout: 3s
+        loadbalance: random
+        methods:
+          - name: Greet
+            retries: 1
+  provider:
+    services:
+      counter:
+        tps.limit.rate: 100
+`
+
+const reloadNewConfig = `
+dubbo:
+  application:
+    name: reload-demo-v2
+  consumer:
+    request-timeout: 5s
+    references:
+      greeter:
+        timeout: 5s
+        loadbalance: p2c
+        params:
+          foo.bar: baz
+        methods:
+          - name: Greet
+            retries: 3
+            sticky: true
+      unknown:
+        timeout: 1s
+  provider:
+    services:
+      counter:
+        tps.limit.rate: 200
+        protocol-ids: triple
+`
+
+func centerValues(t *testing.T, content string) map[string]any {
+	koan := GetConfigResolver(NewLoaderConf(WithBytes([]byte(content))))
+	require.NotNil(t, koan)
+	return koan.All()
+}
+
+func reloadRootConfig() *RootConfig {
+	return &RootConfig{
+		Consumer: &ConsumerConfig{
+			RequestTimeout: "3s",
+			References:     map[string]*ReferenceConfig{"greeter": {InterfaceName: "com.reload.Greeter"}},
+		},
+		Provider: &ProviderConfig{
+			Services: map[string]*ServiceConfig{"counter": {Interface: "com.reload.Counter", Group: "g", Version: "1.0"}},
+		},
+	}
+}
+
+func TestReload(t *testing.T) {
+	rc := reloadRootConfig()
+	setCenterConfig(centerValues(t, reloadBaseConfig))
+	changes := rc.reload(centerValues(t, reloadNewConfig))
+
+	applied := make(map[string]bool)
+	for _, change := range changes {
+		applied[change.Key] = change.Applied
+	}
+	assert.Equal(t, map[string]bool{
+		"dubbo.application.name":                                  false,
+		"dubbo.consumer.request-timeout":                          true,
+		"dubbo.consumer.references.greeter.timeout":               true,
+		"dubbo.consumer.references.greeter.loadbalance":           true,
+		"dubbo.consumer.references.greeter.params.foo.bar":        true,
+		"dubbo.consumer.references.greeter.methods.Greet.retries": true,
+		"dubbo.consumer.references.greeter.methods.Greet.sticky":  false,
+		"dubbo.consumer.references.unknown.timeout":               false,
+		"dubbo.provider.services.counter.tps.limit.rate":          true,
+		"dubbo.provider.services.counter.protocol-ids":            false,
+	}, applied)
+
+	consumerUrl, _ := common.NewURL("dubbo://127.0.0.1:20000/com.reload.Greeter?side=consumer&timeout=3s")
+	for _, c := range config_center.GetAppConfigurators(common.DubboRole[common.CONSUMER], "com.reload.Greeter") {
+		c.Configure(consumerUrl)
+	}
+	assert.Equal(t, "5s", consumerUrl.GetParam("timeout", ""))
+	assert.Equal(t, "p2c", consumerUrl.GetParam("loadbalance", ""))
+	assert.Equal(t, "baz", consumerUrl.GetParam("foo.bar", ""))
+	assert.Equal(t, "3", consumerUrl.GetParam("methods.Greet.retries", ""))
+
+	providerUrl, _ := common.NewURL("tri://127.0.0.1:20000/com.reload.Counter?side=provider&group=g&version=1.0")
+	for _, c := range config_center.GetAppConfigurators(common.DubboRole[common.PROVIDER], "g/com.reload.Counter:1.0") {
+		c.Configure(providerUrl)
+	}
+	assert.Equal(t, "200", providerUrl.GetParam("tps.limit.rate", ""))
+
+	// the removed keys keep the current values, and the overrides accumulate across reloads
+	changes = rc.reload(centerValues(t, strings.Replace(reloadNewConfig, "        params:\n          foo.bar: baz\n", "", 1)))
+	require.Len(t, changes, 1)
+	assert.Equal(t, "dubbo.consumer.references.greeter.params.foo.bar", changes[0].Key)
+	assert.False(t, changes[0].Applied)
+	consumerUrl, _ = common.NewURL("dubbo://127.0.0.1:20000/com.reload.Greeter?side=consumer")
+	for _, c := range config_center.GetAppConfigurators(common.DubboRole[common.CONSUMER], "com.reload.Greeter") {
+		c.Configure(consumerUrl)
+	}
+	assert.Equal(t, "baz", consumerUrl.GetParam("foo.bar", ""))
+}
+
+func TestProcessDeletedConfig(t *testing.T) {
+	rc := reloadRootConfig()
+	setCenterConfig(centerValues(t, reloadBaseConfig))
+	rc.Process(&config_center.ConfigChangeEvent{Value: reloadNewConfig, ConfigType: remoting.EventTypeUpdate})
+	assert.Equal(t, "5s", rc.Consumer.RequestTimeout)
+
+	// the deleted config doesn't change the current values
+	assert.NotPanics(t, func() {
+		rc.Process(&config_center.ConfigChangeEvent{ConfigType: remoting.EventTypeDel})
+	})
+	assert.Equal(t, "5s", rc.Consumer.RequestTimeout)
+}
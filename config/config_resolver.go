@@ -26,6 +26,7 @@ import (
 
 	"github.com/knadh/koanf"
 	"github.com/knadh/koanf/parsers/json"
+	"github.com/knadh/koanf/parsers/toml"
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/confmap"
 	"github.com/knadh/koanf/providers/rawbytes"
@@ -35,10 +36,22 @@ import (
 
 import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant/file"
+	"dubbo.apache.org/dubbo-go/v3/config/parsers/properties"
+	"dubbo.apache.org/dubbo-go/v3/config/secret"
 )
 
-// GetConfigResolver get config resolver
+// GetConfigResolver get config resolver, it panics if the config fails to be parsed or resolved
 func GetConfigResolver(conf *loaderConf) *koanf.Koanf {
+	k, err := getConfigResolver(conf)
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// getConfigResolver get config resolver, it returns the error if the config fails to be parsed or resolved, e.g.
+// the config pushed by the config center
+func getConfigResolver(conf *loaderConf) (*koanf.Koanf, error) {
 	var (
 		k   *koanf.Koanf
 		err error
@@ -51,7 +64,7 @@ func GetConfigResolver(conf *loaderConf) *koanf.Koanf {
 	}
 	bytes := conf.bytes
 	if len(bytes) <= 0 {
-		panic(errors.New("bytes is nil,please set bytes or file path"))
+		return nil, errors.New("bytes is nil,please set bytes or file path")
 	}
 	k = koanf.New(conf.delim)
 
@@ -60,14 +73,31 @@ func GetConfigResolver(conf *loaderConf) *koanf.Koanf {
 		err = k.Load(rawbytes.Provider(bytes), yaml.Parser())
 	case "json":
 		err = k.Load(rawbytes.Provider(bytes), json.Parser())
+	case "toml":
+		err = k.Load(rawbytes.Provider(bytes), toml.Parser())
+	case "properties":
+		err = k.Load(rawbytes.Provider(bytes), properties.Parser())
 	default:
 		err = errors.Errorf("no support %s file suffix", conf.suffix)
 	}
 
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+	if conf.secrets, err = resolveSecrets(k); err != nil {
+		return nil, err
+	}
+	return resolvePlaceholder(k), nil
+}
+
+// resolveSecrets replaces the secret placeholders like ${secret:vault:path#key} with the secrets,
+// and returns the raw values of the keys with secrets
+func resolveSecrets(resolver *koanf.Koanf) (map[string]string, error) {
+	resolved, raw, err := secret.ResolveAll(resolver.All())
+	if err != nil || len(resolved) == 0 {
+		return raw, err
 	}
-	return resolvePlaceholder(k)
+	return raw, resolver.Load(confmap.Provider(resolved, resolver.Delim()), nil)
 }
 
 // resolvePlaceholder replace ${xx} with real value
@@ -27,6 +27,11 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+import (
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
 func TestResolvePlaceHolder(t *testing.T) {
 	t.Run("test resolver", func(t *testing.T) {
 		conf := NewLoaderConf(WithPath("./testdata/config/resolver/application.yaml"))
@@ -46,3 +51,53 @@ func TestResolvePlaceHolder(t *testing.T) {
 
 	})
 }
+
+func TestResolveSecrets(t *testing.T) {
+	t.Setenv("CONFIG_TEST_NACOS_PASSWORD", "nacos-password")
+	conf := NewLoaderConf(WithBytes([]byte(`
+dubbo:
+  registries:
+    nacos:
+      address: 127.0.0.1:8848
+      username: ${NACOS_USERNAME:nacos}
+      password: ${secret:env:CONFIG_TEST_NACOS_PASSWORD}
+`)))
+	koan := GetConfigResolver(conf)
+	assert.Equal(t, "nacos-password", koan.String("dubbo.registries.nacos.password"))
+	assert.Equal(t, "nacos", koan.String("dubbo.registries.nacos.username"))
+	assert.Equal(t, map[string]string{
+		"dubbo.registries.nacos.password": "${secret:env:CONFIG_TEST_NACOS_PASSWORD}",
+	}, conf.secrets)
+
+	rc := NewRootConfigBuilder().Build()
+	err := koan.UnmarshalWithConf(rc.Prefix(), rc, koanf.UnmarshalConf{Tag: "yaml"})
+	assert.Nil(t, err)
+	rc.DynamicUpdateProperties(&RootConfig{Registries: map[string]*RegistryConfig{
+		"nacos": {Password: "rotated-password"},
+		"zk":    {Password: "unknown"},
+	}})
+	assert.Equal(t, "rotated-password", rc.Registries["nacos"].Password)
+	assert.Equal(t, "nacos", rc.Registries["nacos"].Username)
+}
+
+func TestGetConfigResolverUnresolvedSecret(t *testing.T) {
+	content := `
+dubbo:
+  registries:
+    nacos:
+      address: 127.0.0.1:8848
+      password: ${secret:absent:nacos#password}
+`
+	_, err := getConfigResolver(NewLoaderConf(WithBytes([]byte(content))))
+	assert.Error(t, err)
+	assert.Panics(t, func() {
+		GetConfigResolver(NewLoaderConf(WithBytes([]byte(content))))
+	})
+
+	// the config pushed by the config center is ignored instead of crashing
+	rc := NewRootConfigBuilder().Build()
+	assert.NotPanics(t, func() {
+		rc.Process(&config_center.ConfigChangeEvent{Key: "dubbo.yaml", Value: content, ConfigType: remoting.EventTypeUpdate})
+	})
+	assert.Empty(t, rc.Registries)
+}
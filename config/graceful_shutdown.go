@@ -32,6 +32,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/internal"
 )
 
 /*
@@ -71,6 +72,8 @@ func gracefulShutdownInit() {
 		filter.Set(constant.GracefulShutdownFilterShutdownConfig, GetShutDown())
 	}
 
+	internal.GracefulShutdownServeReadiness(GetShutDown().ReadinessPort, GetShutDown().ReadinessPath)
+
 	if GetShutDown().GetInternalSignal() {
 		signals := make(chan os.Signal, 1)
 		signal.Notify(signals, ShutdownSignals...)
@@ -96,17 +99,27 @@ func gracefulShutdownInit() {
 	}
 }
 
-// BeforeShutdown provides processing flow before shutdown
+// BeforeShutdown provides processing flow before shutdown, the phases are entered as graceful_shutdown does
 func BeforeShutdown() {
+	// run the pre-stop hooks registered by users
+	internal.GracefulShutdownEnterPhase(constant.ShutdownPhasePreStop)
+
+	// make readiness probes and health checks fail so that the traffic is moved away
+	internal.GracefulShutdownEnterPhase(constant.ShutdownPhaseReadinessDrop)
+	internal.HealthShutdown()
+
+	internal.GracefulShutdownEnterPhase(constant.ShutdownPhaseUnregister)
 	destroyAllRegistries()
 	// waiting for a short time so that the clients have enough time to get the notification that server shutdowns
 	// The value of configuration depends on how long the clients will get notification.
 	waitAndAcceptNewRequests()
 
 	// reject sending/receiving the new request, but keeping waiting for accepting requests
+	internal.GracefulShutdownEnterPhase(constant.ShutdownPhaseDrain)
 	waitForSendingAndReceivingRequests()
 
 	// destroy all protocols
+	internal.GracefulShutdownEnterPhase(constant.ShutdownPhaseCloseListeners)
 	destroyProtocols()
 
 	logger.Info("Graceful shutdown --- Execute the custom callbacks.")
@@ -114,6 +127,7 @@ func BeforeShutdown() {
 	for callback := customCallbacks.Front(); callback != nil; callback = callback.Next() {
 		callback.Value.(func())()
 	}
+	internal.GracefulShutdownEnterPhase(constant.ShutdownPhaseTerminated)
 }
 
 func destroyAllRegistries() {
@@ -69,6 +69,10 @@ type ShutdownConfig struct {
 	InternalSignal *bool `default:"true" yaml:"internal-signal" json:"internal.signal,omitempty" property:"internal.signal"`
 	// offline request window length
 	OfflineRequestWindowTimeout string `yaml:"offline-request-window-timeout" json:"offlineRequestWindowTimeout,omitempty" property:"offlineRequestWindowTimeout"`
+	// port of the http readiness endpoint, the endpoint is disabled if it is empty
+	ReadinessPort string `yaml:"readiness-port" json:"readiness-port,omitempty" property:"readiness-port"`
+	// path of the http readiness endpoint
+	ReadinessPath string `default:"/ready" yaml:"readiness-path" json:"readiness-path,omitempty" property:"readiness-path"`
 	// true -> new request will be rejected.
 	RejectRequest atomic.Bool
 	// active invocation
@@ -177,3 +181,13 @@ func (scb *ShutdownConfigBuilder) SetOfflineRequestWindowTimeout(offlineRequestW
 	scb.shutdownConfig.OfflineRequestWindowTimeout = offlineRequestWindowTimeout
 	return scb
 }
+
+func (scb *ShutdownConfigBuilder) SetReadinessPort(readinessPort string) *ShutdownConfigBuilder {
+	scb.shutdownConfig.ReadinessPort = readinessPort
+	return scb
+}
+
+func (scb *ShutdownConfigBuilder) SetReadinessPath(readinessPath string) *ShutdownConfigBuilder {
+	scb.shutdownConfig.ReadinessPath = readinessPath
+	return scb
+}
@@ -117,7 +117,15 @@ func (l *LoggerConfig) toURL() *common.URL {
 
 // DynamicUpdateProperties dynamically update properties.
 func (l *LoggerConfig) DynamicUpdateProperties(new *LoggerConfig) {
-
+	if l == nil || new == nil || new.Level == "" || new.Level == l.Level {
+		return
+	}
+	if !logger.SetLoggerLevel(new.Level) {
+		logger.Warnf("LoggerConfig's Level %s can't be dynamically updated by the current logger", new.Level)
+		return
+	}
+	l.Level = new.Level
+	logger.Infof("LoggerConfig's Level was dynamically updated, new value:%v", l.Level)
 }
 
 type LoggerConfigBuilder struct {
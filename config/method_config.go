@@ -44,6 +44,12 @@ type MethodConfig struct {
 	TpsLimitStrategy            string `yaml:"tps.limit.strategy" json:"tps.limit.strategy,omitempty" property:"tps.limit.strategy"`
 	ExecuteLimit                string `yaml:"execute.limit" json:"execute.limit,omitempty" property:"execute.limit"`
 	ExecuteLimitRejectedHandler string `yaml:"execute.limit.rejected.handler" json:"execute.limit.rejected.handler,omitempty" property:"execute.limit.rejected.handler"`
+	ThreadPool                  string `yaml:"threadpool" json:"threadpool,omitempty" property:"threadpool"`
+	Threads                     string `yaml:"threads" json:"threads,omitempty" property:"threads"`
+	CoreThreads                 string `yaml:"corethreads" json:"corethreads,omitempty" property:"corethreads"`
+	Queues                      string `yaml:"queues" json:"queues,omitempty" property:"queues"`
+	ThreadPoolRejectedHandler   string `yaml:"threadpool.rejected.handler" json:"threadpool.rejected.handler,omitempty" property:"threadpool.rejected.handler"`
+	Scopes                      string `yaml:"scopes" json:"scopes,omitempty" property:"scopes"`
 	Sticky                      bool   `yaml:"sticky"   json:"sticky,omitempty" property:"sticky"`
 	RequestTimeout              string `yaml:"timeout"  json:"timeout,omitempty" property:"timeout"`
 }
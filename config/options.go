@@ -19,6 +19,7 @@ package config
 
 import (
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -94,6 +95,44 @@ func WithExecuteLimitRejectedHandler(handler string) MethodOption {
 	}
 }
 
+// WithThreadPool runs the method in its own thread pool, it's one of fixed, cached and eager.
+func WithThreadPool(threadPool string) MethodOption {
+	return func(opts *MethodOptions) {
+		opts.Method.ThreadPool = threadPool
+	}
+}
+
+func WithThreads(threads int) MethodOption {
+	return func(opts *MethodOptions) {
+		opts.Method.Threads = strconv.Itoa(threads)
+	}
+}
+
+func WithCoreThreads(coreThreads int) MethodOption {
+	return func(opts *MethodOptions) {
+		opts.Method.CoreThreads = strconv.Itoa(coreThreads)
+	}
+}
+
+func WithQueues(queues int) MethodOption {
+	return func(opts *MethodOptions) {
+		opts.Method.Queues = strconv.Itoa(queues)
+	}
+}
+
+func WithThreadPoolRejectedHandler(handler string) MethodOption {
+	return func(opts *MethodOptions) {
+		opts.Method.ThreadPoolRejectedHandler = handler
+	}
+}
+
+// WithScopes requires the bearer tokens of the invocations of the method to be granted all the scopes
+func WithScopes(scopes ...string) MethodOption {
+	return func(opts *MethodOptions) {
+		opts.Method.Scopes = strings.Join(scopes, ",")
+	}
+}
+
 func WithSticky() MethodOption {
 	return func(opts *MethodOptions) {
 		opts.Method.Sticky = true
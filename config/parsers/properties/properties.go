@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package properties implements a koanf.Parser that parses java style properties bytes as conf maps.
+package properties
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+import (
+	"github.com/knadh/koanf/maps"
+
+	"github.com/magiconair/properties"
+)
+
+const delim = "."
+
+// Properties implements a properties parser, the dotted keys are parsed as nested maps.
+type Properties struct{}
+
+// Parser returns a properties Parser.
+func Parser() *Properties {
+	return &Properties{}
+}
+
+// Unmarshal parses the given properties bytes. ${key} expressions are kept as they are, which are
+// resolved by the config resolver later.
+func (p *Properties) Unmarshal(b []byte) (map[string]any, error) {
+	loader := &properties.Loader{Encoding: properties.UTF8, DisableExpansion: true}
+	props, err := loader.LoadBytes(b)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]any, props.Len())
+	for k, v := range props.Map() {
+		m[k] = v
+	}
+	return maps.Unflatten(m, delim), nil
+}
+
+// Marshal marshals the given config map to properties bytes.
+func (p *Properties) Marshal(o map[string]any) ([]byte, error) {
+	flat, _ := maps.Flatten(o, nil, delim)
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var buf bytes.Buffer
+	for _, k := range keys {
+		fmt.Fprintf(&buf, "%s=%v\n", k, flat[k])
+	}
+	return buf.Bytes(), nil
+}
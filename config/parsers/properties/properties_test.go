@@ -0,0 +1,51 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package properties
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProperties(t *testing.T) {
+	m, err := Parser().Unmarshal([]byte(`
+# registries
+dubbo.registries.demo.protocol=zookeeper
+dubbo.registries.demo.address = ${ZK_ADDRESS:127.0.0.1:2181}
+dubbo.application.name: demo
+`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"dubbo": map[string]any{
+			"registries": map[string]any{
+				"demo": map[string]any{"protocol": "zookeeper", "address": "${ZK_ADDRESS:127.0.0.1:2181}"},
+			},
+			"application": map[string]any{"name": "demo"},
+		},
+	}, m)
+
+	b, err := Parser().Marshal(m)
+	require.NoError(t, err)
+	assert.Equal(t, "dubbo.application.name=demo\n"+
+		"dubbo.registries.demo.address=${ZK_ADDRESS:127.0.0.1:2181}\n"+
+		"dubbo.registries.demo.protocol=zookeeper\n", string(b))
+}
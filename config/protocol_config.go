@@ -32,6 +32,20 @@ type ProtocolConfig struct {
 	Port   string `default:"50051" yaml:"port" json:"port,omitempty" property:"port"`
 	Params any    `yaml:"params" json:"params,omitempty" property:"params"`
 
+	// ThreadPool, Threads, CoreThreads, Queues and ThreadPoolRejectedHandler configure the thread pools of
+	// the services exported by the protocol, they are overridden by the ones of the services. CoreThreads is
+	// kept for the configurations of dubbo java, it takes no effect since the thread pools don't own threads.
+	ThreadPool                string `yaml:"threadpool" json:"threadpool,omitempty" property:"threadpool"`
+	Threads                   string `yaml:"threads" json:"threads,omitempty" property:"threads"`
+	CoreThreads               string `yaml:"corethreads" json:"corethreads,omitempty" property:"corethreads"`
+	Queues                    string `yaml:"queues" json:"queues,omitempty" property:"queues"`
+	ThreadPoolRejectedHandler string `yaml:"threadpool.rejected.handler" json:"threadpool.rejected.handler,omitempty" property:"threadpool.rejected.handler"`
+
+	// UnixSocket is the unix domain socket served besides the tcp address, e.g. unix:///var/run/dubbo/tri.sock,
+	// it's registered with the provider urls and only dialed by the consumers on the same host.
+	// The triple protocol and the dubbo protocol serve it, except the dubbo protocol with TLS.
+	UnixSocket string `yaml:"unix-socket" json:"unix-socket,omitempty" property:"unix-socket"`
+
 	TripleConfig *TripleConfig `yaml:"triple" json:"triple,omitempty" property:"triple"`
 
 	// MaxServerSendMsgSize max size of server send message, 1mb=1000kb=1000000b 1mib=1024kb=1048576b.
@@ -19,6 +19,7 @@ package config
 
 import (
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -192,6 +193,10 @@ func (c *RegistryConfig) toURLs(roleType common.RoleType) ([]*common.URL, error)
 		if registryURL, err = c.createNewURL(constant.ServiceRegistryProtocol, address, roleType); err == nil {
 			urls = append(urls, registryURL)
 		}
+		// consumers subscribe to both of them by the migration invoker of registry protocol
+		if roleType == common.CONSUMER {
+			break
+		}
 		if registryURL, err = c.createNewURL(constant.RegistryProtocol, address, roleType); err == nil {
 			urls = append(urls, registryURL)
 		}
@@ -488,4 +493,24 @@ func (c *RegistryConfig) DynamicUpdateProperties(updateRegistryConfig *RegistryC
 		c.Timeout = updateRegistryConfig.Timeout
 		logger.Infof("RegistryConfigs Timeout was dynamically updated, new value:%v", c.Timeout)
 	}
+	// credentials are updated once the secrets are rotated
+	if updateRegistryConfig != nil && updateRegistryConfig.Username != "" && updateRegistryConfig.Username != c.Username {
+		c.Username = updateRegistryConfig.Username
+		logger.Infof("RegistryConfigs Username was dynamically updated")
+	}
+	if updateRegistryConfig != nil && updateRegistryConfig.Password != "" && updateRegistryConfig.Password != c.Password {
+		c.Password = updateRegistryConfig.Password
+		logger.Infof("RegistryConfigs Password was dynamically updated")
+	}
+}
+
+// UpdateRegistryCredentials re-creates the clients of the live registries configured by the registry id with the
+// rotated credentials, since updating the config doesn't affect the clients created already
+func UpdateRegistryCredentials(registryId, username, password string) {
+	if !slices.Contains(extension.GetAllProtocolNames(), constant.RegistryProtocol) {
+		return
+	}
+	if updater, ok := extension.GetProtocol(constant.RegistryProtocol).(registry.RegistryCredentialsUpdater); ok {
+		updater.UpdateRegistryCredentials(registryId, username, password)
+	}
 }
@@ -80,6 +80,11 @@ func TestRegistryTypeAll(t *testing.T) {
 	urls := LoadRegistries(target, regs, common.PROVIDER)
 	assert.Equal(t, 2, len(urls))
 	assert.Equal(t, "service-discovery-registry://127.0.0.2:2181", urls[0].PrimitiveURL)
+
+	// consumers subscribe to both by the migration invoker
+	urls = LoadRegistries(target, regs, common.CONSUMER)
+	assert.Equal(t, 1, len(urls))
+	assert.Equal(t, constant.RegistryTypeAll, urls[0].GetParam(constant.RegistryTypeKey, ""))
 }
 
 func TestTranslateRegistryAddress(t *testing.T) {
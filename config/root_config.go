@@ -35,6 +35,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/config_center"
 	"dubbo.apache.org/dubbo-go/v3/registry/exposed_tmp"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
 )
 
 var (
@@ -350,19 +351,39 @@ func (rb *RootConfigBuilder) Build() *RootConfig {
 // Process receive changing listener's event, dynamic update config
 func (rc *RootConfig) Process(event *config_center.ConfigChangeEvent) {
 	logger.Infof("CenterConfig process event:\n%+v", event)
-	config := NewLoaderConf(WithBytes([]byte(event.Value.(string))))
-	koan := GetConfigResolver(config)
-
-	updateRootConfig := &RootConfig{}
-	if err := koan.UnmarshalWithConf(rc.Prefix(),
-		updateRootConfig, koanf.UnmarshalConf{Tag: "yaml"}); err != nil {
-		logger.Errorf("CenterConfig process unmarshalConf failed, got error %#v", err)
-		return
+	// the deleted config is regarded as empty, the changes found are logged and keep the current values
+	values := make(map[string]any)
+	if content, _ := event.Value.(string); event.ConfigType != remoting.EventTypeDel && content != "" {
+		config := NewLoaderConf(WithBytes([]byte(content)))
+		koan, err := getConfigResolver(config)
+		if err != nil {
+			logger.Errorf("CenterConfig process the config failed, keep the current one, got error %v", err)
+			return
+		}
+
+		updateRootConfig := &RootConfig{}
+		if err := koan.UnmarshalWithConf(rc.Prefix(),
+			updateRootConfig, koanf.UnmarshalConf{Tag: "yaml"}); err != nil {
+			logger.Errorf("CenterConfig process unmarshalConf failed, got error %#v", err)
+			return
+		}
+		rc.DynamicUpdateProperties(updateRootConfig)
+		values = koan.All()
 	}
+	rc.reload(values)
+}
+
+// DynamicUpdateProperties dynamically update the properties supporting dynamic updates
+func (rc *RootConfig) DynamicUpdateProperties(updateRootConfig *RootConfig) {
 	// dynamically update register
 	for registerId, updateRegister := range updateRootConfig.Registries {
-		register := rc.Registries[registerId]
-		register.DynamicUpdateProperties(updateRegister)
+		if register, ok := rc.Registries[registerId]; ok {
+			username, password := register.Username, register.Password
+			register.DynamicUpdateProperties(updateRegister)
+			if register.Username != username || register.Password != password {
+				UpdateRegistryCredentials(registerId, register.Username, register.Password)
+			}
+		}
 	}
 	// dynamically update consumer
 	rc.Consumer.DynamicUpdateProperties(updateRootConfig.Consumer)
@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secret
+
+import (
+	"os"
+	"strings"
+)
+
+import (
+	"github.com/magiconair/properties"
+
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/config/interfaces"
+)
+
+func init() {
+	extension.SetSecretProvider(constant.FileSecretProvider, func() interfaces.SecretProvider {
+		return &fileProvider{}
+	})
+	extension.SetSecretProvider(constant.EnvSecretProvider, func() interfaces.SecretProvider {
+		return &envProvider{}
+	})
+}
+
+// fileProvider reads the secret from a file, e.g. the secrets mounted by kubernetes. The whole content
+// without the trailing line break is the secret, unless ref is in the form of path#key, in which case
+// the file is parsed as properties and the value of key is the secret.
+type fileProvider struct{}
+
+// GetSecret reads the secret from the file
+func (p *fileProvider) GetSecret(ref string) (string, error) {
+	path, key, hasKey := strings.Cut(ref, "#")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if !hasKey {
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+	loader := &properties.Loader{Encoding: properties.UTF8, DisableExpansion: true}
+	props, err := loader.LoadBytes(content)
+	if err != nil {
+		return "", err
+	}
+	value, ok := props.Get(key)
+	if !ok {
+		return "", perrors.Errorf("no key %s in %s", key, path)
+	}
+	return value, nil
+}
+
+// envProvider reads the secret from the environment variable named ref
+type envProvider struct{}
+
+// GetSecret reads the secret from the environment variable
+func (p *envProvider) GetSecret(ref string) (string, error) {
+	value, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", perrors.Errorf("environment variable %s is not set", ref)
+	}
+	return value, nil
+}
@@ -0,0 +1,238 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package secret resolves the secret placeholders in config values by the secret providers, for example:
+//
+//	registries:
+//	  nacos:
+//	    password: ${secret:vault:secret/data/nacos#password}
+//	    username: ${file:/run/secrets/nacos-username}
+//
+// ${file:path} is the shorthand of ${secret:file:path}. The providers are registered by
+// extension.SetSecretProvider, and the file and env providers are built in. The resolved secrets are cached
+// and refreshed periodically, the changes are propagated to the watchers.
+package secret
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/config/interfaces"
+)
+
+// DefaultRefreshInterval is the default interval to refresh the cached secrets
+const DefaultRefreshInterval = time.Minute
+
+// placeholderPattern matches ${secret:provider:ref} and ${file:ref}
+var placeholderPattern = regexp.MustCompile(`\$\{(?:secret:([\w.-]+):|(file):)([^}]+)}`)
+
+// Watcher is notified with the keys and the new values once the secrets in their raw values change
+type Watcher func(values map[string]string)
+
+type watch struct {
+	raw     map[string]string
+	watcher Watcher
+}
+
+var (
+	lock            sync.Mutex
+	providers       = make(map[string]interfaces.SecretProvider)
+	cache           = make(map[string]string) // placeholder -> secret
+	watches         []*watch
+	refreshInterval = DefaultRefreshInterval
+	refreshOnce     sync.Once
+)
+
+// Contains returns true if there are secret placeholders in value
+func Contains(value string) bool {
+	return placeholderPattern.MatchString(value)
+}
+
+// Resolve replaces the secret placeholders in value with the secrets
+func Resolve(value string) (string, error) {
+	if !Contains(value) {
+		return value, nil
+	}
+	lock.Lock()
+	resolved, err := resolveLocked(value)
+	lock.Unlock()
+	if err == nil {
+		startRefresh()
+	}
+	return resolved, err
+}
+
+func resolveLocked(value string) (string, error) {
+	var err error
+	resolved := placeholderPattern.ReplaceAllStringFunc(value, func(placeholder string) string {
+		if err != nil {
+			return placeholder
+		}
+		if secret, ok := cache[placeholder]; ok {
+			return secret
+		}
+		var secret string
+		if secret, err = fetchLocked(placeholder); err != nil {
+			return placeholder
+		}
+		cache[placeholder] = secret
+		return secret
+	})
+	return resolved, err
+}
+
+// fetchLocked gets the secret of the placeholder from its provider
+func fetchLocked(placeholder string) (string, error) {
+	match := placeholderPattern.FindStringSubmatch(placeholder)
+	name, ref := match[1]+match[2], strings.TrimSpace(match[3])
+	provider, ok := providers[name]
+	if !ok {
+		var err error
+		if provider, err = extension.GetSecretProvider(name); err != nil {
+			return "", err
+		}
+		providers[name] = provider
+	}
+	secret, err := provider.GetSecret(ref)
+	if err != nil {
+		return "", perrors.Wrapf(err, "failed to resolve the secret %s", placeholder)
+	}
+	return secret, nil
+}
+
+// ResolveAll resolves the secret placeholders in the string values, it returns the resolved values and the raw values
+// of the keys with secrets.
+func ResolveAll(values map[string]any) (map[string]any, map[string]string, error) {
+	resolved := make(map[string]any)
+	raw := make(map[string]string)
+	for k, v := range values {
+		s, ok := v.(string)
+		if !ok || !Contains(s) {
+			continue
+		}
+		secret, err := Resolve(s)
+		if err != nil {
+			return nil, nil, perrors.Wrapf(err, "failed to resolve %s", k)
+		}
+		resolved[k], raw[k] = secret, s
+	}
+	return resolved, raw, nil
+}
+
+// Watch notifies the watcher once the secrets in the raw values change, raw is the keys and their raw values
+// returned by ResolveAll.
+func Watch(raw map[string]string, watcher Watcher) {
+	if len(raw) == 0 {
+		return
+	}
+	lock.Lock()
+	watches = append(watches, &watch{raw: raw, watcher: watcher})
+	lock.Unlock()
+	startRefresh()
+}
+
+// startRefresh refreshes the cached secrets periodically once any secret is resolved
+func startRefresh() {
+	refreshOnce.Do(func() {
+		go func() {
+			for {
+				lock.Lock()
+				interval := refreshInterval
+				lock.Unlock()
+				if interval <= 0 {
+					return
+				}
+				time.Sleep(interval)
+				refresh()
+			}
+		}()
+	})
+}
+
+// SetRefreshInterval sets the interval to refresh the cached secrets, zero or negative disables the refresh.
+// It takes effect after the current interval.
+func SetRefreshInterval(interval time.Duration) {
+	lock.Lock()
+	defer lock.Unlock()
+	refreshInterval = interval
+}
+
+// refresh gets all the cached secrets again, and notifies the watchers of the changed ones
+func refresh() {
+	type notification struct {
+		watcher Watcher
+		values  map[string]string
+	}
+	var notifications []notification
+	func() {
+		lock.Lock()
+		defer lock.Unlock()
+		changed := make(map[string]bool)
+		for placeholder, old := range cache {
+			secret, err := fetchLocked(placeholder)
+			if err != nil {
+				logger.Warnf("[Secret] keep the cached secret of %s: %v", placeholder, err)
+				continue
+			}
+			if secret != old {
+				cache[placeholder] = secret
+				changed[placeholder] = true
+			}
+		}
+		if len(changed) == 0 {
+			return
+		}
+		for _, w := range watches {
+			values := make(map[string]string)
+			for k, v := range w.raw {
+				if !containsAny(v, changed) {
+					continue
+				}
+				if secret, err := resolveLocked(v); err == nil {
+					values[k] = secret
+				}
+			}
+			if len(values) > 0 {
+				notifications = append(notifications, notification{watcher: w.watcher, values: values})
+			}
+		}
+	}()
+	// notify without the lock since the watchers may resolve secrets as well
+	for _, n := range notifications {
+		n.watcher(n.values)
+	}
+}
+
+func containsAny(value string, placeholders map[string]bool) bool {
+	for _, placeholder := range placeholderPattern.FindAllString(value, -1) {
+		if placeholders[placeholder] {
+			return true
+		}
+	}
+	return false
+}
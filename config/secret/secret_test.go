@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secret
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/config/interfaces"
+)
+
+type mockProvider map[string]string
+
+func (p mockProvider) GetSecret(ref string) (string, error) {
+	return p[ref], nil
+}
+
+func TestResolve(t *testing.T) {
+	SetRefreshInterval(0)
+	dir := t.TempDir()
+	password := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(password, []byte("file-secret\n"), 0o600))
+	keys := filepath.Join(dir, "keys.properties")
+	require.NoError(t, os.WriteFile(keys, []byte("ak=access\nsk=secret\n"), 0o600))
+	t.Setenv("SECRET_TEST_TOKEN", "env-secret")
+
+	value, err := Resolve("${file:" + password + "}")
+	require.NoError(t, err)
+	assert.Equal(t, "file-secret", value)
+	value, err = Resolve("${secret:file:" + keys + "#ak}/${secret:env:SECRET_TEST_TOKEN}")
+	require.NoError(t, err)
+	assert.Equal(t, "access/env-secret", value)
+	value, err = Resolve("${registry.address:127.0.0.1}")
+	require.NoError(t, err)
+	assert.Equal(t, "${registry.address:127.0.0.1}", value)
+
+	_, err = Resolve("${secret:unknown:path}")
+	assert.Error(t, err)
+	_, err = Resolve("${secret:env:SECRET_TEST_UNSET}")
+	assert.Error(t, err)
+	_, err = Resolve("${secret:file:" + keys + "#unknown}")
+	assert.Error(t, err)
+}
+
+func TestWatch(t *testing.T) {
+	SetRefreshInterval(0)
+	provider := mockProvider{"nacos#password": "v1"}
+	extension.SetSecretProvider("mock", func() interfaces.SecretProvider { return provider })
+
+	resolved, raw, err := ResolveAll(map[string]any{
+		"dubbo.registries.nacos.password": "${secret:mock:nacos#password}",
+		"dubbo.registries.nacos.address":  "127.0.0.1:8848",
+		"dubbo.registries.nacos.weight":   1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"dubbo.registries.nacos.password": "v1"}, resolved)
+	assert.Equal(t, map[string]string{"dubbo.registries.nacos.password": "${secret:mock:nacos#password}"}, raw)
+
+	var notified map[string]string
+	Watch(raw, func(values map[string]string) {
+		notified = values
+	})
+	refresh()
+	assert.Nil(t, notified)
+
+	provider["nacos#password"] = "v2"
+	refresh()
+	assert.Equal(t, map[string]string{"dubbo.registries.nacos.password": "v2"}, notified)
+	value, err := Resolve("${secret:mock:nacos#password}")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", value)
+}
@@ -42,6 +42,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/common/threadpool"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/protocolwrapper"
 )
@@ -71,6 +72,11 @@ type ServiceConfig struct {
 	TpsLimitRejectedHandler     string            `yaml:"tps.limit.rejected.handler" json:"tps.limit.rejected.handler,omitempty" property:"tps.limit.rejected.handler"`
 	ExecuteLimit                string            `yaml:"execute.limit" json:"execute.limit,omitempty" property:"execute.limit"`
 	ExecuteLimitRejectedHandler string            `yaml:"execute.limit.rejected.handler" json:"execute.limit.rejected.handler,omitempty" property:"execute.limit.rejected.handler"`
+	ThreadPool                  string            `yaml:"threadpool" json:"threadpool,omitempty" property:"threadpool"`
+	Threads                     string            `yaml:"threads" json:"threads,omitempty" property:"threads"`
+	CoreThreads                 string            `yaml:"corethreads" json:"corethreads,omitempty" property:"corethreads"`
+	Queues                      string            `yaml:"queues" json:"queues,omitempty" property:"queues"`
+	ThreadPoolRejectedHandler   string            `yaml:"threadpool.rejected.handler" json:"threadpool.rejected.handler,omitempty" property:"threadpool.rejected.handler"`
 	Auth                        string            `yaml:"auth" json:"auth,omitempty" property:"auth"`
 	NotRegister                 bool              `yaml:"not_register" json:"not_register,omitempty" property:"not_register"`
 	ParamSign                   string            `yaml:"param.sign" json:"param.sign,omitempty" property:"param.sign"`
@@ -177,6 +183,9 @@ func (s *ServiceConfig) check() error {
 			panic(err)
 		}
 	}
+	if s.ThreadPool != "" && !threadpool.Supported(s.ThreadPool) {
+		return fmt.Errorf("[ServiceConfig] Unknown threadpool %s for service %s, please check your configuration", s.ThreadPool, s.Interface)
+	}
 
 	if s.TpsLimitInterval != "" {
 		tpsLimitInterval, err := strconv.ParseInt(s.TpsLimitInterval, 0, 0)
@@ -288,6 +297,8 @@ func (s *ServiceConfig) Export() error {
 			common.WithParamsValue(constant.MaxServerSendMsgSize, protocolConf.MaxServerSendMsgSize),
 			common.WithParamsValue(constant.MaxServerRecvMsgSize, protocolConf.MaxServerRecvMsgSize),
 		)
+		setProtocolThreadPool(ivkURL, protocolConf)
+		common.SetUnixSocketParams(ivkURL, protocolConf.UnixSocket)
 		info := GetProviderServiceInfo(s.id)
 		if info != nil {
 			ivkURL.SetAttribute(constant.ServiceInfoKey, info)
@@ -363,6 +374,21 @@ func loadProtocol(protocolIds []string, protocols map[string]*ProtocolConfig) []
 	return returnProtocols
 }
 
+// setProtocolThreadPool sets the thread pool configuration of the protocol which isn't configured by the service
+func setProtocolThreadPool(ivkURL *common.URL, protocolConf *ProtocolConfig) {
+	for key, value := range map[string]string{
+		constant.ThreadPoolKey:                protocolConf.ThreadPool,
+		constant.ThreadsKey:                   protocolConf.Threads,
+		constant.CoreThreadsKey:               protocolConf.CoreThreads,
+		constant.QueuesKey:                    protocolConf.Queues,
+		constant.ThreadPoolRejectedHandlerKey: protocolConf.ThreadPoolRejectedHandler,
+	} {
+		if len(value) > 0 && len(ivkURL.GetParam(key, "")) == 0 {
+			ivkURL.SetParam(key, value)
+		}
+	}
+}
+
 // Unexport will call unexport of all exporters service config exported
 func (s *ServiceConfig) Unexport() {
 	if !s.exported.Load() {
@@ -422,6 +448,7 @@ func (s *ServiceConfig) getUrlMap() url.Values {
 	urlMap.Set(constant.AppVersionKey, ac.Version)
 	urlMap.Set(constant.OwnerKey, ac.Owner)
 	urlMap.Set(constant.EnvironmentKey, ac.Environment)
+	common.SetLocalityParams(urlMap)
 
 	// filter
 	var filters string
@@ -452,6 +479,13 @@ func (s *ServiceConfig) getUrlMap() url.Values {
 	urlMap.Set(constant.ExecuteLimitKey, s.ExecuteLimit)
 	urlMap.Set(constant.ExecuteRejectedExecutionHandlerKey, s.ExecuteLimitRejectedHandler)
 
+	// executor filter
+	urlMap.Set(constant.ThreadPoolKey, s.ThreadPool)
+	urlMap.Set(constant.ThreadsKey, s.Threads)
+	urlMap.Set(constant.CoreThreadsKey, s.CoreThreads)
+	urlMap.Set(constant.QueuesKey, s.Queues)
+	urlMap.Set(constant.ThreadPoolRejectedHandlerKey, s.ThreadPoolRejectedHandler)
+
 	// auth filter
 	urlMap.Set(constant.ServiceAuthKey, s.Auth)
 	urlMap.Set(constant.ParameterSignatureEnableKey, s.ParamSign)
@@ -472,6 +506,14 @@ func (s *ServiceConfig) getUrlMap() url.Values {
 
 		urlMap.Set(constant.ExecuteLimitKey, v.ExecuteLimit)
 		urlMap.Set(constant.ExecuteRejectedExecutionHandlerKey, v.ExecuteLimitRejectedHandler)
+
+		urlMap.Set(prefix+constant.ThreadPoolKey, v.ThreadPool)
+		urlMap.Set(prefix+constant.ThreadsKey, v.Threads)
+		urlMap.Set(prefix+constant.CoreThreadsKey, v.CoreThreads)
+		urlMap.Set(prefix+constant.QueuesKey, v.Queues)
+		urlMap.Set(prefix+constant.ThreadPoolRejectedHandlerKey, v.ThreadPoolRejectedHandler)
+
+		urlMap.Set(prefix+constant.RequiredScopesKey, v.Scopes)
 	}
 
 	return urlMap
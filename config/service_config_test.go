@@ -79,10 +79,13 @@ func TestNewServiceConfigBuilder(t *testing.T) {
 
 		serviceConfig.Methods = []*MethodConfig{
 			{
-				Name:    "Say",
-				Retries: "3",
+				Name:       "Say",
+				Retries:    "3",
+				ThreadPool: "cached",
 			},
 		}
+		serviceConfig.ThreadPool = "fixed"
+		serviceConfig.Threads = "10"
 
 		err := serviceConfig.Init(rc)
 		assert.NoError(t, err)
@@ -114,7 +117,17 @@ func TestNewServiceConfigBuilder(t *testing.T) {
 		values := serviceConfig.getUrlMap()
 		assert.Equal(t, values.Get("methods.Say.weight"), "0")
 		assert.Equal(t, values.Get("methods.Say.tps.limit.rate"), "")
-		assert.Equal(t, values.Get(constant.ServiceFilterKey), "echo,token,accesslog,tps,generic_service,execute,pshutdown")
+		assert.Equal(t, values.Get(constant.ServiceFilterKey), "echo,token,accesslog,tps,generic_service,execute,executor,pshutdown")
+		assert.Equal(t, "fixed", values.Get(constant.ThreadPoolKey))
+		assert.Equal(t, "10", values.Get(constant.ThreadsKey))
+		assert.Equal(t, "cached", values.Get("methods.Say."+constant.ThreadPoolKey))
+	})
+
+	t.Run("setProtocolThreadPool", func(t *testing.T) {
+		ivkURL := common.NewURLWithOptions(common.WithParams(serviceConfig.getUrlMap()))
+		setProtocolThreadPool(ivkURL, &ProtocolConfig{ThreadPool: "eager", Queues: "100"})
+		assert.Equal(t, "fixed", ivkURL.GetParam(constant.ThreadPoolKey, ""))
+		assert.Equal(t, "100", ivkURL.GetParam(constant.QueuesKey, ""))
 	})
 
 	t.Run("Implement", func(t *testing.T) {
@@ -29,4 +29,6 @@ type TripleConfig struct {
 
 	KeepAliveInterval string `yaml:"keep-alive-interval" json:"keep-alive-interval,omitempty" property:"keep-alive-interval"`
 	KeepAliveTimeout  string `yaml:"keep-alive-timeout" json:"keep-alive-timeout,omitempty" property:"keep-alive-timeout"`
+
+	ConnPool *TripleConnPoolConfig `yaml:"conn-pool" json:"conn-pool,omitempty" property:"conn-pool"`
 }
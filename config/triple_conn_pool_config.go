@@ -0,0 +1,31 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+// TripleConnPoolConfig represents the config of the connection pools of the triple clients over HTTP/2
+type TripleConnPoolConfig struct {
+	// MaxConnections is the max number of the connections to an address, 1 by default
+	MaxConnections int `yaml:"max-connections" json:"max-connections,omitempty" property:"max-connections"`
+	// MaxConcurrentStreams is the max number of the concurrent streams of a connection before a new one is opened,
+	// 0 by default which means the one advertised by the server
+	MaxConcurrentStreams int `yaml:"max-concurrent-streams" json:"max-concurrent-streams,omitempty" property:"max-concurrent-streams"`
+	// IdleTimeout is how long an idle connection is kept, the idle connections are never closed by default
+	IdleTimeout string `yaml:"idle-timeout" json:"idle-timeout,omitempty" property:"idle-timeout"`
+	// Warmup is the number of the connections opened when the invoker is created
+	Warmup int `yaml:"warmup" json:"warmup,omitempty" property:"warmup"`
+}
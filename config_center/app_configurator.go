@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config_center
+
+import (
+	"sync"
+)
+
+// AppConfiguratorListener is notified with the service key once the app configurator of the service changes
+type AppConfiguratorListener func(serviceKey string)
+
+type appConfiguratorListener struct {
+	side     string
+	listener AppConfiguratorListener
+}
+
+var (
+	appConfiguratorsLock     sync.RWMutex
+	appConfigurators         = make(map[string]Configurator) // side/serviceKey -> configurator
+	appConfiguratorListeners = make(map[*appConfiguratorListener]struct{})
+)
+
+// SetAppConfigurator sets the configurator of the application configuration reloaded from the config center,
+// side is consumer for references and provider for services. The listeners of the side are notified.
+func SetAppConfigurator(side, serviceKey string, configurator Configurator) {
+	appConfiguratorsLock.Lock()
+	appConfigurators[side+"/"+serviceKey] = configurator
+	var listeners []AppConfiguratorListener
+	for l := range appConfiguratorListeners {
+		if l.side == side {
+			listeners = append(listeners, l.listener)
+		}
+	}
+	appConfiguratorsLock.Unlock()
+
+	for _, listener := range listeners {
+		listener(serviceKey)
+	}
+}
+
+// GetAppConfigurators returns the configurators of the application configuration of the service
+func GetAppConfigurators(side, serviceKey string) []Configurator {
+	appConfiguratorsLock.RLock()
+	defer appConfiguratorsLock.RUnlock()
+	if configurator, ok := appConfigurators[side+"/"+serviceKey]; ok {
+		return []Configurator{configurator}
+	}
+	return nil
+}
+
+// AddAppConfiguratorListener adds the listener of the app configurators of the side, it returns the function
+// to remove the listener.
+func AddAppConfiguratorListener(side string, listener AppConfiguratorListener) func() {
+	l := &appConfiguratorListener{side: side, listener: listener}
+	appConfiguratorsLock.Lock()
+	appConfiguratorListeners[l] = struct{}{}
+	appConfiguratorsLock.Unlock()
+	return func() {
+		appConfiguratorsLock.Lock()
+		delete(appConfiguratorListeners, l)
+		appConfiguratorsLock.Unlock()
+	}
+}
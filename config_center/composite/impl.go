@@ -0,0 +1,355 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package composite
+
+import (
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/go-zookeeper/zk"
+
+	gxset "github.com/dubbogo/gost/container/set"
+	"github.com/dubbogo/gost/log/logger"
+
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/parser"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsConfigCenter "dubbo.apache.org/dubbo-go/v3/metrics/config_center"
+)
+
+// defaultSnapshotGroup is the snapshot directory of the configs without group
+const defaultSnapshotGroup = "_default"
+
+// CompositeDynamicConfiguration layers the config centers by their priorities, a config is got from the first one
+// which has it. The configs got are persisted to the snapshot directory, and they are served from there if all
+// the config centers are unavailable.
+type CompositeDynamicConfiguration struct {
+	config_center.BaseDynamicConfiguration
+	// configurations are in priority order, the first one is the highest
+	configurations []config_center.DynamicConfiguration
+	snapshotDir    string
+	parser         parser.ConfigurationParser
+
+	lock      sync.Mutex
+	listeners map[listenerKey][]*layerListener
+	// stale are the configs served from the snapshot
+	stale map[string]bool
+}
+
+type listenerKey struct {
+	key      string
+	group    string
+	listener config_center.ConfigurationListener
+}
+
+// NewCompositeDynamicConfiguration returns the composite of the config centers in priority order, the snapshot is
+// disabled if snapshotDir is empty
+func NewCompositeDynamicConfiguration(snapshotDir string, configurations ...config_center.DynamicConfiguration) *CompositeDynamicConfiguration {
+	return &CompositeDynamicConfiguration{
+		configurations: configurations,
+		snapshotDir:    snapshotDir,
+		listeners:      make(map[listenerKey][]*layerListener),
+		stale:          make(map[string]bool),
+	}
+}
+
+// Configurations returns the config centers in priority order
+func (c *CompositeDynamicConfiguration) Configurations() []config_center.DynamicConfiguration {
+	return c.configurations
+}
+
+// Parser Get Parser
+func (c *CompositeDynamicConfiguration) Parser() parser.ConfigurationParser {
+	return c.parser
+}
+
+// SetParser sets the parser of all the config centers
+func (c *CompositeDynamicConfiguration) SetParser(p parser.ConfigurationParser) {
+	c.parser = p
+	for _, dc := range c.configurations {
+		dc.SetParser(p)
+	}
+}
+
+// AddListener adds the listener to all the config centers, the changes shadowed by the config centers of higher
+// priorities are not notified
+func (c *CompositeDynamicConfiguration) AddListener(key string, listener config_center.ConfigurationListener,
+	opts ...config_center.Option) {
+	lk := listenerKey{key: key, group: config_center.NewOptions(opts...).Center.Group, listener: listener}
+	shared := &compositeListener{composite: c, key: key, group: lk.group, opts: opts, listener: listener}
+	layers := make([]*layerListener, 0, len(c.configurations))
+	for i, dc := range c.configurations {
+		l := &layerListener{compositeListener: shared, index: i}
+		dc.AddListener(key, l, opts...)
+		layers = append(layers, l)
+	}
+	c.lock.Lock()
+	c.listeners[lk] = append(c.listeners[lk], layers...)
+	c.lock.Unlock()
+}
+
+// RemoveListener removes the listener from all the config centers
+func (c *CompositeDynamicConfiguration) RemoveListener(key string, listener config_center.ConfigurationListener,
+	opts ...config_center.Option) {
+	lk := listenerKey{key: key, group: config_center.NewOptions(opts...).Center.Group, listener: listener}
+	c.lock.Lock()
+	layers := c.listeners[lk]
+	delete(c.listeners, lk)
+	c.lock.Unlock()
+	for _, l := range layers {
+		c.configurations[l.index].RemoveListener(key, l, opts...)
+	}
+}
+
+// GetProperties get properties file
+func (c *CompositeDynamicConfiguration) GetProperties(key string, opts ...config_center.Option) (string, error) {
+	return c.get(key, opts, func(dc config_center.DynamicConfiguration) (string, error) {
+		return dc.GetProperties(key, opts...)
+	})
+}
+
+// GetRule get Router rule properties file
+func (c *CompositeDynamicConfiguration) GetRule(key string, opts ...config_center.Option) (string, error) {
+	return c.get(key, opts, func(dc config_center.DynamicConfiguration) (string, error) {
+		return dc.GetRule(key, opts...)
+	})
+}
+
+// GetInternalProperty get value by key in Default properties file(dubbo.properties)
+func (c *CompositeDynamicConfiguration) GetInternalProperty(key string, opts ...config_center.Option) (string, error) {
+	return c.get(key, opts, func(dc config_center.DynamicConfiguration) (string, error) {
+		return dc.GetInternalProperty(key, opts...)
+	})
+}
+
+// PublishConfig publishes the config to the config center of the highest priority
+func (c *CompositeDynamicConfiguration) PublishConfig(key string, group string, value string) error {
+	if len(c.configurations) == 0 {
+		return perrors.New("no config center is available")
+	}
+	return c.configurations[0].PublishConfig(key, group, value)
+}
+
+// RemoveConfig removes the config from the config center of the highest priority
+func (c *CompositeDynamicConfiguration) RemoveConfig(key string, group string) error {
+	if len(c.configurations) == 0 {
+		return perrors.New("no config center is available")
+	}
+	return c.configurations[0].RemoveConfig(key, group)
+}
+
+// GetConfigWithVersion returns the config and its version from the config center of the highest priority, which
+// the compare-and-set publishing goes to
+func (c *CompositeDynamicConfiguration) GetConfigWithVersion(key string, group string) (string, string, error) {
+	dc, err := c.versionedConfiguration()
+	if err != nil {
+		return "", "", err
+	}
+	return dc.GetConfigWithVersion(key, group)
+}
+
+// PublishConfigCas publishes the config to the config center of the highest priority by compare-and-set
+func (c *CompositeDynamicConfiguration) PublishConfigCas(key string, group string, value string, expectedVersion string) error {
+	dc, err := c.versionedConfiguration()
+	if err != nil {
+		return err
+	}
+	return dc.PublishConfigCas(key, group, value, expectedVersion)
+}
+
+func (c *CompositeDynamicConfiguration) versionedConfiguration() (config_center.VersionedDynamicConfiguration, error) {
+	if len(c.configurations) == 0 {
+		return nil, perrors.New("no config center is available")
+	}
+	dc, ok := c.configurations[0].(config_center.VersionedDynamicConfiguration)
+	if !ok {
+		return nil, perrors.Errorf("the config center %T doesn't support compare-and-set publishing", c.configurations[0])
+	}
+	return dc, nil
+}
+
+// GetConfigKeysByGroup returns the keys of the group in all the available config centers
+func (c *CompositeDynamicConfiguration) GetConfigKeysByGroup(group string) (*gxset.HashSet, error) {
+	var lastErr error
+	var result *gxset.HashSet
+	for _, dc := range c.configurations {
+		keys, err := dc.GetConfigKeysByGroup(group)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if result == nil {
+			result = gxset.NewSet()
+		}
+		if keys != nil {
+			result.Add(keys.Values()...)
+		}
+	}
+	if result == nil {
+		if lastErr == nil {
+			lastErr = perrors.New("no config center is available")
+		}
+		return nil, lastErr
+	}
+	return result, nil
+}
+
+// get returns the config from the first config center which has it, the config centers failing are skipped.
+// The snapshot is served only if all the config centers fail, and it's removed if none of them has the config,
+// the config centers reporting the config doesn't exist are regarded as available.
+func (c *CompositeDynamicConfiguration) get(key string, opts []config_center.Option,
+	get func(config_center.DynamicConfiguration) (string, error)) (string, error) {
+	group := config_center.NewOptions(opts...).Center.Group
+	var lastErr error
+	available := false
+	for i, dc := range c.configurations {
+		content, err := get(dc)
+		if err != nil && !isConfigNotFound(err) {
+			logger.Warnf("[Composite ConfigCenter] Get config %s of group %s from config center #%d error: %v",
+				key, group, i, err)
+			lastErr = err
+			continue
+		}
+		available = true
+		if len(content) != 0 {
+			c.saveSnapshot(key, group, content)
+			return content, nil
+		}
+	}
+	if available {
+		c.removeSnapshot(key, group)
+		return "", nil
+	}
+	if lastErr == nil {
+		lastErr = perrors.New("no config center is available")
+	}
+
+	content, modTime, err := c.loadSnapshot(key, group)
+	if err != nil {
+		return "", lastErr
+	}
+	staleness := time.Since(modTime)
+	c.lock.Lock()
+	c.stale[snapshotKey(key, group)] = true
+	c.lock.Unlock()
+	metrics.Publish(metricsConfigCenter.NewSnapshotMetricEvent(key, group, staleness))
+	logger.Warnf("[Composite ConfigCenter] All config centers are unavailable, config %s of group %s is served "+
+		"from the snapshot fetched %s ago, error: %v", key, group, staleness.Round(time.Second), lastErr)
+	return content, nil
+}
+
+// isConfigNotFound returns true if the error means the config doesn't exist rather than the config center is
+// unavailable, e.g. the node of zookeeper or the file of the file system config center doesn't exist
+func isConfigNotFound(err error) bool {
+	return errors.Is(err, zk.ErrNoNode) || errors.Is(err, fs.ErrNotExist)
+}
+
+func (c *CompositeDynamicConfiguration) snapshotPath(key, group string) string {
+	if len(group) == 0 {
+		group = defaultSnapshotGroup
+	}
+	return filepath.Join(c.snapshotDir, url.PathEscape(group), url.PathEscape(key))
+}
+
+func snapshotKey(key, group string) string {
+	return group + "/" + key
+}
+
+// clearStale resets the staleness of the config once it's got from the config centers again
+func (c *CompositeDynamicConfiguration) clearStale(key, group string) {
+	c.lock.Lock()
+	stale := c.stale[snapshotKey(key, group)]
+	delete(c.stale, snapshotKey(key, group))
+	c.lock.Unlock()
+	if stale {
+		metrics.Publish(metricsConfigCenter.NewSnapshotMetricEvent(key, group, 0))
+	}
+}
+
+// saveSnapshot writes the snapshot by renaming a temp file, so it's never read partially
+func (c *CompositeDynamicConfiguration) saveSnapshot(key, group, content string) {
+	c.clearStale(key, group)
+	if len(c.snapshotDir) == 0 {
+		return
+	}
+
+	path := c.snapshotPath(key, group)
+	if old, err := os.ReadFile(path); err == nil && string(old) == content {
+		// refresh the time it's fetched
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		logger.Warnf("[Composite ConfigCenter] Create snapshot directory of %s error: %v", path, err)
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".snapshot-*")
+	if err != nil {
+		logger.Warnf("[Composite ConfigCenter] Save snapshot %s error: %v", path, err)
+		return
+	}
+	_, err = tmp.WriteString(content)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		logger.Warnf("[Composite ConfigCenter] Save snapshot %s error: %v", path, err)
+	}
+}
+
+func (c *CompositeDynamicConfiguration) removeSnapshot(key, group string) {
+	c.clearStale(key, group)
+	if len(c.snapshotDir) == 0 {
+		return
+	}
+	if err := os.Remove(c.snapshotPath(key, group)); err != nil && !os.IsNotExist(err) {
+		logger.Warnf("[Composite ConfigCenter] Remove snapshot of config %s error: %v", key, err)
+	}
+}
+
+func (c *CompositeDynamicConfiguration) loadSnapshot(key, group string) (string, time.Time, error) {
+	if len(c.snapshotDir) == 0 {
+		return "", time.Time{}, perrors.New("snapshot is disabled")
+	}
+	path := c.snapshotPath(key, group)
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return string(content), info.ModTime(), nil
+}
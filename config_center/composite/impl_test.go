@@ -0,0 +1,294 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package composite
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+import (
+	"github.com/dubbogo/go-zookeeper/zk"
+
+	gxset "github.com/dubbogo/gost/container/set"
+
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/parser"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+// memoryDynamicConfiguration keeps the configs of the default group in memory
+type memoryDynamicConfiguration struct {
+	config_center.BaseDynamicConfiguration
+	lock sync.Mutex
+	down bool
+	// notFound reports the missing configs by zk.ErrNoNode like the zookeeper config center
+	notFound  bool
+	configs   map[string]string
+	listeners map[string][]config_center.ConfigurationListener
+}
+
+func newMemoryDynamicConfiguration(configs map[string]string) *memoryDynamicConfiguration {
+	return &memoryDynamicConfiguration{configs: configs, listeners: make(map[string][]config_center.ConfigurationListener)}
+}
+
+func (m *memoryDynamicConfiguration) Parser() parser.ConfigurationParser { return nil }
+
+func (m *memoryDynamicConfiguration) SetParser(parser.ConfigurationParser) {}
+
+func (m *memoryDynamicConfiguration) AddListener(key string, l config_center.ConfigurationListener, _ ...config_center.Option) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.listeners[key] = append(m.listeners[key], l)
+}
+
+func (m *memoryDynamicConfiguration) RemoveListener(key string, _ config_center.ConfigurationListener, _ ...config_center.Option) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.listeners, key)
+}
+
+func (m *memoryDynamicConfiguration) GetProperties(key string, _ ...config_center.Option) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.down {
+		return "", errors.New("config center is down")
+	}
+	if _, ok := m.configs[key]; !ok && m.notFound {
+		return "", fmt.Errorf("get %s: %w", key, zk.ErrNoNode)
+	}
+	return m.configs[key], nil
+}
+
+func (m *memoryDynamicConfiguration) GetRule(key string, opts ...config_center.Option) (string, error) {
+	return m.GetProperties(key, opts...)
+}
+
+func (m *memoryDynamicConfiguration) GetInternalProperty(key string, opts ...config_center.Option) (string, error) {
+	return m.GetProperties(key, opts...)
+}
+
+func (m *memoryDynamicConfiguration) PublishConfig(key string, _ string, value string) error {
+	m.lock.Lock()
+	m.configs[key] = value
+	listeners := m.listeners[key]
+	m.lock.Unlock()
+	for _, l := range listeners {
+		l.Process(&config_center.ConfigChangeEvent{Key: key, Value: value, ConfigType: remoting.EventTypeUpdate})
+	}
+	return nil
+}
+
+func (m *memoryDynamicConfiguration) RemoveConfig(key string, _ string) error {
+	m.lock.Lock()
+	delete(m.configs, key)
+	listeners := m.listeners[key]
+	m.lock.Unlock()
+	for _, l := range listeners {
+		l.Process(&config_center.ConfigChangeEvent{Key: key, ConfigType: remoting.EventTypeDel})
+	}
+	return nil
+}
+
+func (m *memoryDynamicConfiguration) GetConfigKeysByGroup(string) (*gxset.HashSet, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.down {
+		return nil, errors.New("config center is down")
+	}
+	keys := gxset.NewSet()
+	for k := range m.configs {
+		keys.Add(k)
+	}
+	return keys, nil
+}
+
+func (m *memoryDynamicConfiguration) setDown(down bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.down = down
+}
+
+// versionedDynamicConfiguration supports publishing the configs by compare-and-set
+type versionedDynamicConfiguration struct {
+	*memoryDynamicConfiguration
+	versions map[string]int
+}
+
+func (v *versionedDynamicConfiguration) GetConfigWithVersion(key string, _ string) (string, string, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.configs[key], strconv.Itoa(v.versions[key]), nil
+}
+
+func (v *versionedDynamicConfiguration) PublishConfigCas(key string, _ string, value string, expectedVersion string) error {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if strconv.Itoa(v.versions[key]) != expectedVersion {
+		return config_center.ErrConfigVersionConflict
+	}
+	v.configs[key] = value
+	v.versions[key]++
+	return nil
+}
+
+type recordListener struct {
+	events []*config_center.ConfigChangeEvent
+}
+
+func (l *recordListener) Process(event *config_center.ConfigChangeEvent) {
+	l.events = append(l.events, event)
+}
+
+func TestGetProperties(t *testing.T) {
+	primary := newMemoryDynamicConfiguration(map[string]string{"a": "primary-a"})
+	fallback := newMemoryDynamicConfiguration(map[string]string{"a": "fallback-a", "b": "fallback-b"})
+	c := NewCompositeDynamicConfiguration(t.TempDir(), primary, fallback)
+
+	content, err := c.GetProperties("a")
+	assert.NoError(t, err)
+	assert.Equal(t, "primary-a", content)
+	content, err = c.GetRule("b")
+	assert.NoError(t, err)
+	assert.Equal(t, "fallback-b", content)
+	content, err = c.GetProperties("c")
+	assert.NoError(t, err)
+	assert.Empty(t, content)
+
+	// the fallback serves the configs if the primary is down
+	primary.setDown(true)
+	content, err = c.GetProperties("a")
+	assert.NoError(t, err)
+	assert.Equal(t, "fallback-a", content)
+	// the snapshot serves the configs fetched last time if all the config centers are down
+	fallback.setDown(true)
+	content, err = c.GetProperties("a")
+	assert.NoError(t, err)
+	assert.Equal(t, "fallback-a", content)
+	content, err = c.GetInternalProperty("b")
+	assert.NoError(t, err)
+	assert.Equal(t, "fallback-b", content)
+	_, err = c.GetProperties("c")
+	assert.Error(t, err)
+
+	keys, err := NewCompositeDynamicConfiguration("", fallback, primary).GetConfigKeysByGroup("")
+	assert.Error(t, err)
+	assert.Nil(t, keys)
+	fallback.setDown(false)
+	keys, err = c.GetConfigKeysByGroup("")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, keys.Size())
+
+	// the snapshot of the config removed from all the config centers is removed
+	primary.setDown(false)
+	delete(primary.configs, "a")
+	delete(fallback.configs, "a")
+	content, err = c.GetProperties("a")
+	assert.NoError(t, err)
+	assert.Empty(t, content)
+	primary.setDown(true)
+	fallback.setDown(true)
+	_, err = c.GetProperties("a")
+	assert.Error(t, err)
+
+	// the snapshot is disabled without the directory
+	_, err = NewCompositeDynamicConfiguration("", primary, fallback).GetProperties("b")
+	assert.Error(t, err)
+}
+
+func TestListener(t *testing.T) {
+	primary := newMemoryDynamicConfiguration(map[string]string{})
+	fallback := newMemoryDynamicConfiguration(map[string]string{})
+	c := NewCompositeDynamicConfiguration(t.TempDir(), primary, fallback)
+	listener := &recordListener{}
+	c.AddListener("a", listener)
+
+	assert.NoError(t, fallback.PublishConfig("a", "", "fallback-a"))
+	assert.NoError(t, c.PublishConfig("a", "", "primary-a"))
+	// shadowed by the primary
+	assert.NoError(t, fallback.PublishConfig("a", "", "fallback-a2"))
+	// the value of the fallback takes effect
+	assert.NoError(t, c.RemoveConfig("a", ""))
+	assert.NoError(t, fallback.RemoveConfig("a", ""))
+
+	values := make([]any, 0, len(listener.events))
+	for _, event := range listener.events {
+		values = append(values, event.Value)
+	}
+	assert.Equal(t, []any{"fallback-a", "primary-a", "fallback-a2", nil}, values)
+	assert.Equal(t, remoting.EventTypeUpdate, listener.events[2].ConfigType)
+	assert.Equal(t, remoting.EventTypeDel, listener.events[3].ConfigType)
+
+	c.RemoveListener("a", listener)
+	assert.NoError(t, c.PublishConfig("a", "", "primary-a"))
+	assert.Len(t, listener.events, 4)
+}
+
+func TestGetPropertiesNotFound(t *testing.T) {
+	primary := newMemoryDynamicConfiguration(map[string]string{"a": "primary-a"})
+	primary.notFound = true
+	fallback := newMemoryDynamicConfiguration(map[string]string{})
+	fallback.notFound = true
+	c := NewCompositeDynamicConfiguration(t.TempDir(), primary, fallback)
+
+	content, err := c.GetProperties("a")
+	assert.NoError(t, err)
+	assert.Equal(t, "primary-a", content)
+
+	// the config missing isn't regarded as the config centers being down, so the snapshot isn't served
+	delete(primary.configs, "a")
+	content, err = c.GetProperties("a")
+	assert.NoError(t, err)
+	assert.Empty(t, content)
+	primary.setDown(true)
+	fallback.setDown(true)
+	_, err = c.GetProperties("a")
+	assert.Error(t, err)
+}
+
+func TestPublishConfigCas(t *testing.T) {
+	primary := &versionedDynamicConfiguration{
+		memoryDynamicConfiguration: newMemoryDynamicConfiguration(map[string]string{}),
+		versions:                   make(map[string]int),
+	}
+	fallback := newMemoryDynamicConfiguration(map[string]string{"a": "fallback-a"})
+	var c config_center.DynamicConfiguration = NewCompositeDynamicConfiguration("", primary, fallback)
+	versioned, ok := c.(config_center.VersionedDynamicConfiguration)
+	assert.True(t, ok)
+
+	// compare-and-set goes to the primary
+	content, version, err := versioned.GetConfigWithVersion("a", "")
+	assert.NoError(t, err)
+	assert.Empty(t, content)
+	assert.NoError(t, versioned.PublishConfigCas("a", "", "primary-a", version))
+	assert.ErrorIs(t, versioned.PublishConfigCas("a", "", "primary-a2", version), config_center.ErrConfigVersionConflict)
+	content, _, err = versioned.GetConfigWithVersion("a", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "primary-a", content)
+
+	_, _, err = NewCompositeDynamicConfiguration("", fallback, primary).GetConfigWithVersion("a", "")
+	assert.Error(t, err)
+	assert.Error(t, NewCompositeDynamicConfiguration("").PublishConfigCas("a", "", "a", "0"))
+}
@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package composite
+
+import (
+	"fmt"
+	"sync"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+// compositeListener merges the changes of a config in all the config centers, only the changes of the config
+// center which has the config with the highest priority are notified, and the same value isn't notified twice.
+type compositeListener struct {
+	composite *CompositeDynamicConfiguration
+	key       string
+	group     string
+	opts      []config_center.Option
+	listener  config_center.ConfigurationListener
+
+	lock      sync.Mutex
+	notified  bool
+	lastValue string
+}
+
+// layerListener is the listener added to the config center at index
+type layerListener struct {
+	*compositeListener
+	index int
+}
+
+// Process resolves the value of the config after the change, it's the changed value unless a config center of
+// higher priority has the config, or the value of the config centers of lower priorities if it's deleted
+func (l *layerListener) Process(event *config_center.ConfigChangeEvent) {
+	value := ""
+	if event.Value != nil {
+		value = fmt.Sprint(event.Value)
+	}
+	if event.ConfigType == remoting.EventTypeDel || len(value) == 0 {
+		resolved, err := l.composite.GetProperties(l.key, l.opts...)
+		if err != nil {
+			logger.Warnf("[Composite ConfigCenter] Resolve config %s after it's deleted from config center #%d "+
+				"error: %v", l.key, l.index, err)
+			return
+		}
+		value = resolved
+	} else {
+		for i := 0; i < l.index; i++ {
+			if content, err := l.composite.configurations[i].GetProperties(l.key, l.opts...); err == nil && len(content) != 0 {
+				logger.Debugf("[Composite ConfigCenter] Change of config %s in config center #%d is shadowed by "+
+					"config center #%d", l.key, l.index, i)
+				return
+			}
+		}
+		l.composite.saveSnapshot(l.key, l.group, value)
+	}
+
+	l.lock.Lock()
+	if l.notified && l.lastValue == value {
+		l.lock.Unlock()
+		return
+	}
+	l.notified, l.lastValue = true, value
+	l.lock.Unlock()
+
+	if len(value) == 0 {
+		l.listener.Process(&config_center.ConfigChangeEvent{Key: event.Key, ConfigType: remoting.EventTypeDel})
+		return
+	}
+	configType := event.ConfigType
+	if configType == remoting.EventTypeDel {
+		configType = remoting.EventTypeUpdate
+	}
+	l.listener.Process(&config_center.ConfigChangeEvent{Key: event.Key, Value: value, ConfigType: configType})
+}
@@ -19,6 +19,8 @@ package config_center
 
 import (
 	gxset "github.com/dubbogo/gost/container/set"
+
+	perrors "github.com/pkg/errors"
 )
 
 import (
@@ -58,6 +60,25 @@ type DynamicConfiguration interface {
 	GetConfigKeysByGroup(group string) (*gxset.HashSet, error)
 }
 
+// ErrConfigVersionConflict is returned by PublishConfigCas if the config has been changed since the expected version
+var ErrConfigVersionConflict = perrors.New("config version conflict")
+
+// VersionedDynamicConfiguration is the DynamicConfiguration which supports compare-and-set publishing, so that
+// the concurrent modifications of the same config, e.g. routing rules edited by two operators, don't overwrite
+// each other. The versions are opaque strings: the stat version for zookeeper and the md5 of the content for
+// nacos and file.
+type VersionedDynamicConfiguration interface {
+	DynamicConfiguration
+
+	// GetConfigWithVersion returns the content and the version of the config with the (key, group) pair,
+	// both are empty if the config doesn't exist
+	GetConfigWithVersion(key string, group string) (string, string, error)
+
+	// PublishConfigCas publishes the config only if its version is still expectedVersion, an empty expectedVersion
+	// means the config must not exist. ErrConfigVersionConflict is returned otherwise.
+	PublishConfigCas(key string, group string, value string, expectedVersion string) error
+}
+
 // GetRuleKey The format is '{interfaceName}:[version]:[group]'
 func GetRuleKey(url *common.URL) string {
 	return url.ColonSeparatedKey()
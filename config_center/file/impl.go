@@ -19,6 +19,8 @@ package file
 
 import (
 	"bytes"
+	"crypto/md5"
+	"encoding/hex"
 	"errors"
 	"os"
 	"os/exec"
@@ -26,6 +28,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 )
 
 import (
@@ -60,6 +63,8 @@ type FileSystemDynamicConfiguration struct {
 	encoding      string
 	cacheListener *CacheListener
 	parser        parser.ConfigurationParser
+	// casLock serializes PublishConfigCas in the process, the files aren't locked across processes
+	casLock sync.Mutex
 }
 
 func newFileSystemDynamicConfiguration(url *common.URL) (*FileSystemDynamicConfiguration, error) {
@@ -146,6 +151,36 @@ func (fsdc *FileSystemDynamicConfiguration) PublishConfig(key string, group stri
 	return fsdc.write2File(tmpPath, value)
 }
 
+// GetConfigWithVersion returns the config with the (key, group) pair and the md5 of its content
+func (fsdc *FileSystemDynamicConfiguration) GetConfigWithVersion(key string, group string) (string, string, error) {
+	file, err := os.ReadFile(fsdc.GetPath(key, group))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", "", nil
+		}
+		return "", "", perrors.WithStack(err)
+	}
+	sum := md5.Sum(file)
+	return string(file), hex.EncodeToString(sum[:]), nil
+}
+
+// PublishConfigCas writes the file only if the md5 of its content is still expectedVersion
+func (fsdc *FileSystemDynamicConfiguration) PublishConfigCas(key string, group string, value string,
+	expectedVersion string) error {
+	fsdc.casLock.Lock()
+	defer fsdc.casLock.Unlock()
+
+	_, version, err := fsdc.GetConfigWithVersion(key, group)
+	if err != nil {
+		return err
+	}
+	if version != expectedVersion {
+		return perrors.Wrapf(config_center.ErrConfigVersionConflict, "config %s is of version %q rather than %q",
+			key, version, expectedVersion)
+	}
+	return fsdc.PublishConfig(key, group, value)
+}
+
 // GetConfigKeysByGroup will return all keys with the group
 func (fsdc *FileSystemDynamicConfiguration) GetConfigKeysByGroup(group string) (*gxset.HashSet, error) {
 	tmpPath := fsdc.GetPath("", group)
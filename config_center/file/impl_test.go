@@ -63,6 +63,30 @@ func TestPublishAndGetConfig(t *testing.T) {
 	defer destroy(file.rootPath, file)
 }
 
+func TestPublishConfigCas(t *testing.T) {
+	file, err := initFileData(t)
+	assert.NoError(t, err)
+	defer destroy(file.rootPath, file)
+	group := "dubbogo-cas"
+
+	content, version, err := file.GetConfigWithVersion(key, group)
+	assert.NoError(t, err)
+	assert.Empty(t, content)
+	assert.Empty(t, version)
+
+	assert.NoError(t, file.PublishConfigCas(key, group, "A", ""))
+	assert.ErrorIs(t, file.PublishConfigCas(key, group, "B", ""), config_center.ErrConfigVersionConflict)
+	content, version, err = file.GetConfigWithVersion(key, group)
+	assert.NoError(t, err)
+	assert.Equal(t, "A", content)
+
+	assert.NoError(t, file.PublishConfigCas(key, group, "B", version))
+	assert.ErrorIs(t, file.PublishConfigCas(key, group, "C", version), config_center.ErrConfigVersionConflict)
+	prop, err := file.GetProperties(key, config_center.WithGroup(group))
+	assert.NoError(t, err)
+	assert.Equal(t, "B", prop)
+}
+
 func TestAddListener(t *testing.T) {
 	file, err := initFileData(t)
 	assert.Nil(t, err)
@@ -18,6 +18,8 @@
 package nacos
 
 import (
+	"crypto/md5"
+	"encoding/hex"
 	"strings"
 	"sync"
 )
@@ -122,6 +124,55 @@ func (n *nacosDynamicConfiguration) PublishConfig(key string, group string, valu
 	return nil
 }
 
+// GetConfigWithVersion returns the config with the (key, group) pair and the md5 of its content,
+// which is the version checked by nacos when publishing with casMd5
+func (n *nacosDynamicConfiguration) GetConfigWithVersion(key string, group string) (string, string, error) {
+	content, err := n.client.Client().GetConfig(vo.ConfigParam{
+		DataId: key,
+		Group:  n.resolvedGroup(group),
+	})
+	if err != nil {
+		return "", "", perrors.WithStack(err)
+	}
+	if len(content) == 0 {
+		return "", "", nil
+	}
+	return content, contentMd5(content), nil
+}
+
+// PublishConfigCas publishes the config with casMd5, nacos rejects it if the md5 of the content has been changed.
+// Nacos can't create a config only if it's absent, so the absence is checked before publishing when
+// expectedVersion is empty.
+func (n *nacosDynamicConfiguration) PublishConfigCas(key string, group string, value string, expectedVersion string) error {
+	if len(expectedVersion) == 0 {
+		_, version, err := n.GetConfigWithVersion(key, group)
+		if err != nil {
+			return err
+		}
+		if len(version) != 0 {
+			return perrors.Wrapf(config_center.ErrConfigVersionConflict, "config %s already exists", key)
+		}
+		return n.PublishConfig(key, group, value)
+	}
+	ok, err := n.client.Client().PublishConfig(vo.ConfigParam{
+		DataId:  key,
+		Group:   n.resolvedGroup(group),
+		Content: value,
+		CasMd5:  expectedVersion,
+	})
+	if err == nil && ok {
+		return nil
+	}
+	// nacos doesn't tell the cas failure from the others, so the version is checked again
+	if _, version, getErr := n.GetConfigWithVersion(key, group); getErr == nil && version != expectedVersion {
+		return perrors.Wrapf(config_center.ErrConfigVersionConflict, "config %s is not of version %s", key, expectedVersion)
+	}
+	if err != nil {
+		return perrors.WithStack(err)
+	}
+	return perrors.New("publish config to Nacos failed")
+}
+
 // RemoveConfig will remove the config with the (key, group) pair
 func (n *nacosDynamicConfiguration) RemoveConfig(key string, group string) error {
 	group = n.resolvedGroup(group)
@@ -238,6 +289,11 @@ func (n *nacosDynamicConfiguration) IsAvailable() bool {
 	}
 }
 
+func contentMd5(content string) string {
+	sum := md5.Sum([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
 func (n *nacosDynamicConfiguration) closeConfigs() {
 	// Close the old configClient first to close the tmp node
 	n.client.Close()
@@ -18,6 +18,7 @@
 package nacos
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -30,6 +31,8 @@ import (
 
 	"github.com/nacos-group/nacos-sdk-go/v2/model"
 	"github.com/nacos-group/nacos-sdk-go/v2/vo"
+
+	"github.com/stretchr/testify/assert"
 )
 
 import (
@@ -265,3 +268,33 @@ func Test_nacosDynamicConfiguration_GetConfigKeysByGroup(t *testing.T) {
 		})
 	}
 }
+
+func Test_nacosDynamicConfiguration_PublishConfigCas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mnc := NewMockIConfigClient(ctrl)
+	nc := &nacosClient.NacosConfigClient{}
+	nc.SetClient(mnc)
+	n := newnNacosDynamicConfiguration(&fields{client: nc, url: common.NewURLWithOptions()})
+
+	mnc.EXPECT().GetConfig(gomock.Any()).Return("A", nil)
+	content, version, err := n.GetConfigWithVersion("dubbo.properties", "dubbogo")
+	assert.NoError(t, err)
+	assert.Equal(t, "A", content)
+	assert.Equal(t, "7fc56270e7a70fa81a5935b72eacbe29", version)
+
+	mnc.EXPECT().PublishConfig(vo.ConfigParam{
+		DataId: "dubbo.properties", Group: "dubbogo", Content: "B", CasMd5: version,
+	}).Return(true, nil)
+	assert.NoError(t, n.PublishConfigCas("dubbo.properties", "dubbogo", "B", version))
+
+	// the config has been changed by others
+	mnc.EXPECT().PublishConfig(gomock.Any()).Return(false, errors.New("cas publish fail"))
+	mnc.EXPECT().GetConfig(gomock.Any()).Return("C", nil)
+	err = n.PublishConfigCas("dubbo.properties", "dubbogo", "B", version)
+	assert.ErrorIs(t, err, config_center.ErrConfigVersionConflict)
+
+	// the config exists already
+	mnc.EXPECT().GetConfig(gomock.Any()).Return("C", nil)
+	err = n.PublishConfigCas("dubbo.properties", "dubbogo", "B", "")
+	assert.ErrorIs(t, err, config_center.ErrConfigVersionConflict)
+}
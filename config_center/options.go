@@ -134,6 +134,23 @@ func WithParams(params map[string]string) Option {
 	}
 }
 
+// WithFallback adds a config center of lower priority, the configs not found in the config centers before are
+// got from it
+func WithFallback(opts ...Option) Option {
+	return func(options *Options) {
+		fallback := NewOptions(opts...)
+		options.Center.Fallbacks = append(options.Center.Fallbacks, fallback.Center)
+	}
+}
+
+// WithSnapshotDir persists the configs got last time to dir, they are used when all the config centers are
+// unavailable
+func WithSnapshotDir(dir string) Option {
+	return func(opts *Options) {
+		opts.Center.SnapshotDir = dir
+	}
+}
+
 func WithFile() Option {
 	return func(opts *Options) {
 		opts.Center.Protocol = constant.FileKey
@@ -0,0 +1,153 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rule
+
+import (
+	"strings"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+
+	"gopkg.in/yaml.v2"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+)
+
+// Kind is the kind of routing rules, it's the suffix of the rule key
+type Kind string
+
+const (
+	TagRoute       Kind = constant.TagRouterRuleSuffix
+	ConditionRoute Kind = constant.ConditionRouterRuleSuffix
+	AffinityRoute  Kind = constant.AffinityRuleSuffix
+)
+
+func (k Kind) String() string {
+	return strings.TrimPrefix(string(k), ".")
+}
+
+// maxUpdateRetries is the times Update retries if the rule is changed by others
+const maxUpdateRetries = 3
+
+// Rule is a routing rule in the config center
+type Rule struct {
+	Kind Kind
+	// Target is the application name for tag routes, or the application name or the service key
+	// {interface}:{version}:{group} for condition and affinity routes
+	Target  string
+	Content string
+	// Version is the version of the rule got from the config center, it's empty if the rule doesn't exist
+	Version string
+}
+
+// Key is the key of the rule in the config center, which is read by the routers
+func (r *Rule) Key() string {
+	return r.Target + string(r.Kind)
+}
+
+// Manager reads and publishes the routing rules in the default group, where the routers read them from
+type Manager struct {
+	configuration config_center.VersionedDynamicConfiguration
+}
+
+// NewManager returns the Manager of the config center, which must support compare-and-set publishing
+func NewManager(dc config_center.DynamicConfiguration) (*Manager, error) {
+	configuration, ok := dc.(config_center.VersionedDynamicConfiguration)
+	if !ok {
+		return nil, perrors.Errorf("config center %T doesn't support compare-and-set publishing", dc)
+	}
+	return &Manager{configuration: configuration}, nil
+}
+
+// Get returns the rule of the target with its version, the content and the version are empty if it doesn't exist
+func (m *Manager) Get(kind Kind, target string) (*Rule, error) {
+	rule := &Rule{Kind: kind, Target: target}
+	content, version, err := m.configuration.GetConfigWithVersion(rule.Key(), "")
+	if err != nil {
+		return nil, err
+	}
+	rule.Content, rule.Version = content, version
+	return rule, nil
+}
+
+// Publish validates the rule and publishes it if the rule in the config center is still of rule.Version,
+// config_center.ErrConfigVersionConflict is returned otherwise
+func (m *Manager) Publish(rule *Rule) error {
+	if err := Validate(rule.Kind, rule.Content); err != nil {
+		return err
+	}
+	return m.configuration.PublishConfigCas(rule.Key(), "", rule.Content, rule.Version)
+}
+
+// Update reads the rule, modifies its content by update and publishes it, it's retried with the latest rule
+// if the rule is changed by others in the meantime
+func (m *Manager) Update(kind Kind, target string, update func(content string) (string, error)) (*Rule, error) {
+	var err error
+	for i := 0; i < maxUpdateRetries; i++ {
+		var rule *Rule
+		if rule, err = m.Get(kind, target); err != nil {
+			return nil, err
+		}
+		if rule.Content, err = update(rule.Content); err != nil {
+			return nil, err
+		}
+		if err = m.Publish(rule); !perrors.Is(err, config_center.ErrConfigVersionConflict) {
+			if err != nil {
+				return nil, err
+			}
+			return m.Get(kind, target)
+		}
+	}
+	return nil, err
+}
+
+// Validate checks the rule has the fields required by the router of the kind
+func Validate(kind Kind, content string) error {
+	rule := make(map[string]any)
+	if err := yaml.Unmarshal([]byte(content), &rule); err != nil {
+		return perrors.Wrapf(err, "invalid %s rule", kind)
+	}
+	if rule["key"] == nil {
+		return perrors.Errorf("invalid %s rule: key is required", kind)
+	}
+	switch kind {
+	case TagRoute:
+		if tags, _ := rule["tags"].([]any); len(tags) == 0 {
+			return perrors.Errorf("invalid %s rule: tags are required", kind)
+		}
+	case ConditionRoute:
+		if _, ok := rule["configVersion"].(string); !ok {
+			return perrors.Errorf("invalid %s rule: configVersion is required", kind)
+		}
+		if conditions, _ := rule["conditions"].([]any); len(conditions) == 0 {
+			return perrors.Errorf("invalid %s rule: conditions are required", kind)
+		}
+	case AffinityRoute:
+		aware, _ := rule["affinityAware"].(map[any]any)
+		if aware["key"] == nil {
+			return perrors.Errorf("invalid %s rule: affinityAware.key is required", kind)
+		}
+	default:
+		return perrors.Errorf("unknown rule kind %s", kind)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package rule
+
+import (
+	"strings"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/config_center/file"
+)
+
+const tagRule = `key: demo-provider
+tags:
+  - name: gray
+    addresses: [192.168.0.1:20000]
+`
+
+func newFileManager(t *testing.T) *Manager {
+	url, err := common.NewURL("file://127.0.0.1:0?" + file.ConfigCenterDirParamName + "=" + t.TempDir())
+	require.NoError(t, err)
+	factory, err := extension.GetConfigCenterFactory(constant.FileKey)
+	require.NoError(t, err)
+	dc, err := factory.GetDynamicConfiguration(url)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = dc.(*file.FileSystemDynamicConfiguration).Close()
+	})
+	m, err := NewManager(dc)
+	require.NoError(t, err)
+	return m
+}
+
+func TestManager(t *testing.T) {
+	m := newFileManager(t)
+	rule, err := m.Get(TagRoute, "demo-provider")
+	assert.NoError(t, err)
+	assert.Equal(t, "demo-provider.tag-router", rule.Key())
+	assert.Empty(t, rule.Version)
+
+	rule.Content = tagRule
+	assert.NoError(t, m.Publish(rule))
+	// the rule is published by others since it's read
+	assert.ErrorIs(t, m.Publish(rule), config_center.ErrConfigVersionConflict)
+
+	published, err := m.Get(TagRoute, "demo-provider")
+	assert.NoError(t, err)
+	assert.Equal(t, tagRule, published.Content)
+	assert.NotEmpty(t, published.Version)
+
+	updated, err := m.Update(TagRoute, "demo-provider", func(content string) (string, error) {
+		return strings.Replace(content, "gray", "blue", 1), nil
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, updated.Content, "blue")
+	assert.NotEqual(t, published.Version, updated.Version)
+	assert.ErrorIs(t, m.Publish(published), config_center.ErrConfigVersionConflict)
+
+	// the invalid rules aren't published
+	_, err = m.Update(TagRoute, "demo-provider", func(string) (string, error) {
+		return "key: demo-provider", nil
+	})
+	assert.Error(t, err)
+
+	_, err = NewManager(&config_center.MockDynamicConfiguration{})
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(TagRoute, tagRule))
+	assert.NoError(t, Validate(ConditionRoute, `configVersion: v3.0
+scope: service
+key: org.apache.dubbo.UserProvider
+conditions:
+  - => host = 127.0.0.1
+`))
+	assert.NoError(t, Validate(AffinityRoute, `configVersion: v3.1
+scope: service
+key: org.apache.dubbo.UserProvider
+affinityAware:
+  key: region
+  ratio: 20
+`))
+	assert.Error(t, Validate(ConditionRoute, "key: org.apache.dubbo.UserProvider\nconditions: []"))
+	assert.Error(t, Validate(AffinityRoute, "key: org.apache.dubbo.UserProvider"))
+	assert.Error(t, Validate(TagRoute, "tags: ["))
+	assert.Error(t, Validate(Kind(".script-router"), tagRule))
+}
@@ -178,6 +178,53 @@ func (c *zookeeperDynamicConfiguration) PublishConfig(key string, group string,
 	return nil
 }
 
+// GetConfigWithVersion returns the config with the (key, group) pair and the stat version of its node
+func (c *zookeeperDynamicConfiguration) GetConfigWithVersion(key string, group string) (string, string, error) {
+	content, stat, err := c.client.GetContent(c.getPath(key, group))
+	if err != nil {
+		if perrors.Is(err, zk.ErrNoNode) {
+			return "", "", nil
+		}
+		return "", "", perrors.WithStack(err)
+	}
+	if c.base64Enabled {
+		decoded, err := base64.StdEncoding.DecodeString(string(content))
+		if err != nil {
+			return "", "", perrors.WithStack(err)
+		}
+		content = decoded
+	}
+	return string(content), strconv.Itoa(int(stat.Version)), nil
+}
+
+// PublishConfigCas creates the node if expectedVersion is empty, otherwise sets its value with expectedVersion,
+// zookeeper rejects it if the node has been changed since then.
+func (c *zookeeperDynamicConfiguration) PublishConfigCas(key string, group string, value string, expectedVersion string) error {
+	path := c.getPath(key, group)
+	valueBytes := []byte(value)
+	if c.base64Enabled {
+		valueBytes = []byte(base64.StdEncoding.EncodeToString(valueBytes))
+	}
+	if len(expectedVersion) == 0 {
+		err := c.client.CreateWithValue(path, valueBytes)
+		if perrors.Is(err, zk.ErrNodeExists) {
+			return perrors.Wrapf(config_center.ErrConfigVersionConflict, "config %s already exists", path)
+		}
+		return perrors.WithStack(err)
+	}
+	version, err := strconv.ParseInt(expectedVersion, 10, 32)
+	if err != nil {
+		return perrors.Wrapf(err, "invalid zookeeper version %s", expectedVersion)
+	}
+	if _, err = c.client.SetContent(path, valueBytes, int32(version)); err != nil {
+		if perrors.Is(err, zk.ErrBadVersion) || perrors.Is(err, zk.ErrNoNode) {
+			return perrors.Wrapf(config_center.ErrConfigVersionConflict, "config %s is not of version %s", path, expectedVersion)
+		}
+		return perrors.WithStack(err)
+	}
+	return nil
+}
+
 // RemoveConfig will remove the config with the (key, group) pair
 func (c *zookeeperDynamicConfiguration) RemoveConfig(key string, group string) error {
 	path := c.getPath(key, group)
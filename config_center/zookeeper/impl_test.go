@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package zookeeper
+
+import (
+	"testing"
+	"time"
+)
+
+import (
+	gxzookeeper "github.com/dubbogo/gost/database/kv/zk"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+)
+
+const key = "com.ikurento.user.UserProvider"
+
+// initZkData starts a zookeeper test cluster, which requires the zookeeper server jar, e.g. found by
+// ZOOKEEPER_PATH, and returns the configuration connected to it.
+func initZkData(t *testing.T) *zookeeperDynamicConfiguration {
+	ts, client, _, err := gxzookeeper.NewMockZookeeperClient("test", 15*time.Second)
+	if err != nil {
+		t.Skipf("zookeeper test cluster is unavailable: %v", err)
+	}
+	t.Cleanup(func() {
+		client.Close()
+		_ = ts.Stop()
+	})
+	return &zookeeperDynamicConfiguration{rootPath: "/dubbo/config", client: client}
+}
+
+func TestPublishConfigCas(t *testing.T) {
+	c := initZkData(t)
+	group := "dubbogo-cas"
+
+	content, version, err := c.GetConfigWithVersion(key, group)
+	require.NoError(t, err)
+	assert.Empty(t, content)
+	assert.Empty(t, version)
+
+	// ErrNoNode of the absent node
+	assert.ErrorIs(t, c.PublishConfigCas(key, group, "A", "0"), config_center.ErrConfigVersionConflict)
+
+	assert.NoError(t, c.PublishConfigCas(key, group, "A", ""))
+	// ErrNodeExists of the node created already
+	assert.ErrorIs(t, c.PublishConfigCas(key, group, "B", ""), config_center.ErrConfigVersionConflict)
+	content, version, err = c.GetConfigWithVersion(key, group)
+	require.NoError(t, err)
+	assert.Equal(t, "A", content)
+	assert.Equal(t, "0", version)
+
+	assert.NoError(t, c.PublishConfigCas(key, group, "B", version))
+	// ErrBadVersion of the node changed since the version
+	assert.ErrorIs(t, c.PublishConfigCas(key, group, "C", version), config_center.ErrConfigVersionConflict)
+	content, version, err = c.GetConfigWithVersion(key, group)
+	require.NoError(t, err)
+	assert.Equal(t, "B", content)
+	assert.Equal(t, "1", version)
+
+	assert.Error(t, c.PublishConfigCas(key, group, "C", "v1"))
+}
+
+func TestPublishConfigCasBase64(t *testing.T) {
+	c := initZkData(t)
+	c.base64Enabled = true
+	group := "dubbogo-cas-base64"
+
+	assert.NoError(t, c.PublishConfigCas(key, group, "A", ""))
+	content, version, err := c.GetConfigWithVersion(key, group)
+	require.NoError(t, err)
+	assert.Equal(t, "A", content)
+	assert.NoError(t, c.PublishConfigCas(key, group, "B", version))
+	content, _, err = c.GetConfigWithVersion(key, group)
+	require.NoError(t, err)
+	assert.Equal(t, "B", content)
+}
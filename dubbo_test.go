@@ -96,3 +96,20 @@ func TestIndependentConfig(t *testing.T) {
 		panic(err)
 	}
 }
+
+// TestInstanceOverlays tests the environment variables take precedence over the instance options.
+func TestInstanceOverlays(t *testing.T) {
+	t.Setenv("DUBBO_APPLICATION_NAME", "dubbo_test_env")
+	t.Setenv("DUBBO_REGISTRIES_ZOOKEEPER_ADDRESS", "127.0.0.1:2185")
+	ins, err := NewInstance(
+		WithName("dubbo_test"),
+		WithRegistry(
+			registry.WithZookeeper(),
+			registry.WithAddress("127.0.0.1:2181"),
+		),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "dubbo_test_env", ins.insOpts.Application.Name)
+	assert.Equal(t, "127.0.0.1:2185", ins.insOpts.Registries[constant.ZookeeperKey].Address)
+	assert.Equal(t, constant.ZookeeperKey, ins.insOpts.Registries[constant.ZookeeperKey].Protocol)
+}
@@ -37,3 +37,9 @@ type AccessKeyPair struct {
 type AccessKeyStorage interface {
 	GetAccessKeyPair(base.Invocation, *common.URL) *AccessKeyPair
 }
+
+// TokenSource is the interface which supplies the bearer tokens attached to the invocations by consumers,
+// such as the static tokens and the ones issued by an OAuth2 authorization server.
+type TokenSource interface {
+	Token(base.Invocation, *common.URL) (string, error)
+}
@@ -21,10 +21,15 @@ import (
 	"sync"
 )
 
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/config/secret"
 	"dubbo.apache.org/dubbo-go/v3/filter"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 )
@@ -50,10 +55,20 @@ func newDefaultAccessKeyStorage() filter.AccessKeyStorage {
 	return storage
 }
 
-// GetAccessKeyPair retrieves AccessKeyPair from url by the key "accessKeyId" and "secretAccessKey"
+// GetAccessKeyPair retrieves AccessKeyPair from url by the key "accessKeyId" and "secretAccessKey",
+// the secret placeholders like ${file:/run/secrets/ak} in them are resolved and refreshed periodically.
 func (storage *defaultAccesskeyStorage) GetAccessKeyPair(inv base.Invocation, url *common.URL) *filter.AccessKeyPair {
 	return &filter.AccessKeyPair{
-		AccessKey: url.GetParam(constant.AccessKeyIDKey, ""),
-		SecretKey: url.GetParam(constant.SecretAccessKeyKey, ""),
+		AccessKey: resolveSecret(url.GetParam(constant.AccessKeyIDKey, "")),
+		SecretKey: resolveSecret(url.GetParam(constant.SecretAccessKeyKey, "")),
+	}
+}
+
+func resolveSecret(value string) string {
+	secretValue, err := secret.Resolve(value)
+	if err != nil {
+		logger.Errorf("[Auth] failed to resolve the access key: %v", err)
+		return ""
 	}
+	return secretValue
 }
@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"errors"
+)
+
+// The reasons of the authentication failures, they are the labels of the metric dubbo_provider_auth_failed_total
+const (
+	ReasonMissingCredentials = "missing_credentials"
+	ReasonKeyNotFound        = "key_not_found"
+	ReasonExpired            = "expired"
+	ReasonReplayed           = "replayed"
+	ReasonNonceCacheFull     = "nonce_cache_full"
+	ReasonInvalidSignature   = "invalid_signature"
+	ReasonUnsupportedVersion = "unsupported_version"
+	ReasonInvalidToken       = "invalid_token"
+	ReasonUnknown            = "unknown"
+)
+
+// AuthError is the error of an authentication failure with its reason, the custom authenticators return it
+// to break down the failures in metrics
+type AuthError struct {
+	Reason string
+	Err    error
+}
+
+// NewAuthError returns the AuthError of the reason
+func NewAuthError(reason string, err error) *AuthError {
+	return &AuthError{Reason: reason, Err: err}
+}
+
+func (e *AuthError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// failureReason returns the reason of the authentication failure, it's unknown if err is not an AuthError
+func failureReason(err error) string {
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		return authErr.Reason
+	}
+	return ReasonUnknown
+}
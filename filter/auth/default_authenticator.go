@@ -18,9 +18,11 @@
 package auth
 
 import (
+	"crypto/hmac"
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -44,18 +46,22 @@ func init() {
 }
 
 // defaultAuthenticator is the default implementation of Authenticator
-type defaultAuthenticator struct{}
+type defaultAuthenticator struct {
+	// nonces are the nonces of the authenticated requests, the requests reusing them are rejected as replays
+	nonces *nonceCache
+}
 
 func newDefaultAuthenticator() filter.Authenticator {
 	if authenticator == nil {
 		authenticatorOnce.Do(func() {
-			authenticator = &defaultAuthenticator{}
+			authenticator = &defaultAuthenticator{nonces: newNonceCache()}
 		})
 	}
 	return authenticator
 }
 
-// Sign adds the signature to the invocation
+// Sign adds the signature to the invocation, it's signed by the version of auth.signature.version,
+// and v2 at least if the nonces are enabled by auth.nonce
 func (authenticator *defaultAuthenticator) Sign(inv base.Invocation, url *common.URL) error {
 	currentTimeMillis := strconv.Itoa(int(time.Now().Unix() * 1000))
 
@@ -64,8 +70,18 @@ func (authenticator *defaultAuthenticator) Sign(inv base.Invocation, url *common
 	if err != nil {
 		return errors.New("get accessKey pair failed, cause: " + err.Error())
 	}
+	version, err := minSignatureVersion(url)
+	if err != nil {
+		return err
+	}
+	var nonce string
+	if url.GetParamBool(constant.AuthNonceKey, false) {
+		if nonce, err = newNonce(); err != nil {
+			return errors.New("generate nonce failed, cause: " + err.Error())
+		}
+	}
 	rpcInv := inv.(*invocation.RPCInvocation)
-	signature, err := getSignature(url, inv, accessKeyPair.SecretKey, currentTimeMillis)
+	signature, err := signRequest(version, url, inv, accessKeyPair.SecretKey, currentTimeMillis, nonce, consumer)
 	if err != nil {
 		return err
 	}
@@ -73,14 +89,41 @@ func (authenticator *defaultAuthenticator) Sign(inv base.Invocation, url *common
 	rpcInv.SetAttachment(constant.RequestTimestampKey, currentTimeMillis)
 	rpcInv.SetAttachment(constant.AKKey, accessKeyPair.AccessKey)
 	rpcInv.SetAttachment(constant.Consumer, consumer)
+	if version != constant.SignatureV1 {
+		// v1 is signed without the version for the providers which don't know the versions
+		rpcInv.SetAttachment(constant.SignatureVersionKey, version)
+	}
+	if nonce != "" {
+		rpcInv.SetAttachment(constant.RequestNonceKey, nonce)
+	}
 	return nil
 }
 
+// signRequest signs the request by the version of the signature
+func signRequest(version string, url *common.URL, inv base.Invocation, secretKey, currentTime, nonce, consumer string) (string, error) {
+	switch version {
+	case constant.SignatureV1:
+		return getSignature(url, inv, secretKey, currentTime)
+	case constant.SignatureV2:
+		// unlike v1 the secret key is not a part of the request string, and the consumer and the nonce are signed
+		// so that they can't be replaced
+		requestString := strings.Join([]string{constant.SignatureV2, url.ColonSeparatedKey(), inv.MethodName(),
+			consumer, currentTime, nonce}, "#")
+		return signRequestString(url, inv, requestString, secretKey)
+	default:
+		return "", errors.New("unsupported signature version " + version)
+	}
+}
+
 // getSignature
 // get signature by the metadata and params of the invocation
 func getSignature(url *common.URL, inv base.Invocation, secrectKey string, currentTime string) (string, error) {
 	requestString := fmt.Sprintf(constant.SignatureStringFormat,
 		url.ColonSeparatedKey(), inv.MethodName(), secrectKey, currentTime)
+	return signRequestString(url, inv, requestString, secrectKey)
+}
+
+func signRequestString(url *common.URL, inv base.Invocation, requestString, secrectKey string) (string, error) {
 	var signature string
 	if parameterEncrypt := url.GetParamBool(constant.ParameterSignatureEnableKey, false); parameterEncrypt {
 		var err error
@@ -95,7 +138,9 @@ func getSignature(url *common.URL, inv base.Invocation, secrectKey string, curre
 	return signature, nil
 }
 
-// Authenticate verifies whether the signature sent by the requester is correct
+// Authenticate verifies whether the signature sent by the requester is correct, the requests whose timestamps are
+// out of auth.timestamp.window are rejected, so are the ones reusing the nonces if auth.nonce is enabled, or the
+// ones whose nonces can't be remembered since there are auth.nonce.cache.size nonces within the window
 func (authenticator *defaultAuthenticator) Authenticate(inv base.Invocation, url *common.URL) error {
 	accessKeyId := inv.GetAttachmentWithDefaultValue(constant.AKKey, "")
 
@@ -104,24 +149,103 @@ func (authenticator *defaultAuthenticator) Authenticate(inv base.Invocation, url
 	consumer := inv.GetAttachmentWithDefaultValue(constant.Consumer, "")
 	if IsEmpty(accessKeyId, false) || IsEmpty(consumer, false) ||
 		IsEmpty(requestTimestamp, false) || IsEmpty(originSignature, false) {
-		return errors.New("failed to authenticate your ak/sk, maybe the consumer has not enabled the auth")
+		return NewAuthError(ReasonMissingCredentials,
+			errors.New("failed to authenticate your ak/sk, maybe the consumer has not enabled the auth"))
+	}
+
+	version := inv.GetAttachmentWithDefaultValue(constant.SignatureVersionKey, constant.SignatureV1)
+	minVersion, err := minSignatureVersion(url)
+	if err != nil {
+		return err
+	}
+	if compareSignatureVersion(version, minVersion) < 0 {
+		return NewAuthError(ReasonUnsupportedVersion,
+			fmt.Errorf("failed to authenticate, signature version %s is not supported, %s at least", version, minVersion))
+	}
+
+	window, err := time.ParseDuration(url.GetParam(constant.AuthTimestampWindowKey, constant.DefaultAuthTimestampWindow))
+	if err != nil {
+		return fmt.Errorf("failed to authenticate, invalid %s: %w", constant.AuthTimestampWindowKey, err)
+	}
+	timestampMillis, err := strconv.ParseInt(requestTimestamp, 10, 64)
+	if err != nil {
+		return NewAuthError(ReasonMissingCredentials, errors.New("failed to authenticate, the timestamp is invalid"))
+	}
+	now := time.Now()
+	if window > 0 {
+		if skew := now.Sub(time.UnixMilli(timestampMillis)); skew > window || skew < -window {
+			return NewAuthError(ReasonExpired,
+				fmt.Errorf("failed to authenticate, the timestamp is %s away from now beyond %s", skew, window))
+		}
+	}
+	nonce := inv.GetAttachmentWithDefaultValue(constant.RequestNonceKey, "")
+	nonceEnabled := url.GetParamBool(constant.AuthNonceKey, false)
+	if nonceEnabled && IsEmpty(nonce, false) {
+		return NewAuthError(ReasonMissingCredentials, errors.New("failed to authenticate, the nonce is missing"))
 	}
 
 	accessKeyPair, err := getAccessKeyPair(inv, url)
 	if err != nil {
-		return errors.New("failed to authenticate , can't load the accessKeyPair")
+		return NewAuthError(ReasonKeyNotFound, errors.New("failed to authenticate , can't load the accessKeyPair"))
 	}
 
-	computeSignature, err := getSignature(url, inv, accessKeyPair.SecretKey, requestTimestamp)
+	computeSignature, err := signRequest(version, url, inv, accessKeyPair.SecretKey, requestTimestamp, nonce, consumer)
 	if err != nil {
-		return err
+		return NewAuthError(ReasonUnsupportedVersion, err)
+	}
+	if success := hmac.Equal([]byte(computeSignature), []byte(originSignature)); !success {
+		return NewAuthError(ReasonInvalidSignature, errors.New("failed to authenticate, signature is not correct"))
+	}
+	if nonceEnabled {
+		return authenticator.checkNonce(accessKeyId+"#"+nonce, timestampMillis, window,
+			url.GetParamByIntValue(constant.AuthNonceCacheSizeKey, constant.DefaultAuthNonceCacheSize), now)
+	}
+	return nil
+}
+
+// checkNonce remembers the nonce until the timestamp of the request is out of the window, it's called after the
+// signature is verified, so the forged requests can't fill the cache. The requests are rejected rather than
+// evicting the live nonces if the cache is full, since the replays of the evicted ones would be accepted.
+func (authenticator *defaultAuthenticator) checkNonce(nonce string, timestampMillis int64, window time.Duration,
+	capacity int, now time.Time) error {
+	var expiry time.Time
+	if window > 0 {
+		expiry = time.UnixMilli(timestampMillis).Add(window)
 	}
-	if success := computeSignature == originSignature; !success {
-		return errors.New("failed to authenticate, signature is not correct")
+	switch authenticator.nonces.add(nonce, expiry, capacity, now) {
+	case nonceReplayed:
+		return NewAuthError(ReasonReplayed, errors.New("failed to authenticate, the nonce has been used"))
+	case nonceCacheFull:
+		return NewAuthError(ReasonNonceCacheFull, fmt.Errorf("failed to authenticate, the nonces within %s exceed %s %d",
+			window, constant.AuthNonceCacheSizeKey, capacity))
 	}
 	return nil
 }
 
+// minSignatureVersion returns the version consumers sign with and the min version providers accept,
+// the nonces are signed since v2
+func minSignatureVersion(url *common.URL) (string, error) {
+	version := url.GetParam(constant.AuthSignatureVersionKey, constant.SignatureV1)
+	if compareSignatureVersion(version, constant.SignatureV1) < 0 {
+		return "", fmt.Errorf("unsupported %s %s", constant.AuthSignatureVersionKey, version)
+	}
+	if url.GetParamBool(constant.AuthNonceKey, false) && compareSignatureVersion(version, constant.SignatureV2) < 0 {
+		version = constant.SignatureV2
+	}
+	return version, nil
+}
+
+// compareSignatureVersion compares the versions like v1 and v2, the unknown versions are less than any known one
+func compareSignatureVersion(a, b string) int {
+	return signatureVersions[a] - signatureVersions[b]
+}
+
+// signatureVersions are the known versions of the signature
+var signatureVersions = map[string]int{
+	constant.SignatureV1: 1,
+	constant.SignatureV2: 2,
+}
+
 func getAccessKeyPair(inv base.Invocation, url *common.URL) (*filter.AccessKeyPair, error) {
 	accessKeyStorage, err := extension.GetAccessKeyStorages(url.GetParam(constant.AccessKeyStorageKey, constant.DefaultAccessKeyStorage))
 	if err != nil {
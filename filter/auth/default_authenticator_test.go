@@ -151,3 +151,113 @@ func Test_getSignature(t *testing.T) {
 	assert.False(t, IsEmpty(signature, false))
 	assert.Equal(t, s, signature)
 }
+
+func newSignedInvocation(t *testing.T, authenticator *defaultAuthenticator, url *common.URL) *invocation.RPCInvocation {
+	inv := invocation.NewRPCInvocation("test", []any{"OK"}, nil)
+	assert.NoError(t, authenticator.Sign(inv, url))
+	return inv
+}
+
+func TestDefaultAuthenticator_TimestampWindow(t *testing.T) {
+	authenticator := &defaultAuthenticator{nonces: newNonceCache()}
+	testUrl, _ := common.NewURL("dubbo://127.0.0.1:20000/com.ikurento.user.UserProvider?application=test&interface=com.ikurento.user.UserProvider")
+	testUrl.SetParam(constant.AccessKeyIDKey, "akey")
+	testUrl.SetParam(constant.SecretAccessKeyKey, "skey")
+
+	inv := newSignedInvocation(t, authenticator, testUrl)
+	assert.NoError(t, authenticator.Authenticate(inv, testUrl))
+
+	// the request signed 10 minutes ago is out of the default window
+	requestTime := strconv.FormatInt(time.Now().Add(-10*time.Minute).UnixMilli(), 10)
+	signature, _ := getSignature(testUrl, inv, "skey", requestTime)
+	inv.SetAttachment(constant.RequestTimestampKey, requestTime)
+	inv.SetAttachment(constant.RequestSignatureKey, signature)
+	err := authenticator.Authenticate(inv, testUrl)
+	assert.Equal(t, ReasonExpired, failureReason(err))
+
+	testUrl.SetParam(constant.AuthTimestampWindowKey, "15m")
+	assert.NoError(t, authenticator.Authenticate(inv, testUrl))
+	testUrl.SetParam(constant.AuthTimestampWindowKey, "0s")
+	assert.NoError(t, authenticator.Authenticate(inv, testUrl))
+
+	inv.SetAttachment(constant.RequestSignatureKey, signature[1:])
+	err = authenticator.Authenticate(inv, testUrl)
+	assert.Equal(t, ReasonInvalidSignature, failureReason(err))
+}
+
+func TestDefaultAuthenticator_Nonce(t *testing.T) {
+	authenticator := &defaultAuthenticator{nonces: newNonceCache()}
+	testUrl, _ := common.NewURL("dubbo://127.0.0.1:20000/com.ikurento.user.UserProvider?application=test&interface=com.ikurento.user.UserProvider")
+	testUrl.SetParam(constant.AccessKeyIDKey, "akey")
+	testUrl.SetParam(constant.SecretAccessKeyKey, "skey")
+	testUrl.SetParam(constant.AuthNonceKey, "true")
+
+	// the nonces are signed since v2
+	inv := newSignedInvocation(t, authenticator, testUrl)
+	assert.Equal(t, constant.SignatureV2, inv.GetAttachmentWithDefaultValue(constant.SignatureVersionKey, ""))
+	assert.NotEmpty(t, inv.GetAttachmentWithDefaultValue(constant.RequestNonceKey, ""))
+	assert.NoError(t, authenticator.Authenticate(inv, testUrl))
+
+	// the replay is rejected
+	err := authenticator.Authenticate(inv, testUrl)
+	assert.Equal(t, ReasonReplayed, failureReason(err))
+
+	// the nonce can't be replaced
+	inv.SetAttachment(constant.RequestNonceKey, "another")
+	err = authenticator.Authenticate(inv, testUrl)
+	assert.Equal(t, ReasonInvalidSignature, failureReason(err))
+
+	inv = newSignedInvocation(t, authenticator, testUrl)
+	inv.SetAttachment(constant.RequestNonceKey, "")
+	err = authenticator.Authenticate(inv, testUrl)
+	assert.Equal(t, ReasonMissingCredentials, failureReason(err))
+
+	// the requests are rejected if the nonces within the window are too many
+	testUrl.SetParam(constant.AuthNonceCacheSizeKey, "1")
+	inv = newSignedInvocation(t, authenticator, testUrl)
+	err = authenticator.Authenticate(inv, testUrl)
+	assert.Equal(t, ReasonNonceCacheFull, failureReason(err))
+	testUrl.SetParam(constant.AuthNonceCacheSizeKey, "")
+
+	// the nonce is live until the timestamp of the request is out of the window
+	now := time.Now()
+	signed := now.Add(-4 * time.Minute).UnixMilli()
+	assert.NoError(t, authenticator.checkNonce("akey#signed", signed, 5*time.Minute, 10, now))
+	err = authenticator.checkNonce("akey#signed", signed, 5*time.Minute, 10, now.Add(30*time.Second))
+	assert.Equal(t, ReasonReplayed, failureReason(err))
+	assert.NoError(t, authenticator.checkNonce("akey#signed", signed, 5*time.Minute, 10, now.Add(61*time.Second)))
+
+	// v1 doesn't sign the nonces, so it's rejected
+	testUrl.SetParam(constant.AuthNonceKey, "false")
+	inv = newSignedInvocation(t, authenticator, testUrl)
+	testUrl.SetParam(constant.AuthNonceKey, "true")
+	err = authenticator.Authenticate(inv, testUrl)
+	assert.Equal(t, ReasonUnsupportedVersion, failureReason(err))
+}
+
+func TestDefaultAuthenticator_SignatureVersion(t *testing.T) {
+	authenticator := &defaultAuthenticator{nonces: newNonceCache()}
+	testUrl, _ := common.NewURL("dubbo://127.0.0.1:20000/com.ikurento.user.UserProvider?application=test&interface=com.ikurento.user.UserProvider")
+	testUrl.SetParam(constant.AccessKeyIDKey, "akey")
+	testUrl.SetParam(constant.SecretAccessKeyKey, "skey")
+	testUrl.SetParam(constant.ParameterSignatureEnableKey, "true")
+
+	v1 := newSignedInvocation(t, authenticator, testUrl)
+	assert.Empty(t, v1.GetAttachmentWithDefaultValue(constant.SignatureVersionKey, ""))
+	testUrl.SetParam(constant.AuthSignatureVersionKey, constant.SignatureV2)
+	v2 := newSignedInvocation(t, authenticator, testUrl)
+	assert.NotEqual(t, v1.GetAttachmentWithDefaultValue(constant.RequestSignatureKey, ""),
+		v2.GetAttachmentWithDefaultValue(constant.RequestSignatureKey, ""))
+
+	// the provider of v2 rejects v1, and the provider of v1 accepts both
+	assert.NoError(t, authenticator.Authenticate(v2, testUrl))
+	assert.Equal(t, ReasonUnsupportedVersion, failureReason(authenticator.Authenticate(v1, testUrl)))
+	testUrl.SetParam(constant.AuthSignatureVersionKey, constant.SignatureV1)
+	assert.NoError(t, authenticator.Authenticate(v1, testUrl))
+	assert.NoError(t, authenticator.Authenticate(v2, testUrl))
+
+	v2.SetAttachment(constant.SignatureVersionKey, "v9")
+	assert.Equal(t, ReasonUnsupportedVersion, failureReason(authenticator.Authenticate(v2, testUrl)))
+	testUrl.SetParam(constant.AuthSignatureVersionKey, "v9")
+	assert.Error(t, authenticator.Sign(invocation.NewRPCInvocation("test", []any{"OK"}, nil), testUrl))
+}
@@ -16,4 +16,42 @@
  */
 
 // Package auth providers authorization filter.
+/*
+ The authenticator is chosen by the param "authenticator", the default one signs the invocations by the AK/SK,
+ and the jwt one authenticates them by the JWT bearer tokens, for example:
+ provider:
+   services:
+     "UserProvider":
+       interface: "com.ikurento.user.UserProvider"
+       auth: "true"
+       params:
+         authenticator: "jwt"
+         jwt.jwks.file: "/etc/dubbo/jwks.json" # or jwt.public.key for a PEM file, or jwt.secret for HS256
+         jwt.issuer: "https://issuer.example.com"
+         jwt.audience: "user-service"
+         jwt.clock.skew: "30s"
+       methods:
+        - name: "GetUser"
+          scopes: "user.read" # the scopes the token must be granted, separated by comma
+ consumer:
+   references:
+     "UserProvider":
+       params:
+         token.source: "oauth2" # or static to send jwt.token, e.g. ${file:/var/run/secrets/token}
+         oauth2.token.url: "https://issuer.example.com/oauth2/token"
+         oauth2.client.id: "order-service"
+         oauth2.client.secret: "${file:/var/run/secrets/client-secret}"
+         oauth2.scopes: "user.read"
+ The handlers get the verified claims of the token by ClaimsFromContext, the custom token sources can be
+ registered by invoking extension.SetTokenSource.
+
+ The default authenticator rejects the requests whose timestamps are out of the window, and the replays of the
+ requests within the window if the nonces are enabled:
+       params:
+         auth.timestamp.window: "5m" # 0s disables the check
+         auth.nonce: "true" # the consumers attach the nonces, which are signed by v2 at least
+         auth.nonce.cache.size: "100000" # the max nonces within the window, the requests beyond it are rejected
+         auth.signature.version: "v2" # the version consumers sign with and the min version providers accept
+ The failures are counted by the metric dubbo_provider_auth_failed_total with the reasons.
+*/
 package auth
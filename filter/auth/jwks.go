@@ -0,0 +1,270 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+)
+
+// jwtKey is a key verifying the signatures of the tokens, key is []byte for HMAC, *rsa.PublicKey,
+// *ecdsa.PublicKey or ed25519.PublicKey
+type jwtKey struct {
+	kid string
+	alg string
+	key any
+}
+
+// matches returns true if the key may have signed the token of the header
+func (k *jwtKey) matches(header jwtHeader) bool {
+	if k.kid != "" && header.Kid != "" && k.kid != header.Kid {
+		return false
+	}
+	return k.alg == "" || k.alg == header.Alg
+}
+
+// keyFile is a file of the keys, it's reloaded once it's modified
+type keyFile struct {
+	modTime time.Time
+	size    int64
+	keys    []*jwtKey
+}
+
+var (
+	keyFilesLock sync.Mutex
+	keyFiles     = make(map[string]*keyFile)
+)
+
+// getJWTKeys returns the keys verifying the tokens of the url, they are the secret, the keys in the PEM file
+// and the ones in the JWKS file
+func getJWTKeys(url *common.URL) ([]*jwtKey, error) {
+	var keys []*jwtKey
+	if secret := resolveSecret(url.GetParam(constant.JWTSecretKey, "")); secret != "" {
+		keys = append(keys, &jwtKey{key: []byte(secret)})
+	}
+	if path := url.GetParam(constant.JWTPublicKeyKey, ""); path != "" {
+		pemKeys, err := loadKeyFile(path, parsePEMKeys)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, pemKeys...)
+	}
+	if path := url.GetParam(constant.JWTJWKSFileKey, ""); path != "" {
+		jwks, err := loadKeyFile(path, parseJWKS)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, jwks...)
+	}
+	if len(keys) == 0 {
+		return nil, perrors.Errorf("none of %s, %s and %s is configured to verify the tokens",
+			constant.JWTSecretKey, constant.JWTPublicKeyKey, constant.JWTJWKSFileKey)
+	}
+	return keys, nil
+}
+
+// loadKeyFile returns the keys parsed from the file, they are cached until the file is modified
+func loadKeyFile(path string, parse func([]byte) ([]*jwtKey, error)) ([]*jwtKey, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, perrors.Wrapf(err, "failed to stat the key file %s", path)
+	}
+
+	keyFilesLock.Lock()
+	defer keyFilesLock.Unlock()
+	if cached, ok := keyFiles[path]; ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.keys, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, perrors.Wrapf(err, "failed to read the key file %s", path)
+	}
+	keys, err := parse(data)
+	if err != nil {
+		return nil, perrors.Wrapf(err, "failed to parse the key file %s", path)
+	}
+	keyFiles[path] = &keyFile{modTime: info.ModTime(), size: info.Size(), keys: keys}
+	logger.Infof("[Auth] %d keys are loaded from %s", len(keys), path)
+	return keys, nil
+}
+
+// parsePEMKeys parses the public keys and the certificates in PEM
+func parsePEMKeys(data []byte) ([]*jwtKey, error) {
+	var keys []*jwtKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		var (
+			key any
+			err error
+		)
+		switch block.Type {
+		case "PUBLIC KEY":
+			key, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		case "CERTIFICATE":
+			var cert *x509.Certificate
+			if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+				key = cert.PublicKey
+			}
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, &jwtKey{key: key})
+	}
+	if len(keys) == 0 {
+		return nil, perrors.New("no public key is found")
+	}
+	return keys, nil
+}
+
+// jwk is a JSON web key of RFC 7517
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC and OKP
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	// oct
+	K string `json:"k"`
+}
+
+// parseJWKS parses the JWK set, the keys which are not for signatures or of the unsupported types are skipped
+func parseJWKS(data []byte) ([]*jwtKey, error) {
+	var set struct {
+		Keys []*jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	keys := make([]*jwtKey, 0, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			logger.Warnf("[Auth] the key %s in JWKS is skipped: %v", k.Kid, err)
+			continue
+		}
+		keys = append(keys, &jwtKey{kid: k.Kid, alg: k.Alg, key: key})
+	}
+	if len(keys) == 0 {
+		return nil, perrors.New("no key for signatures is found")
+	}
+	return keys, nil
+}
+
+func (k *jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, perrors.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, perrors.New("the point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, perrors.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, perrors.New("invalid Ed25519 public key")
+		}
+		return ed25519.PublicKey(x), nil
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(k.K)
+		if err != nil {
+			return nil, err
+		}
+		return secret, nil
+	default:
+		return nil, perrors.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,309 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+// Claims are the claims of a verified JWT, the numeric ones like exp are float64 as they are decoded from json
+type Claims map[string]any
+
+type claimsKey struct{}
+
+// ClaimsFromContext returns the claims of the bearer token verified by the jwt authenticator,
+// the handlers of the providers get them from the context of the invocations
+func ClaimsFromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(Claims)
+	return claims, ok
+}
+
+// Subject returns the sub claim
+func (c Claims) Subject() string {
+	sub, _ := c["sub"].(string)
+	return sub
+}
+
+// Issuer returns the iss claim
+func (c Claims) Issuer() string {
+	iss, _ := c["iss"].(string)
+	return iss
+}
+
+// Audience returns the aud claim, it's either a string or an array of strings
+func (c Claims) Audience() []string {
+	return stringList(c["aud"], "")
+}
+
+// Scopes returns the scopes granted to the token, they are in the scope claim separated by spaces,
+// or the scp claim which is either a string separated by spaces or an array of strings
+func (c Claims) Scopes() []string {
+	if scope, ok := c["scope"]; ok {
+		return stringList(scope, " ")
+	}
+	return stringList(c["scp"], " ")
+}
+
+// time returns the numeric date claim of the name
+func (c Claims) time(name string) (time.Time, bool) {
+	value, ok := c[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	seconds := int64(value)
+	return time.Unix(seconds, int64((value-float64(seconds))*float64(time.Second))), true
+}
+
+func stringList(value any, sep string) []string {
+	switch v := value.(type) {
+	case string:
+		if sep == "" {
+			return []string{v}
+		}
+		return strings.Fields(v)
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+// jwtHeader is the JOSE header of a JWT
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// jwtValidation is what the claims of a JWT are checked against
+type jwtValidation struct {
+	issuer    string
+	audiences []string
+	scopes    []string
+	clockSkew time.Duration
+	now       time.Time
+}
+
+// parseJWT decodes the JWS compact serialization without verifying it
+func parseJWT(token string) (header jwtHeader, claims Claims, signingInput string, signature []byte, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		err = perrors.New("the token is not a JWS in the compact serialization")
+		return
+	}
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		err = perrors.Wrap(err, "failed to decode the header of the token")
+		return
+	}
+	if err = json.Unmarshal(headerJSON, &header); err != nil {
+		err = perrors.Wrap(err, "failed to unmarshal the header of the token")
+		return
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		err = perrors.Wrap(err, "failed to decode the claims of the token")
+		return
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		err = perrors.Wrap(err, "failed to unmarshal the claims of the token")
+		return
+	}
+	if signature, err = base64.RawURLEncoding.DecodeString(parts[2]); err != nil {
+		err = perrors.Wrap(err, "failed to decode the signature of the token")
+		return
+	}
+	signingInput = parts[0] + "." + parts[1]
+	return
+}
+
+// verifyJWT verifies the signature of the token by the keys and validates its claims
+func verifyJWT(token string, keys []*jwtKey, validation *jwtValidation) (Claims, error) {
+	header, claims, signingInput, signature, err := parseJWT(token)
+	if err != nil {
+		return nil, err
+	}
+	if !supportedAlg(header.Alg) {
+		// none is never accepted
+		return nil, perrors.Errorf("the alg %q of the token is not supported", header.Alg)
+	}
+
+	verified := false
+	for _, key := range keys {
+		if !key.matches(header) {
+			continue
+		}
+		if verifySignature(header.Alg, key.key, []byte(signingInput), signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, perrors.Errorf("failed to verify the signature of the token, alg: %s, kid: %s", header.Alg, header.Kid)
+	}
+	if err = validation.validate(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// validate checks the time, the issuer, the audience and the scopes of the claims
+func (v *jwtValidation) validate(claims Claims) error {
+	exp, ok := claims.time("exp")
+	if !ok {
+		return perrors.New("the token has no exp claim")
+	}
+	if v.now.After(exp.Add(v.clockSkew)) {
+		return perrors.Errorf("the token expired at %s", exp.Format(time.RFC3339))
+	}
+	if nbf, ok := claims.time("nbf"); ok && v.now.Add(v.clockSkew).Before(nbf) {
+		return perrors.Errorf("the token is not valid before %s", nbf.Format(time.RFC3339))
+	}
+	if iat, ok := claims.time("iat"); ok && v.now.Add(v.clockSkew).Before(iat) {
+		return perrors.Errorf("the token is issued in the future at %s", iat.Format(time.RFC3339))
+	}
+
+	if v.issuer != "" && claims.Issuer() != v.issuer {
+		return perrors.Errorf("the issuer %q of the token is not %q", claims.Issuer(), v.issuer)
+	}
+	if len(v.audiences) > 0 && !intersects(claims.Audience(), v.audiences) {
+		return perrors.Errorf("the audience %v of the token is not any of %v", claims.Audience(), v.audiences)
+	}
+	if len(v.scopes) > 0 {
+		granted := claims.Scopes()
+		for _, scope := range v.scopes {
+			if !contains(granted, scope) {
+				return perrors.Errorf("the token is not granted the scope %q", scope)
+			}
+		}
+	}
+	return nil
+}
+
+func supportedAlg(alg string) bool {
+	switch alg {
+	case "HS256", "HS384", "HS512", "RS256", "RS384", "RS512", "PS256", "PS384", "PS512",
+		"ES256", "ES384", "ES512", "EdDSA":
+		return true
+	}
+	return false
+}
+
+// algHash returns the hash of the alg like RS256
+func algHash(alg string) crypto.Hash {
+	switch alg[len(alg)-3:] {
+	case "384":
+		return crypto.SHA384
+	case "512":
+		return crypto.SHA512
+	default:
+		return crypto.SHA256
+	}
+}
+
+// esCurveBits are the bit sizes of the curves of ES256, ES384 and ES512
+var esCurveBits = map[crypto.Hash]int{
+	crypto.SHA256: 256,
+	crypto.SHA384: 384,
+	crypto.SHA512: 521,
+}
+
+// verifySignature verifies the signature of the signing input by the key, it returns false if the key
+// is not the kind the alg requires
+func verifySignature(alg string, key any, signingInput, signature []byte) bool {
+	if alg == "EdDSA" {
+		pub, ok := key.(ed25519.PublicKey)
+		return ok && ed25519.Verify(pub, signingInput, signature)
+	}
+
+	hash := algHash(alg)
+	switch alg[:2] {
+	case "HS":
+		secret, ok := key.([]byte)
+		if !ok {
+			return false
+		}
+		mac := hmac.New(hash.New, secret)
+		mac.Write(signingInput)
+		return hmac.Equal(mac.Sum(nil), signature)
+	}
+
+	h := hash.New()
+	h.Write(signingInput)
+	digest := h.Sum(nil)
+	switch alg[:2] {
+	case "RS":
+		pub, ok := key.(*rsa.PublicKey)
+		return ok && rsa.VerifyPKCS1v15(pub, hash, digest, signature) == nil
+	case "PS":
+		pub, ok := key.(*rsa.PublicKey)
+		return ok && rsa.VerifyPSS(pub, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}) == nil
+	case "ES":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return false
+		}
+		// the signature is r and s of the size of the curve, and the curve must be the one of the alg
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size || pub.Curve.Params().BitSize != esCurveBits[hash] {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(pub, digest, r, s)
+	}
+	return false
+}
+
+func intersects(a, b []string) bool {
+	for _, item := range a {
+		if contains(b, item) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, item string) bool {
+	for _, v := range list {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/filter"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+)
+
+const bearerPrefix = "Bearer "
+
+var (
+	jwtAuthenticatorOnce sync.Once
+	jwtAuth              *jwtAuthenticator
+)
+
+func init() {
+	extension.SetAuthenticator(constant.JWTAuthenticator, newJWTAuthenticator)
+}
+
+// jwtAuthenticator authenticates the invocations by the JWT bearer tokens. Consumers attach the tokens got
+// from the token source, and providers verify their signatures and claims.
+type jwtAuthenticator struct{}
+
+func newJWTAuthenticator() filter.Authenticator {
+	if jwtAuth == nil {
+		jwtAuthenticatorOnce.Do(func() {
+			jwtAuth = &jwtAuthenticator{}
+		})
+	}
+	return jwtAuth
+}
+
+// Sign attaches the bearer token got from the token source to the invocation
+func (a *jwtAuthenticator) Sign(inv base.Invocation, url *common.URL) error {
+	source, err := extension.GetTokenSource(url.GetParam(constant.TokenSourceKey, constant.DefaultTokenSource))
+	if err != nil {
+		return err
+	}
+	token, err := source.Token(inv, url)
+	if err != nil {
+		return perrors.WithMessage(err, "failed to get the token")
+	}
+	inv.SetAttachment(constant.AuthorizationKey, bearerPrefix+token)
+	return nil
+}
+
+// Authenticate verifies the bearer token of the invocation
+func (a *jwtAuthenticator) Authenticate(inv base.Invocation, url *common.URL) error {
+	_, err := a.AuthenticateContext(context.Background(), inv, url)
+	return err
+}
+
+// AuthenticateContext verifies the bearer token of the invocation, and returns the context carrying its claims,
+// which are got by ClaimsFromContext
+func (a *jwtAuthenticator) AuthenticateContext(ctx context.Context, inv base.Invocation, url *common.URL) (context.Context, error) {
+	authorization := inv.GetAttachmentWithDefaultValue(constant.AuthorizationKey, "")
+	if len(authorization) <= len(bearerPrefix) || !strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return ctx, NewAuthError(ReasonMissingCredentials,
+			perrors.New("failed to authenticate, the bearer token is missing, maybe the consumer has not enabled the auth"))
+	}
+
+	keys, err := getJWTKeys(url)
+	if err != nil {
+		return ctx, NewAuthError(ReasonKeyNotFound, err)
+	}
+	clockSkew, err := time.ParseDuration(url.GetParam(constant.JWTClockSkewKey, "0s"))
+	if err != nil {
+		return ctx, perrors.Wrapf(err, "invalid %s", constant.JWTClockSkewKey)
+	}
+	methodName := inv.MethodName()
+	validation := &jwtValidation{
+		issuer:    url.GetParam(constant.JWTIssuerKey, ""),
+		audiences: splitList(url.GetParam(constant.JWTAudienceKey, "")),
+		scopes:    splitList(url.GetMethodParam(methodName, constant.RequiredScopesKey, url.GetParam(constant.RequiredScopesKey, ""))),
+		clockSkew: clockSkew,
+		now:       time.Now(),
+	}
+	claims, err := verifyJWT(strings.TrimSpace(authorization[len(bearerPrefix):]), keys, validation)
+	if err != nil {
+		return ctx, NewAuthError(ReasonInvalidToken, perrors.WithMessage(err, "failed to authenticate"))
+	}
+	return context.WithValue(ctx, claimsKey{}, claims), nil
+}
+
+// splitList splits the comma separated list and trims the items
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, constant.CommaSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,266 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/protocol/mock"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+)
+
+// signToken signs the claims by the key, the key is []byte, *rsa.PrivateKey or *ecdsa.PrivateKey
+func signToken(t *testing.T, alg, kid string, key any, claims map[string]any) string {
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	assert.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	assert.NoError(t, err)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	hash := algHash(alg)
+	var signature []byte
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(hash.New, k)
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	case *rsa.PrivateKey:
+		h := hash.New()
+		h.Write([]byte(signingInput))
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, hash, h.Sum(nil))
+		assert.NoError(t, err)
+	case *ecdsa.PrivateKey:
+		h := hash.New()
+		h.Write([]byte(signingInput))
+		r, s, err := ecdsa.Sign(rand.Reader, k, h.Sum(nil))
+		assert.NoError(t, err)
+		size := (k.Curve.Params().BitSize + 7) / 8
+		signature = make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		s.FillBytes(signature[size:])
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func newJWTURL(params ...string) *common.URL {
+	url, _ := common.NewURL("dubbo://127.0.0.1:20000/com.ikurento.user.UserProvider?interface=com.ikurento.user.UserProvider")
+	url.SetParam(constant.AuthenticatorKey, constant.JWTAuthenticator)
+	for i := 0; i+1 < len(params); i += 2 {
+		url.SetParam(params[i], params[i+1])
+	}
+	return url
+}
+
+func bearer(token string) base.Invocation {
+	return invocation.NewRPCInvocation("GetUser", []any{"OK"}, map[string]any{
+		constant.AuthorizationKey: bearerPrefix + token,
+	})
+}
+
+func TestJWTAuthenticator_Sign(t *testing.T) {
+	authenticator := newJWTAuthenticator()
+	inv := invocation.NewRPCInvocation("GetUser", []any{"OK"}, nil)
+
+	err := authenticator.Sign(inv, newJWTURL())
+	assert.Error(t, err)
+
+	err = authenticator.Sign(inv, newJWTURL(constant.JWTTokenKey, "token"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer token", inv.GetAttachmentWithDefaultValue(constant.AuthorizationKey, ""))
+
+	err = authenticator.Sign(inv, newJWTURL(constant.TokenSourceKey, "unknown"))
+	assert.Error(t, err)
+}
+
+func TestJWTAuthenticator_Authenticate(t *testing.T) {
+	secret := []byte("dubbo-jwt-secret")
+	now := time.Now()
+	url := newJWTURL(constant.JWTSecretKey, string(secret),
+		constant.JWTIssuerKey, "https://issuer.example.com",
+		constant.JWTAudienceKey, "user-service, order-service",
+		constant.JWTClockSkewKey, "30s",
+		"methods.GetUser."+constant.RequiredScopesKey, "user.read")
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{
+			"sub":   "alice",
+			"iss":   "https://issuer.example.com",
+			"aud":   []string{"user-service"},
+			"exp":   now.Add(time.Minute).Unix(),
+			"iat":   now.Unix(),
+			"scope": "user.read user.write",
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+	authenticator := newJWTAuthenticator().(*jwtAuthenticator)
+
+	ctx, err := authenticator.AuthenticateContext(context.Background(), bearer(signToken(t, "HS256", "", secret, claims(nil))), url)
+	assert.NoError(t, err)
+	verified, ok := ClaimsFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "alice", verified.Subject())
+	assert.Equal(t, []string{"user.read", "user.write"}, verified.Scopes())
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{"HS512", signToken(t, "HS512", "", secret, claims(nil)), true},
+		{"expired within the clock skew", signToken(t, "HS256", "", secret, claims(map[string]any{"exp": now.Add(-10 * time.Second).Unix()})), true},
+		{"scp claim", signToken(t, "HS256", "", secret, claims(map[string]any{"scope": nil, "scp": []string{"user.read"}})), true},
+		{"expired", signToken(t, "HS256", "", secret, claims(map[string]any{"exp": now.Add(-time.Minute).Unix()})), false},
+		{"no exp", signToken(t, "HS256", "", secret, claims(map[string]any{"exp": nil})), false},
+		{"not before", signToken(t, "HS256", "", secret, claims(map[string]any{"nbf": now.Add(time.Minute).Unix()})), false},
+		{"issuer", signToken(t, "HS256", "", secret, claims(map[string]any{"iss": "https://other.example.com"})), false},
+		{"audience", signToken(t, "HS256", "", secret, claims(map[string]any{"aud": "pay-service"})), false},
+		{"scope", signToken(t, "HS256", "", secret, claims(map[string]any{"scope": "user.write"})), false},
+		{"secret", signToken(t, "HS256", "", []byte("other"), claims(nil)), false},
+		{"alg none", signToken(t, "none", "", secret, claims(nil)), false},
+		{"malformed", "a.b", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := authenticator.Authenticate(bearer(tt.token), url)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+
+	// the scopes are only required by the configured methods
+	inv := invocation.NewRPCInvocation("ListUsers", []any{"OK"}, map[string]any{
+		constant.AuthorizationKey: bearerPrefix + signToken(t, "HS256", "", secret, claims(map[string]any{"scope": nil})),
+	})
+	assert.NoError(t, authenticator.Authenticate(inv, url))
+
+	// the consumer has not enabled the auth
+	assert.Error(t, authenticator.Authenticate(invocation.NewRPCInvocation("GetUser", []any{"OK"}, nil), url))
+}
+
+func TestJWTAuthenticator_KeyFiles(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	claims := map[string]any{"sub": "alice", "exp": time.Now().Add(time.Minute).Unix()}
+	dir := t.TempDir()
+
+	// JWKS
+	jwksFile := filepath.Join(dir, "jwks.json")
+	writeJWKS := func(keys ...map[string]string) {
+		data, err := json.Marshal(map[string]any{"keys": keys})
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(jwksFile, data, 0o600))
+	}
+	rsaJWK := map[string]string{
+		"kty": "RSA", "kid": "rsa", "alg": "RS256", "use": "sig",
+		"n": base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+		"e": base64.RawURLEncoding.EncodeToString([]byte{1, 0, 1}),
+	}
+	ecJWK := map[string]string{
+		"kty": "EC", "kid": "ec", "crv": "P-256",
+		"x": base64.RawURLEncoding.EncodeToString(ecKey.X.FillBytes(make([]byte, 32))),
+		"y": base64.RawURLEncoding.EncodeToString(ecKey.Y.FillBytes(make([]byte, 32))),
+	}
+	writeJWKS(rsaJWK, ecJWK, map[string]string{"kty": "RSA", "kid": "enc", "use": "enc"})
+	url := newJWTURL(constant.JWTJWKSFileKey, jwksFile)
+	authenticator := newJWTAuthenticator()
+
+	assert.NoError(t, authenticator.Authenticate(bearer(signToken(t, "RS256", "rsa", rsaKey, claims)), url))
+	assert.NoError(t, authenticator.Authenticate(bearer(signToken(t, "ES256", "ec", ecKey, claims)), url))
+	assert.NoError(t, authenticator.Authenticate(bearer(signToken(t, "ES256", "", ecKey, claims)), url))
+	// the alg of the key is RS256
+	assert.Error(t, authenticator.Authenticate(bearer(signToken(t, "RS512", "rsa", rsaKey, claims)), url))
+	assert.Error(t, authenticator.Authenticate(bearer(signToken(t, "ES256", "rsa", ecKey, claims)), url))
+	assert.Error(t, authenticator.Authenticate(bearer(signToken(t, "ES256", "ec", otherKey, claims)), url))
+
+	// the JWKS is reloaded once it's modified
+	writeJWKS(rsaJWK)
+	modTime := time.Now().Add(time.Second)
+	assert.NoError(t, os.Chtimes(jwksFile, modTime, modTime))
+	assert.Error(t, authenticator.Authenticate(bearer(signToken(t, "ES256", "ec", ecKey, claims)), url))
+
+	// PEM
+	der, err := x509.MarshalPKIXPublicKey(&otherKey.PublicKey)
+	assert.NoError(t, err)
+	pemFile := filepath.Join(dir, "public.pem")
+	assert.NoError(t, os.WriteFile(pemFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+	url = newJWTURL(constant.JWTPublicKeyKey, pemFile)
+	assert.NoError(t, authenticator.Authenticate(bearer(signToken(t, "ES256", "", otherKey, claims)), url))
+	assert.Error(t, authenticator.Authenticate(bearer(signToken(t, "ES256", "", ecKey, claims)), url))
+
+	// no key is configured
+	assert.Error(t, authenticator.Authenticate(bearer(signToken(t, "ES256", "", ecKey, claims)), newJWTURL()))
+}
+
+func TestAuthFilter_Claims(t *testing.T) {
+	secret := []byte("dubbo-jwt-secret")
+	url := newJWTURL(constant.ServiceAuthKey, "true", constant.JWTSecretKey, string(secret))
+	inv := bearer(signToken(t, "HS256", "", secret, map[string]any{"sub": "alice", "exp": time.Now().Add(time.Minute).Unix()}))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	invoker := mock.NewMockInvoker(ctrl)
+	res := &result.RPCResult{}
+	invoker.EXPECT().GetURL().Return(url).AnyTimes()
+	invoker.EXPECT().Invoke(gomock.Any(), inv).DoAndReturn(func(ctx context.Context, _ base.Invocation) result.Result {
+		claims, ok := ClaimsFromContext(ctx)
+		assert.True(t, ok)
+		assert.Equal(t, "alice", claims.Subject())
+		return res
+	}).Times(1)
+
+	assert.Equal(t, res, newAuthFilter().Invoke(context.Background(), invoker, inv))
+	assert.Error(t, newAuthFilter().Invoke(context.Background(), invoker, bearer("invalid")).Error())
+}
@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"container/heap"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// nonceCache remembers the nonces until the timestamps of their requests are out of the window. The live nonces
+// are never evicted, since the requests reusing them would be accepted as new ones, so the nonces are rejected
+// if the cache is full of the live ones.
+type nonceCache struct {
+	lock    sync.Mutex
+	entries map[string]*nonceEntry
+	// expiries is the min heap of the entries by their expiries
+	expiries nonceHeap
+	seq      uint64
+}
+
+type nonceEntry struct {
+	nonce  string
+	expiry time.Time
+	// seq is the order the entries are added in
+	seq   uint64
+	index int
+}
+
+// nonceAddResult is the result of nonceCache.add
+type nonceAddResult int
+
+const (
+	nonceAdded nonceAddResult = iota
+	// nonceReplayed means the nonce has been added and not expired
+	nonceReplayed
+	// nonceCacheFull means the nonce can't be added without evicting the live ones
+	nonceCacheFull
+)
+
+func newNonceCache() *nonceCache {
+	return &nonceCache{entries: make(map[string]*nonceEntry)}
+}
+
+// add adds the nonce which is live until expiry. The zero expiry means the nonce never expires, the one expiring
+// first or the oldest one is evicted for it once the cache is full, since the cache would be full forever otherwise.
+func (c *nonceCache) add(nonce string, expiry time.Time, capacity int, now time.Time) nonceAddResult {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for len(c.expiries) > 0 && c.expiries[0].expired(now) {
+		c.remove(c.expiries[0])
+	}
+	if entry, ok := c.entries[nonce]; ok {
+		if !entry.expired(now) {
+			return nonceReplayed
+		}
+		c.remove(entry)
+	}
+	if !expiry.IsZero() && !expiry.After(now) {
+		// the nonce has expired already, the request is out of the window
+		return nonceAdded
+	}
+	if capacity > 0 && len(c.expiries) >= capacity {
+		if !expiry.IsZero() {
+			return nonceCacheFull
+		}
+		c.remove(c.expiries[0])
+	}
+
+	c.seq++
+	entry := &nonceEntry{nonce: nonce, expiry: expiry, seq: c.seq}
+	c.entries[nonce] = entry
+	heap.Push(&c.expiries, entry)
+	return nonceAdded
+}
+
+func (c *nonceCache) remove(entry *nonceEntry) {
+	heap.Remove(&c.expiries, entry.index)
+	delete(c.entries, entry.nonce)
+}
+
+func (c *nonceCache) len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.expiries)
+}
+
+func (e *nonceEntry) expired(now time.Time) bool {
+	return !e.expiry.IsZero() && !e.expiry.After(now)
+}
+
+// nonceHeap implements heap.Interface, the entries never expiring are the last ones in the order they are added
+type nonceHeap []*nonceEntry
+
+func (h nonceHeap) Len() int {
+	return len(h)
+}
+
+func (h nonceHeap) Less(i, j int) bool {
+	if h[i].expiry.IsZero() && h[j].expiry.IsZero() {
+		return h[i].seq < h[j].seq
+	}
+	if h[i].expiry.IsZero() || h[j].expiry.IsZero() {
+		return !h[i].expiry.IsZero()
+	}
+	return h[i].expiry.Before(h[j].expiry)
+}
+
+func (h nonceHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *nonceHeap) Push(x any) {
+	entry := x.(*nonceEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *nonceHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return entry
+}
+
+// newNonce returns a random nonce of 128 bits
+func newNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonceCache(t *testing.T) {
+	cache := newNonceCache()
+	now := time.Now()
+
+	assert.Equal(t, nonceAdded, cache.add("a", now.Add(time.Minute), 2, now))
+	assert.Equal(t, nonceReplayed, cache.add("a", now.Add(time.Minute), 2, now.Add(time.Second)))
+	assert.Equal(t, nonceAdded, cache.add("b", now.Add(2*time.Minute), 2, now.Add(time.Second)))
+
+	// the live nonces are never evicted
+	assert.Equal(t, nonceCacheFull, cache.add("c", now.Add(3*time.Minute), 2, now.Add(2*time.Second)))
+	assert.Equal(t, 2, cache.len())
+	assert.Equal(t, nonceReplayed, cache.add("a", now.Add(time.Minute), 2, now.Add(3*time.Second)))
+
+	// the expired ones are evicted in the order of their expiries rather than the order they are added
+	assert.Equal(t, nonceAdded, cache.add("c", now.Add(3*time.Minute), 2, now.Add(time.Minute)))
+	assert.Equal(t, nonceReplayed, cache.add("b", now.Add(2*time.Minute), 2, now.Add(time.Minute)))
+	assert.Equal(t, nonceAdded, cache.add("a", now.Add(4*time.Minute), 10, now.Add(2*time.Minute)))
+	assert.Equal(t, 2, cache.len())
+
+	// the nonce expired already isn't kept
+	assert.Equal(t, nonceAdded, cache.add("d", now, 10, now.Add(2*time.Minute)))
+	assert.Equal(t, 2, cache.len())
+
+	// the nonces never expiring evict the one expiring first or the oldest one
+	cache = newNonceCache()
+	assert.Equal(t, nonceAdded, cache.add("a", time.Time{}, 2, now))
+	assert.Equal(t, nonceAdded, cache.add("b", now.Add(time.Minute), 2, now))
+	assert.Equal(t, nonceAdded, cache.add("c", time.Time{}, 2, now))
+	assert.Equal(t, nonceReplayed, cache.add("a", time.Time{}, 2, now))
+	assert.Equal(t, nonceAdded, cache.add("d", time.Time{}, 2, now))
+	assert.Equal(t, nonceAdded, cache.add("a", time.Time{}, 2, now.Add(time.Hour)))
+}
+
+func TestNewNonce(t *testing.T) {
+	a, err := newNonce()
+	assert.NoError(t, err)
+	b, err := newNonce()
+	assert.NoError(t, err)
+	assert.Len(t, a, 32)
+	assert.NotEqual(t, a, b)
+}
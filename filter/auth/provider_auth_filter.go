@@ -30,6 +30,8 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/filter"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsRpc "dubbo.apache.org/dubbo-go/v3/metrics/rpc"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
@@ -55,15 +57,23 @@ func newAuthFilter() filter.Filter {
 	return auth
 }
 
-// Invoke retrieves the configured Authenticator to verify the signature in an invocation
+// Invoke retrieves the configured Authenticator to verify the signature in an invocation, the context returned by
+// the ContextAuthenticator is passed to the invoker
 func (paf *authFilter) Invoke(ctx context.Context, invoker base.Invoker, invocation base.Invocation) result.Result {
 	url := invoker.GetURL()
 
 	err := doAuthWork(url, func(authenticator filter.Authenticator) error {
+		if ctxAuthenticator, ok := authenticator.(filter.ContextAuthenticator); ok {
+			// the verified identity is passed to the handler by the context
+			var err error
+			ctx, err = ctxAuthenticator.AuthenticateContext(ctx, invocation, url)
+			return err
+		}
 		return authenticator.Authenticate(invocation, url)
 	})
 	if err != nil {
 		logger.Errorf("auth the request: %v occur exception, cause: %s", invocation, err.Error())
+		metrics.Publish(metricsRpc.NewAuthFailedEvent(invoker, invocation, failureReason(err)))
 		return &result.RPCResult{
 			Err: err,
 		}
@@ -0,0 +1,189 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/filter"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+)
+
+const (
+	// oauth2ExpiryDelta is how long before the expiry the cached token is refreshed
+	oauth2ExpiryDelta = 10 * time.Second
+	// oauth2Timeout is the timeout of the requests to the token endpoint
+	oauth2Timeout = 10 * time.Second
+)
+
+var (
+	staticTokenSourceOnce sync.Once
+	staticSource          *staticTokenSource
+	oauth2TokenSourceOnce sync.Once
+	oauth2Source          *oauth2TokenSource
+)
+
+func init() {
+	extension.SetTokenSource(constant.DefaultTokenSource, newStaticTokenSource)
+	extension.SetTokenSource(constant.OAuth2TokenSource, newOAuth2TokenSource)
+}
+
+// staticTokenSource returns the token of the key "jwt.token" in url, the secret placeholders like
+// ${file:/var/run/secrets/token} in it are resolved and refreshed periodically.
+type staticTokenSource struct{}
+
+func newStaticTokenSource() filter.TokenSource {
+	if staticSource == nil {
+		staticTokenSourceOnce.Do(func() {
+			staticSource = &staticTokenSource{}
+		})
+	}
+	return staticSource
+}
+
+// Token returns the token in url
+func (s *staticTokenSource) Token(_ base.Invocation, url *common.URL) (string, error) {
+	token := resolveSecret(url.GetParam(constant.JWTTokenKey, ""))
+	if token == "" {
+		return "", perrors.Errorf("%s is not configured", constant.JWTTokenKey)
+	}
+	return token, nil
+}
+
+// oauth2TokenSource gets the tokens from the token endpoint by the OAuth2 client credentials grant,
+// they are cached until they are about to expire.
+type oauth2TokenSource struct {
+	client *http.Client
+	lock   sync.Mutex
+	tokens map[string]*oauth2Token
+}
+
+type oauth2Token struct {
+	lock        sync.Mutex
+	accessToken string
+	expiry      time.Time
+}
+
+func newOAuth2TokenSource() filter.TokenSource {
+	if oauth2Source == nil {
+		oauth2TokenSourceOnce.Do(func() {
+			oauth2Source = &oauth2TokenSource{
+				client: &http.Client{Timeout: oauth2Timeout},
+				tokens: make(map[string]*oauth2Token),
+			}
+		})
+	}
+	return oauth2Source
+}
+
+// Token returns the cached token of the client, a new one is requested if it's about to expire
+func (s *oauth2TokenSource) Token(_ base.Invocation, url *common.URL) (string, error) {
+	tokenURL := url.GetParam(constant.OAuth2TokenURLKey, "")
+	clientID := url.GetParam(constant.OAuth2ClientIDKey, "")
+	if tokenURL == "" || clientID == "" {
+		return "", perrors.Errorf("%s and %s are required by the oauth2 token source",
+			constant.OAuth2TokenURLKey, constant.OAuth2ClientIDKey)
+	}
+	scopes := splitList(url.GetParam(constant.OAuth2ScopesKey, ""))
+
+	key := tokenURL + "#" + clientID + "#" + strings.Join(scopes, " ")
+	s.lock.Lock()
+	token, ok := s.tokens[key]
+	if !ok {
+		token = &oauth2Token{}
+		s.tokens[key] = token
+	}
+	s.lock.Unlock()
+
+	token.lock.Lock()
+	defer token.lock.Unlock()
+	if token.accessToken != "" && time.Now().Add(oauth2ExpiryDelta).Before(token.expiry) {
+		return token.accessToken, nil
+	}
+	accessToken, expiry, err := s.fetch(tokenURL, clientID, resolveSecret(url.GetParam(constant.OAuth2ClientSecretKey, "")), scopes)
+	if err != nil {
+		return "", err
+	}
+	token.accessToken, token.expiry = accessToken, expiry
+	return accessToken, nil
+}
+
+// fetch requests a token from the token endpoint, the client authenticates by HTTP basic auth
+func (s *oauth2TokenSource) fetch(tokenURL, clientID, clientSecret string, scopes []string) (string, time.Time, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+	req, err := http.NewRequest(http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, perrors.WithStack(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", time.Time{}, perrors.Wrapf(err, "failed to request the token from %s", tokenURL)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", time.Time{}, perrors.Wrapf(err, "failed to read the token from %s", tokenURL)
+	}
+
+	var tokenResp struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err = json.Unmarshal(body, &tokenResp); err != nil && resp.StatusCode == http.StatusOK {
+		return "", time.Time{}, perrors.Wrapf(err, "failed to unmarshal the token from %s", tokenURL)
+	}
+	if resp.StatusCode != http.StatusOK || tokenResp.AccessToken == "" {
+		return "", time.Time{}, perrors.Errorf("failed to request the token from %s, status: %s, error: %s %s",
+			tokenURL, resp.Status, tokenResp.Error, tokenResp.ErrorDescription)
+	}
+	if tokenResp.TokenType != "" && !strings.EqualFold(tokenResp.TokenType, "bearer") {
+		return "", time.Time{}, perrors.Errorf("the token type %q from %s is not bearer", tokenResp.TokenType, tokenURL)
+	}
+
+	// the lifetime of the token without expires_in is unknown, it's refreshed every hour
+	expiry := time.Now().Add(time.Hour)
+	if tokenResp.ExpiresIn > 0 {
+		expiry = time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second)
+	}
+	return tokenResp.AccessToken, expiry, nil
+}
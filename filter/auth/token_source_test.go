@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+)
+
+func TestOAuth2TokenSource(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "client" || secret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		assert.Equal(t, "client_credentials", r.PostFormValue("grant_type"))
+		assert.Equal(t, "user.read user.write", r.PostFormValue("scope"))
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, n)
+	}))
+	defer server.Close()
+
+	source, err := extension.GetTokenSource(constant.OAuth2TokenSource)
+	assert.NoError(t, err)
+	inv := invocation.NewRPCInvocation("GetUser", []any{"OK"}, nil)
+	url := newJWTURL(constant.OAuth2TokenURLKey, server.URL,
+		constant.OAuth2ClientIDKey, "client",
+		constant.OAuth2ClientSecretKey, "secret",
+		constant.OAuth2ScopesKey, "user.read,user.write")
+
+	token, err := source.Token(inv, url)
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+	// the token is cached
+	token, err = source.Token(inv, url)
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	url.SetParam(constant.OAuth2ClientSecretKey, "wrong")
+	url.SetParam(constant.OAuth2ClientIDKey, "other")
+	_, err = source.Token(inv, url)
+	assert.ErrorContains(t, err, "invalid_client")
+
+	_, err = source.Token(inv, newJWTURL())
+	assert.Error(t, err)
+}
@@ -17,6 +17,10 @@
 
 package filter
 
+import (
+	"context"
+)
+
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
@@ -32,3 +36,12 @@ type Authenticator interface {
 	// Authenticate verifies the signature of the request
 	Authenticate(base.Invocation, *common.URL) error
 }
+
+// ContextAuthenticator is implemented by the Authenticator which exposes what it verifies, e.g. the claims of
+// the bearer tokens, to the handlers. The returned context is passed to the invokers instead of ctx.
+type ContextAuthenticator interface {
+	Authenticator
+
+	// AuthenticateContext verifies the request and returns the context carrying the verified identity
+	AuthenticateContext(context.Context, base.Invocation, *common.URL) (context.Context, error)
+}
@@ -0,0 +1,218 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package executor provides a filter which bounds the concurrent invocations by the thread pools isolated per service.
+/*
+ example:
+ protocols:
+   tri:
+     name: tri
+     threadpool: fixed # the default thread pool of the services exported by the protocol
+     threads: 100
+ provider:
+   services:
+     "UserProvider":
+       interface: "com.ikurento.user.UserProvider"
+       threadpool: eager # fixed, cached or eager
+       threads: 50 # the max invocations running concurrently
+       queues: 100 # the max invocations waiting for a thread, the invocations are rejected if it's full
+       threadpool.rejected.handler: "default" # the name of rejected handler
+       methods:
+        - name: "GetUser"
+          threads: 20 # in this case, GetUser runs in its own thread pool of 20 threads
+ Each service runs in its own thread pool, so a slow service can't exhaust the threads of the others.
+ The methods with the thread pool configuration run in their own thread pools, and the missing
+ configuration is inherited from the service.
+ The invocations still run in the goroutines of the transports, the thread pools bound how many of them run
+ concurrently and how many wait rather than handing them off, so corethreads takes no effect.
+ When the thread pool is exhausted, the invocation is rejected by the RejectedExecutionHandler,
+ the custom handler can be registered by invoking SetRejectedExecutionHandler.
+ The thread pools are shut down once the services are unexported or the application is shut down.
+*/
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/common/threadpool"
+	"dubbo.apache.org/dubbo-go/v3/filter"
+	_ "dubbo.apache.org/dubbo-go/v3/filter/handler"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsRpc "dubbo.apache.org/dubbo-go/v3/metrics/rpc"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+)
+
+var (
+	once     sync.Once
+	executor *executorFilter
+)
+
+func init() {
+	extension.SetFilter(constant.ExecutorFilterKey, newFilter)
+}
+
+type executorFilter struct {
+	lock sync.Mutex
+	// pools are the thread pools of the services and the methods with their own configuration
+	pools map[string]*pool
+}
+
+type pool struct {
+	// spec is the configuration the pool is created with, the pool is recreated if it's changed
+	spec string
+	*threadpool.Pool
+}
+
+// newFilter returns the singleton Filter instance
+func newFilter() filter.Filter {
+	if executor == nil {
+		once.Do(func() {
+			executor = &executorFilter{
+				pools: make(map[string]*pool),
+			}
+			extension.AddCustomShutdownCallback(executor.shutdown)
+		})
+	}
+	return executor
+}
+
+// Invoke runs the invocation once the thread pool of the service or the method has an idle thread, it's invoked
+// directly if the thread pool isn't configured
+func (f *executorFilter) Invoke(ctx context.Context, invoker base.Invoker, invocation base.Invocation) result.Result {
+	ivkURL := invoker.GetURL()
+	p := f.getPool(ivkURL, invocation.MethodName())
+	if p == nil {
+		return invoker.Invoke(ctx, invocation)
+	}
+
+	queued := time.Now()
+	release, err := p.Acquire(ctx)
+	if err == threadpool.ErrClosed {
+		// the pool is replaced by the one of the new configuration
+		if p = f.getPool(ivkURL, invocation.MethodName()); p != nil {
+			release, err = p.Acquire(ctx)
+		}
+	}
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil && err == ctxErr {
+			// the caller has gone while the invocation is queued
+			return &result.RPCResult{Err: err}
+		}
+		return f.reject(ivkURL, invoker, invocation, err)
+	}
+	defer release()
+	metrics.Publish(metricsRpc.NewQueuedEvent(invoker, invocation, time.Since(queued)))
+	return invoker.Invoke(ctx, invocation)
+}
+
+// OnResponse dummy process, returns the result directly
+func (f *executorFilter) OnResponse(_ context.Context, result result.Result, _ base.Invoker, _ base.Invocation) result.Result {
+	return result
+}
+
+// OnInvokerDestroyed shuts down the thread pools of the service and its methods once it's unexported
+func (f *executorFilter) OnInvokerDestroyed(invoker base.Invoker) {
+	serviceKey := invoker.GetURL().ServiceKey()
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for target, p := range f.pools {
+		if target == serviceKey || strings.HasPrefix(target, serviceKey+"#") {
+			p.Shutdown()
+			delete(f.pools, target)
+		}
+	}
+}
+
+// shutdown shuts down all the thread pools when the application is shut down
+func (f *executorFilter) shutdown() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for target, p := range f.pools {
+		p.Shutdown()
+		delete(f.pools, target)
+	}
+}
+
+// getPool returns the thread pool of the method if it has the configuration, or the one of the service
+func (f *executorFilter) getPool(ivkURL *common.URL, methodName string) *pool {
+	name := ivkURL.GetParam(constant.ThreadPoolKey, "")
+	threads := ivkURL.GetParamByIntValue(constant.ThreadsKey, 0)
+	queues := ivkURL.GetParamByIntValue(constant.QueuesKey, 0)
+
+	target := ivkURL.ServiceKey()
+	methodConfigPrefix := "methods." + methodName + "."
+	for _, key := range []string{constant.ThreadPoolKey, constant.ThreadsKey, constant.QueuesKey} {
+		if len(ivkURL.GetParam(methodConfigPrefix+key, "")) > 0 {
+			// we have the method-level configuration
+			target = target + "#" + methodName
+			name = ivkURL.GetMethodParam(methodName, constant.ThreadPoolKey, name)
+			threads = ivkURL.GetMethodParamIntValue(methodName, constant.ThreadsKey, threads)
+			queues = ivkURL.GetMethodParamIntValue(methodName, constant.QueuesKey, queues)
+			break
+		}
+	}
+	if len(name) == 0 {
+		return nil
+	}
+	spec := fmt.Sprintf("%s/%d/%d", name, threads, queues)
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	p := f.pools[target]
+	if p != nil && p.spec == spec {
+		return p
+	}
+	tp, err := threadpool.New(name, threads, queues)
+	if err != nil {
+		logger.Errorf("The configuration of threadpool is invalid: %v, url: %s", err, ivkURL.String())
+		return nil
+	}
+	if p != nil {
+		logger.Infof("The thread pool of %s is changed from %s to %s", target, p.spec, spec)
+		p.Shutdown()
+	}
+	p = &pool{spec: spec, Pool: tp}
+	f.pools[target] = p
+	return p
+}
+
+func (f *executorFilter) reject(ivkURL *common.URL, invoker base.Invoker, invocation base.Invocation, err error) result.Result {
+	logger.Errorf("The invocation was rejected due to %v, url: %s ", err, ivkURL.String())
+	metrics.Publish(metricsRpc.NewRejectedEvent(invoker, invocation))
+	rejectedHandlerConfig := ivkURL.GetMethodParam(invocation.MethodName(), constant.ThreadPoolRejectedHandlerKey,
+		ivkURL.GetParam(constant.ThreadPoolRejectedHandlerKey, constant.DefaultKey))
+	rejectedExecutionHandler, handlerErr := extension.GetRejectedExecutionHandler(rejectedHandlerConfig)
+	if handlerErr != nil {
+		logger.Warn(handlerErr)
+		return &result.RPCResult{Err: err}
+	}
+	return rejectedExecutionHandler.RejectedExecution(ivkURL, invocation)
+}
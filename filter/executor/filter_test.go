@@ -0,0 +1,206 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package executor
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/common/threadpool"
+	"dubbo.apache.org/dubbo-go/v3/filter"
+	"dubbo.apache.org/dubbo-go/v3/filter/handler"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+)
+
+// blockingInvoker blocks the invocations until release is closed
+type blockingInvoker struct {
+	*base.BaseInvoker
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingInvoker(params ...string) *blockingInvoker {
+	invokeUrl := common.NewURLWithOptions(
+		common.WithParams(url.Values{}),
+		common.WithParamsValue(constant.InterfaceKey, params[0]),
+	)
+	for i := 1; i+1 < len(params); i += 2 {
+		invokeUrl.SetParam(params[i], params[i+1])
+	}
+	return &blockingInvoker{
+		BaseInvoker: base.NewBaseInvoker(invokeUrl),
+		started:     make(chan struct{}, 10),
+		release:     make(chan struct{}),
+	}
+}
+
+func (i *blockingInvoker) Invoke(_ context.Context, _ base.Invocation) result.Result {
+	i.started <- struct{}{}
+	<-i.release
+	return &result.RPCResult{Rest: "OK"}
+}
+
+func TestFilterInvokeIgnored(t *testing.T) {
+	invoc := invocation.NewRPCInvocation("hello", []any{"OK"}, make(map[string]any))
+	invoker := base.NewBaseInvoker(common.NewURLWithOptions(
+		common.WithParams(url.Values{}),
+		common.WithParamsValue(constant.InterfaceKey, "ignored")))
+
+	res := newFilter().Invoke(context.Background(), invoker, invoc)
+	assert.Nil(t, res.Error())
+	assert.Nil(t, newFilter().(*executorFilter).getPool(invoker.GetURL(), "hello"))
+}
+
+func TestFilterInvoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	rejectedExecutionHandler := handler.NewMockRejectedExecutionHandler(ctrl)
+	rejectedExecutionHandler.EXPECT().RejectedExecution(gomock.Any(), gomock.Any()).
+		Return(&result.RPCResult{Rest: "rejected"}).Times(1)
+	extension.SetRejectedExecutionHandler("executor-mock", func() filter.RejectedExecutionHandler {
+		return rejectedExecutionHandler
+	})
+
+	f := newFilter()
+	invoc := invocation.NewRPCInvocation("hello", []any{"OK"}, make(map[string]any))
+	busy := newBlockingInvoker("busy",
+		constant.ThreadPoolKey, threadpool.Fixed,
+		constant.ThreadsKey, "1",
+		constant.ThreadPoolRejectedHandlerKey, "executor-mock")
+	idle := newBlockingInvoker("idle", constant.ThreadPoolKey, threadpool.Fixed, constant.ThreadsKey, "1")
+
+	results := make(chan result.Result, 1)
+	go func() {
+		results <- f.Invoke(context.Background(), busy, invoc)
+	}()
+	<-busy.started
+
+	// the thread pool of busy is exhausted
+	res := f.Invoke(context.Background(), busy, invoc)
+	assert.Equal(t, "rejected", res.Result())
+
+	// the thread pool of idle is isolated from busy
+	close(idle.release)
+	res = f.Invoke(context.Background(), idle, invoc)
+	assert.Nil(t, res.Error())
+	assert.Equal(t, "OK", res.Result())
+
+	close(busy.release)
+	res = <-results
+	assert.Equal(t, "OK", res.Result())
+}
+
+func TestFilterMethodPool(t *testing.T) {
+	invoker := newBlockingInvoker("method",
+		constant.ThreadPoolKey, threadpool.Fixed,
+		constant.ThreadsKey, "1",
+		constant.QueuesKey, "5",
+		"methods.hello."+constant.ThreadsKey, "2")
+	f := newFilter().(*executorFilter)
+
+	servicePool := f.getPool(invoker.GetURL(), "world")
+	assert.Equal(t, "fixed/1/5", servicePool.spec)
+	methodPool := f.getPool(invoker.GetURL(), "hello")
+	assert.Equal(t, "fixed/2/5", methodPool.spec)
+	assert.Same(t, methodPool, f.getPool(invoker.GetURL(), "hello"))
+
+	// the pool is recreated if the configuration is changed
+	invoker.GetURL().SetParam(constant.ThreadPoolKey, "cached")
+	newPool := f.getPool(invoker.GetURL(), "world")
+	assert.Equal(t, "cached/1/5", newPool.spec)
+	assert.NotSame(t, servicePool, newPool)
+
+	// the invalid configuration is ignored
+	invoker.GetURL().SetParam(constant.ThreadPoolKey, "unknown")
+	assert.Nil(t, f.getPool(invoker.GetURL(), "world"))
+}
+
+func TestFilterInvokeQueued(t *testing.T) {
+	f := newFilter()
+	invoc := invocation.NewRPCInvocation("hello", []any{"OK"}, make(map[string]any))
+	invoker := newBlockingInvoker("queued",
+		constant.ThreadPoolKey, threadpool.Fixed,
+		constant.ThreadsKey, "1",
+		constant.QueuesKey, "2")
+
+	results := make(chan result.Result, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			results <- f.Invoke(context.Background(), invoker, invoc)
+		}()
+	}
+	<-invoker.started
+	// the second invocation waits until the first one is done
+	select {
+	case <-invoker.started:
+		t.Fatal("the invocations run beyond the threads")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// the caller gone while queued isn't invoked
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	p := f.(*executorFilter).getPool(invoker.GetURL(), "hello")
+	assert.Eventually(t, func() bool { return p.Queued() == 1 }, time.Second, time.Millisecond)
+	res := f.Invoke(ctx, invoker, invoc)
+	assert.ErrorIs(t, res.Error(), context.DeadlineExceeded)
+
+	close(invoker.release)
+	assert.Equal(t, "OK", (<-results).Result())
+	assert.Equal(t, "OK", (<-results).Result())
+}
+
+func TestFilterShutdownPools(t *testing.T) {
+	f := &executorFilter{pools: make(map[string]*pool)}
+	invoker := newBlockingInvoker("unexported",
+		constant.ThreadPoolKey, threadpool.Fixed,
+		"methods.hello."+constant.ThreadsKey, "2")
+	other := newBlockingInvoker("other", constant.ThreadPoolKey, threadpool.Fixed)
+	servicePool := f.getPool(invoker.GetURL(), "world")
+	methodPool := f.getPool(invoker.GetURL(), "hello")
+	otherPool := f.getPool(other.GetURL(), "hello")
+
+	// the pools of the service and its methods are shut down once it's unexported
+	var listener filter.InvokerDestroyListener = f
+	listener.OnInvokerDestroyed(invoker)
+	assert.Len(t, f.pools, 1)
+	for _, p := range []*pool{servicePool, methodPool} {
+		_, err := p.Acquire(context.Background())
+		assert.ErrorIs(t, err, threadpool.ErrClosed)
+	}
+
+	f.shutdown()
+	assert.Empty(t, f.pools)
+	_, err := otherPool.Acquire(context.Background())
+	assert.ErrorIs(t, err, threadpool.ErrClosed)
+}
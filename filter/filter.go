@@ -34,3 +34,9 @@ type Filter interface {
 	Invoke(context.Context, base.Invoker, base.Invocation) result.Result
 	OnResponse(context.Context, result.Result, base.Invoker, base.Invocation) result.Result
 }
+
+// InvokerDestroyListener is implemented by the filters keeping the states of the invokers, e.g. the thread pools
+// of the services, they are notified once the invokers are destroyed, which is the case the services are unexported
+type InvokerDestroyListener interface {
+	OnInvokerDestroyed(base.Invoker)
+}
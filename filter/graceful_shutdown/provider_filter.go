@@ -78,6 +78,7 @@ func (f *providerGracefulShutdownFilter) Invoke(ctx context.Context, invoker bas
 		}
 	}
 	f.shutdownConfig.ProviderActiveCount.Inc()
+	f.shutdownConfig.IncProtocolActiveCount(invoker.GetURL().Protocol)
 	f.shutdownConfig.ProviderLastReceivedRequestTime.Store(time.Now())
 	return invoker.Invoke(ctx, invocation)
 }
@@ -85,6 +86,7 @@ func (f *providerGracefulShutdownFilter) Invoke(ctx context.Context, invoker bas
 // OnResponse reduces the number of active processes then return the process result
 func (f *providerGracefulShutdownFilter) OnResponse(ctx context.Context, result result.Result, invoker base.Invoker, invocation base.Invocation) result.Result {
 	f.shutdownConfig.ProviderActiveCount.Dec()
+	f.shutdownConfig.DecProtocolActiveCount(invoker.GetURL().Protocol)
 	return result
 }
 
@@ -19,10 +19,13 @@ package trace
 
 import (
 	"context"
+	"net"
+	"strconv"
 )
 
 import (
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
@@ -35,8 +38,10 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/filter"
+	dubbotrace "dubbo.apache.org/dubbo-go/v3/otel/trace"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 )
 
 func init() {
@@ -74,25 +79,32 @@ func (f *otelServerFilter) Invoke(ctx context.Context, invoker base.Invoker, inv
 		trace.WithInstrumentationVersion(sdk.Version()),
 	)
 
+	attrs := rpcAttributes(invoker, invocation)
+	if host, port, ok := splitAddress(invocation.GetAttachmentWithDefaultValue(constant.RemoteAddr, "")); ok {
+		attrs = append(attrs, semconv.ClientAddress(host), semconv.ClientPort(port))
+	}
 	ctx, span := tracer.Start(
 		trace.ContextWithRemoteSpanContext(ctx, spanCtx),
 		invocation.ActualMethodName(),
 		trace.WithSpanKind(trace.SpanKindServer),
-		trace.WithAttributes(
-			semconv.RPCSystemApacheDubbo,
-			semconv.RPCService(invoker.GetURL().ServiceKey()),
-			semconv.RPCMethod(invocation.MethodName()),
-		),
+		trace.WithAttributes(attrs...),
 	)
 	defer span.End()
 
+	// the messages of the streams are recorded by the streams
+	stream := dubbotrace.StreamSpanFromContext(ctx)
+	if stream != nil {
+		stream.Bind(span)
+	} else {
+		dubbotrace.AddMessageEvent(span, false, 1, requestMessage(invocation))
+	}
+
 	result := invoker.Invoke(ctx, invocation)
 
-	if result.Error() != nil {
-		span.SetStatus(codes.Error, result.Error().Error())
-	} else {
-		span.SetStatus(codes.Ok, codes.Ok.String())
+	if stream == nil && result.Error() == nil {
+		dubbotrace.AddMessageEvent(span, true, 1, result.Result())
 	}
+	setStatus(span, invoker, result.Error())
 	return result
 }
 
@@ -111,18 +123,29 @@ func (f *otelClientFilter) Invoke(ctx context.Context, invoker base.Invoker, inv
 		trace.WithInstrumentationVersion(sdk.Version()),
 	)
 
+	attrs := rpcAttributes(invoker, invocation)
+	if url := invoker.GetURL(); url != nil && url.Ip != "" {
+		attrs = append(attrs, semconv.ServerAddress(url.Ip))
+		if port, err := strconv.Atoi(url.Port); err == nil {
+			attrs = append(attrs, semconv.ServerPort(port))
+		}
+	}
 	var span trace.Span
 	ctx, span = tracer.Start(
 		ctx,
 		invocation.ActualMethodName(),
 		trace.WithSpanKind(trace.SpanKindClient),
-		trace.WithAttributes(
-			semconv.RPCSystemApacheDubbo,
-			semconv.RPCService(invoker.GetURL().ServiceKey()),
-			semconv.RPCMethod(invocation.MethodName()),
-		),
+		trace.WithAttributes(attrs...),
 	)
-	defer span.End()
+
+	// the span of a stream is ended by the stream once it's closed, as the stream outlives the invocation
+	var stream *dubbotrace.StreamSpan
+	if callType, _ := invocation.GetAttribute(constant.CallTypeKey); callType != nil && callType != constant.CallUnary {
+		ctx, stream = dubbotrace.WithStreamSpan(ctx)
+		stream.Bind(span)
+	} else {
+		dubbotrace.AddMessageEvent(span, true, 1, requestMessage(invocation))
+	}
 
 	attachments := invocation.Attachments()
 	if attachments == nil {
@@ -134,10 +157,60 @@ func (f *otelClientFilter) Invoke(ctx context.Context, invoker base.Invoker, inv
 	}
 	result := invoker.Invoke(ctx, invocation)
 
-	if result.Error() != nil {
-		span.SetStatus(codes.Error, result.Error().Error())
+	if stream == nil && result.Error() == nil {
+		dubbotrace.AddMessageEvent(span, false, 1, result.Result())
+	}
+	setStatus(span, invoker, result.Error())
+	if stream == nil || result.Error() != nil || !stream.Claimed() {
+		span.End()
+	}
+	return result
+}
+
+// rpcAttributes returns the semantic-convention attributes of the invocation
+func rpcAttributes(invoker base.Invoker, invocation base.Invocation) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.RPCSystemApacheDubbo,
+		semconv.RPCService(invoker.GetURL().ServiceKey()),
+		semconv.RPCMethod(invocation.MethodName()),
+	}
+}
+
+// requestMessage returns the message of the request if the invocation has a single argument, e.g. the protobuf
+// message of triple, whose size is recorded
+func requestMessage(invocation base.Invocation) any {
+	if args := invocation.Arguments(); len(args) == 1 {
+		return args[0]
+	}
+	return nil
+}
+
+// setStatus sets the status of the span by err, the status code of triple is recorded as the one of grpc
+func setStatus(span trace.Span, invoker base.Invoker, err error) {
+	if url := invoker.GetURL(); url != nil && url.Protocol == constant.TriProtocol {
+		code := 0
+		if err != nil {
+			code = int(tri.CodeOf(err))
+		}
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(code))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 	} else {
 		span.SetStatus(codes.Ok, codes.Ok.String())
 	}
-	return result
+}
+
+// splitAddress splits the address like 127.0.0.1:20000
+func splitAddress(address string) (string, int, bool) {
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", 0, false
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, false
+	}
+	return host, port, true
 }
@@ -19,6 +19,7 @@ package trace
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -26,15 +27,27 @@ import (
 import (
 	"github.com/golang/mock/gomock"
 
+	"github.com/stretchr/testify/assert"
+
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	dubbotrace "dubbo.apache.org/dubbo-go/v3/otel/trace"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 )
 
 type fields struct {
@@ -559,6 +572,7 @@ func Test_otelServerFilter_Invoke(t *testing.T) {
 
 	res := NewMockResult(ctrl)
 	res.EXPECT().Error().Return(nil).AnyTimes()
+	res.EXPECT().Result().Return(nil).AnyTimes()
 
 	invoker := NewMockInvoker(ctrl)
 	invoker.EXPECT().GetURL().Return(&common.URL{}).AnyTimes()
@@ -569,6 +583,8 @@ func Test_otelServerFilter_Invoke(t *testing.T) {
 	invocation.EXPECT().MethodName().Return("otel").AnyTimes()
 	invocation.EXPECT().SetAttachment(gomock.Any(), gomock.Any()).Return().AnyTimes()
 	invocation.EXPECT().Attachments().Return(map[string]any{}).AnyTimes()
+	invocation.EXPECT().Arguments().Return(nil).AnyTimes()
+	invocation.EXPECT().GetAttachmentWithDefaultValue(constant.RemoteAddr, "").Return("127.0.0.1:20000").AnyTimes()
 
 	tests := []struct {
 		name   string
@@ -605,6 +621,7 @@ func Test_otelClientFilter_Invoke(t *testing.T) {
 
 	res := NewMockResult(ctrl)
 	res.EXPECT().Error().Return(nil).AnyTimes()
+	res.EXPECT().Result().Return(nil).AnyTimes()
 
 	invoker := NewMockInvoker(ctrl)
 	invoker.EXPECT().GetURL().Return(&common.URL{}).AnyTimes()
@@ -615,6 +632,8 @@ func Test_otelClientFilter_Invoke(t *testing.T) {
 	invocation.EXPECT().MethodName().Return("otel").AnyTimes()
 	invocation.EXPECT().SetAttachment(gomock.Any(), gomock.Any()).Return().AnyTimes()
 	invocation.EXPECT().Attachments().Return(map[string]any{}).AnyTimes()
+	invocation.EXPECT().Arguments().Return(nil).AnyTimes()
+	invocation.EXPECT().GetAttribute(constant.CallTypeKey).Return(constant.CallUnary, true).AnyTimes()
 
 	tests := []struct {
 		name   string
@@ -645,3 +664,85 @@ func Test_otelClientFilter_Invoke(t *testing.T) {
 		})
 	}
 }
+
+// streamInvoker returns the result of the invocation, and claims the span of the stream like the triple streams
+type streamInvoker struct {
+	base.BaseInvoker
+	res    result.Result
+	stream *dubbotrace.StreamSpan
+}
+
+func (i *streamInvoker) Invoke(ctx context.Context, _ base.Invocation) result.Result {
+	if i.stream = dubbotrace.StreamSpanFromContext(ctx); i.stream != nil {
+		i.stream.Claim()
+	}
+	return i.res
+}
+
+func Test_otelFilter_Spans(t *testing.T) {
+	var recorder *tracetest.SpanRecorder
+	var client *otelClientFilter
+	var server *otelServerFilter
+	reset := func() {
+		recorder = tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		client = &otelClientFilter{Propagators: propagation.TraceContext{}, TracerProvider: provider}
+		server = &otelServerFilter{Propagators: propagation.TraceContext{}, TracerProvider: provider}
+	}
+	url, _ := common.NewURL("tri://127.0.0.1:20000/org.apache.dubbo.Greeter")
+
+	t.Run("unary", func(t *testing.T) {
+		reset()
+		invoker := &streamInvoker{BaseInvoker: *base.NewBaseInvoker(url), res: &result.RPCResult{Rest: wrapperspb.String("hello")}}
+		inv := invocation.NewRPCInvocation("Greet", []any{wrapperspb.String("world")}, nil)
+		client.Invoke(context.Background(), invoker, inv)
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+		assert.Subset(t, spans[0].Attributes(), []any{
+			semconv.RPCSystemApacheDubbo,
+			semconv.ServerAddress("127.0.0.1"),
+			semconv.ServerPort(20000),
+			semconv.RPCGRPCStatusCodeKey.Int(0),
+		})
+		events := spans[0].Events()
+		assert.Len(t, events, 2)
+		assert.Contains(t, events[0].Attributes, semconv.MessageTypeSent)
+		assert.Contains(t, events[1].Attributes, semconv.MessageTypeReceived)
+		assert.Contains(t, events[1].Attributes, semconv.MessageUncompressedSizeKey.Int(7))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		reset()
+		err := tri.NewError(tri.CodeUnavailable, errors.New("no provider"))
+		invoker := &streamInvoker{BaseInvoker: *base.NewBaseInvoker(url), res: &result.RPCResult{Err: err}}
+		inv := invocation.NewRPCInvocation("Greet", nil, map[string]any{constant.RemoteAddr: "127.0.0.1:30000"})
+		server.Invoke(context.Background(), invoker, inv)
+
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Subset(t, spans[0].Attributes(), []any{
+			semconv.ClientAddress("127.0.0.1"),
+			semconv.ClientPort(30000),
+			semconv.RPCGRPCStatusCodeKey.Int(int(tri.CodeUnavailable)),
+		})
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		reset()
+		invoker := &streamInvoker{BaseInvoker: *base.NewBaseInvoker(url), res: &result.RPCResult{}}
+		inv := invocation.NewRPCInvocation("GreetStream", nil, nil)
+		inv.SetAttribute(constant.CallTypeKey, constant.CallBidiStream)
+		client.Invoke(context.Background(), invoker, inv)
+
+		// the span is ended by the stream once it's closed
+		assert.Empty(t, recorder.Ended())
+		invoker.stream.Sent(wrapperspb.String("hello"))
+		invoker.stream.End(nil)
+		spans := recorder.Ended()
+		assert.Len(t, spans, 1)
+		assert.Len(t, spans[0].Events(), 1)
+	})
+}
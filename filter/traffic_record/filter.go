@@ -0,0 +1,333 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package traffic_record provides a filter sampling invocations into a sink, the captured
+// traffic can be replayed against another provider by `dubbogo-cli replay`.
+package traffic_record
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/filter"
+	"dubbo.apache.org/dubbo-go/v3/filter/generic/generalizer"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+)
+
+const (
+	// RecordMaxBuffer is the max buffered records, records are dropped once the buffer is full
+	RecordMaxBuffer = 5000
+
+	// startTimeKey is the invocation attribute marking the sampled invocations
+	startTimeKey = "traffic-record-start"
+)
+
+// sensitiveAttachments are the attachments carrying the credentials, which are never recorded
+var sensitiveAttachments = []string{
+	constant.AuthorizationKey,
+	constant.RequestSignatureKey,
+	constant.AKKey,
+	constant.TokenKey,
+}
+
+var (
+	once                sync.Once
+	trafficRecordFilter *Filter
+)
+
+func init() {
+	extension.SetFilter(constant.TrafficRecordFilterKey, newFilter)
+	extension.SetTrafficRecordSink(constant.DefaultTrafficRecordSink, newFileSink)
+}
+
+// Filter samples invocations into the sink configured by the URL, for example:
+// "UserProvider":
+//
+//	filter: "traffic_record"
+//	params:
+//	  traffic.record.path: "/tmp/user-provider.jsonl"
+//	  traffic.record.sample.rate: "0.01" # record 1% invocations, default is 1
+//	  traffic.record.exclude.attachments: "x-api-key,cookie" # besides the credentials of auth and token filters
+//
+// Arguments and results are generalized by the map generalizer, so a record can be
+// replayed by a generic invocation. Filter is designed to be singleton.
+type Filter struct {
+	recordChan chan *recordTask
+	sinkLock   sync.Mutex // protects sinks
+	sinks      map[string]filter.TrafficRecordSink
+	ctx        context.Context
+	cancel     context.CancelFunc
+	done       chan struct{} // closed once processRecords returns
+}
+
+type recordTask struct {
+	url    *common.URL
+	record *filter.TrafficRecord
+}
+
+func newFilter() filter.Filter {
+	if trafficRecordFilter == nil {
+		once.Do(func() {
+			trafficRecordFilter = newTrafficRecordFilter()
+			extension.AddCustomShutdownCallback(trafficRecordFilter.shutdown)
+		})
+	}
+	return trafficRecordFilter
+}
+
+// newTrafficRecordFilter creates a Filter and starts writing its records
+func newTrafficRecordFilter() *Filter {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := &Filter{
+		recordChan: make(chan *recordTask, RecordMaxBuffer),
+		sinks:      make(map[string]filter.TrafficRecordSink),
+		ctx:        ctx,
+		cancel:     cancel,
+		done:       make(chan struct{}),
+	}
+	go f.processRecords()
+	return f
+}
+
+// Invoke marks the invocation if it is sampled
+func (f *Filter) Invoke(ctx context.Context, invoker base.Invoker, invocation base.Invocation) result.Result {
+	if sampled(invoker.GetURL()) {
+		invocation.SetAttribute(startTimeKey, time.Now())
+	}
+	return invoker.Invoke(ctx, invocation)
+}
+
+// OnResponse captures the sampled invocation, the record is written into the sink asynchronously
+func (f *Filter) OnResponse(_ context.Context, res result.Result, invoker base.Invoker, invocation base.Invocation) result.Result {
+	start, ok := invocation.GetAttribute(startTimeKey)
+	if !ok {
+		return res
+	}
+	record := buildRecord(invoker.GetURL(), invocation, res, start.(time.Time))
+	select {
+	case f.recordChan <- &recordTask{url: invoker.GetURL(), record: record}:
+	default:
+		logger.Warn("The channel is full and the traffic record will be dropped")
+	}
+	return res
+}
+
+// sampled checks whether the invocation should be recorded according to the sample rate
+func sampled(url *common.URL) bool {
+	rate := 1.0
+	if v := url.GetParam(constant.TrafficRecordSampleRateKey, ""); v != "" {
+		var err error
+		if rate, err = strconv.ParseFloat(v, 64); err != nil {
+			logger.Warnf("The traffic record sample rate %s is invalid, %v", v, err)
+			return false
+		}
+	}
+	return rate >= 1 || (rate > 0 && rand.Float64() < rate)
+}
+
+func buildRecord(url *common.URL, invocation base.Invocation, res result.Result, start time.Time) *filter.TrafficRecord {
+	record := &filter.TrafficRecord{
+		Timestamp: start.UnixMilli(),
+		Side:      url.GetParam(constant.SideKey, ""),
+		Protocol:  url.Protocol,
+		Interface: url.GetParam(constant.InterfaceKey, url.Service()),
+		Group:     url.GetParam(constant.GroupKey, ""),
+		Version:   url.GetParam(constant.VersionKey, ""),
+		Method:    invocation.MethodName(),
+		Elapsed:   time.Since(start).Milliseconds(),
+	}
+
+	g := generalizer.GetMapGeneralizer()
+	args := invocation.Arguments()
+	if invocation.IsGenericInvocation() && len(args) == 3 {
+		// record the real invocation carried by the generic invocation
+		record.Method, _ = args[0].(string)
+		record.ParameterTypes, _ = args[1].([]string)
+		args = toAnySlice(args[2])
+	} else {
+		record.ParameterTypes = make([]string, 0, len(args))
+		for _, arg := range args {
+			typ, _ := g.GetType(arg)
+			record.ParameterTypes = append(record.ParameterTypes, typ)
+		}
+	}
+	record.Arguments = make([]any, 0, len(args))
+	for _, arg := range args {
+		garg, _ := g.Generalize(arg)
+		record.Arguments = append(record.Arguments, normalize(garg))
+	}
+
+	excluded := excludedAttachments(url)
+	record.Attachments = make(map[string]string, len(invocation.Attachments()))
+	for k, v := range invocation.Attachments() {
+		if excluded[strings.ToLower(k)] {
+			continue
+		}
+		switch value := v.(type) {
+		case string:
+			record.Attachments[k] = value
+		case []string:
+			if len(value) > 0 {
+				record.Attachments[k] = value[0]
+			}
+		}
+	}
+
+	if res.Error() != nil {
+		record.Error = res.Error().Error()
+	} else if res.Result() != nil {
+		gres, _ := g.Generalize(res.Result())
+		record.Result = normalize(gres)
+	}
+	return record
+}
+
+// excludedAttachments returns the lower-case keys of the attachments which are not recorded
+func excludedAttachments(url *common.URL) map[string]bool {
+	excluded := make(map[string]bool, len(sensitiveAttachments))
+	for _, k := range sensitiveAttachments {
+		excluded[k] = true
+	}
+	for _, k := range strings.Split(url.GetParam(constant.TrafficRecordExcludeKey, ""), ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			excluded[strings.ToLower(k)] = true
+		}
+	}
+	return excluded
+}
+
+// normalize converts the generalized value into the form which can be marshaled into JSON
+func normalize(v any) any {
+	switch value := v.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(value))
+		for k, e := range value {
+			m[fmt.Sprint(k)] = normalize(e)
+		}
+		return m
+	case map[string]any:
+		for k, e := range value {
+			value[k] = normalize(e)
+		}
+		return value
+	case []any:
+		for i, e := range value {
+			value[i] = normalize(e)
+		}
+		return value
+	default:
+		return v
+	}
+}
+
+func toAnySlice(v any) []any {
+	if args, ok := v.([]any); ok {
+		return args
+	}
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Slice {
+		return nil
+	}
+	args := make([]any, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		args = append(args, value.Index(i).Interface())
+	}
+	return args
+}
+
+// processRecords runs in a background goroutine to write records into sinks
+func (f *Filter) processRecords() {
+	defer close(f.done)
+	for {
+		select {
+		case task := <-f.recordChan:
+			f.writeRecord(task)
+		case <-f.ctx.Done():
+			return
+		}
+	}
+}
+
+func (f *Filter) writeRecord(task *recordTask) {
+	sink, err := f.getOrCreateSink(task.url)
+	if err != nil {
+		logger.Warnf("Can not create the traffic record sink, %v", err)
+		return
+	}
+	if err = sink.Write(task.record); err != nil {
+		logger.Warnf("Can not write the traffic record of %s.%s, %v", task.record.Interface, task.record.Method, err)
+	}
+}
+
+// getOrCreateSink returns the sink shared by the URLs with the same sink name and path
+func (f *Filter) getOrCreateSink(url *common.URL) (filter.TrafficRecordSink, error) {
+	name := url.GetParam(constant.TrafficRecordSinkKey, constant.DefaultTrafficRecordSink)
+	key := name + "://" + url.GetParam(constant.TrafficRecordPathKey, constant.DefaultTrafficRecordPath)
+
+	f.sinkLock.Lock()
+	defer f.sinkLock.Unlock()
+	if sink, ok := f.sinks[key]; ok {
+		return sink, nil
+	}
+	sink, err := extension.GetTrafficRecordSink(name, url)
+	if err != nil {
+		return nil, err
+	}
+	f.sinks[key] = sink
+	return sink, nil
+}
+
+// shutdown stops recording and flushes the buffered records into sinks,
+// the sinks are closed after the background goroutine has written its last record
+func (f *Filter) shutdown() {
+	f.cancel()
+	<-f.done
+drain:
+	for {
+		select {
+		case task := <-f.recordChan:
+			f.writeRecord(task)
+		default:
+			break drain
+		}
+	}
+
+	f.sinkLock.Lock()
+	defer f.sinkLock.Unlock()
+	for key, sink := range f.sinks {
+		if err := sink.Close(); err != nil {
+			logger.Warnf("Error closing traffic record sink %s: %v", key, err)
+		}
+		delete(f.sinks, key)
+	}
+}
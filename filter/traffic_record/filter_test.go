@@ -0,0 +1,116 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package traffic_record
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+)
+
+type user struct {
+	ID   string
+	Name string
+	Tags map[string]int
+}
+
+func TestFilterRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture.jsonl")
+	url, err := common.NewURL("dubbo://127.0.0.1:20000/com.ikurento.user.UserProvider?" +
+		"interface=com.ikurento.user.UserProvider&side=provider&version=1.0.0&traffic.record.path=" + path)
+	require.NoError(t, err)
+	invoker := base.NewBaseInvoker(url)
+
+	f := newTrafficRecordFilter()
+	inv := invocation.NewRPCInvocation("GetUser", []any{"A001", int32(1)}, map[string]any{"traceId": "t1"})
+	res := f.Invoke(context.Background(), invoker, inv)
+	res.SetResult(&user{ID: "A001", Name: "Alex", Tags: map[string]int{"vip": 1}})
+	f.OnResponse(context.Background(), res, invoker, inv)
+
+	// not sampled
+	url.SetParam(constant.TrafficRecordSampleRateKey, "0")
+	skipped := invocation.NewRPCInvocation("GetUser", []any{"A002"}, nil)
+	f.OnResponse(context.Background(), f.Invoke(context.Background(), invoker, skipped), invoker, skipped)
+
+	f.shutdown()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	records, err := ReadRecords(file)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	record := records[0]
+	assert.Equal(t, "com.ikurento.user.UserProvider", record.Interface)
+	assert.Equal(t, "GetUser", record.Method)
+	assert.Equal(t, "provider", record.Side)
+	assert.Equal(t, "1.0.0", record.Version)
+	assert.Equal(t, []string{"java.lang.String", "int"}, record.ParameterTypes)
+	assert.Equal(t, []any{"A001", float64(1)}, record.Arguments)
+	assert.Equal(t, "t1", record.Attachments["traceId"])
+	assert.Equal(t, map[string]any{"iD": "A001", "name": "Alex", "tags": map[string]any{"vip": float64(1)}}, record.Result)
+}
+
+func TestBuildRecordGeneric(t *testing.T) {
+	url, err := common.NewURL("dubbo://127.0.0.1:20000/com.ikurento.user.UserProvider?side=consumer")
+	require.NoError(t, err)
+	inv := invocation.NewRPCInvocation(constant.Generic,
+		[]any{"GetUser", []string{"java.lang.String"}, []hessian.Object{"A001"}}, map[string]any{constant.GenericKey: "true"})
+	res := &result.RPCResult{Err: assert.AnError}
+
+	record := buildRecord(url, inv, res, time.Now())
+	assert.Equal(t, "GetUser", record.Method)
+	assert.Equal(t, []string{"java.lang.String"}, record.ParameterTypes)
+	assert.Equal(t, []any{"A001"}, record.Arguments)
+	assert.Equal(t, assert.AnError.Error(), record.Error)
+	assert.Nil(t, record.Result)
+}
+
+func TestBuildRecordExcludeAttachments(t *testing.T) {
+	url, err := common.NewURL("dubbo://127.0.0.1:20000/com.ikurento.user.UserProvider?traffic.record.exclude.attachments=X-Api-Key")
+	require.NoError(t, err)
+	inv := invocation.NewRPCInvocation("GetUser", nil, map[string]any{
+		"traceId":                    "t1",
+		constant.AuthorizationKey:    "Bearer xxx",
+		constant.RequestSignatureKey: "sig",
+		constant.AKKey:               "ak",
+		constant.TokenKey:            "token",
+		"x-api-key":                  []string{"key"},
+	})
+
+	record := buildRecord(url, inv, &result.RPCResult{}, time.Now())
+	assert.Equal(t, map[string]string{"traceId": "t1"}, record.Attachments)
+}
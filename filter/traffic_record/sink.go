@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package traffic_record
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+import (
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/filter"
+)
+
+const (
+	// RecordFileMode is the file permission for traffic record files.
+	RecordFileMode = 0o600
+
+	// maxRecordSize is the max size of a line read by ReadRecords
+	maxRecordSize = 16 * 1024 * 1024
+)
+
+// fileSink appends records into a local file, one JSON object per line
+type fileSink struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+func newFileSink(url *common.URL) (filter.TrafficRecordSink, error) {
+	path := url.GetParam(constant.TrafficRecordPathKey, constant.DefaultTrafficRecordPath)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, RecordFileMode)
+	if err != nil {
+		return nil, perrors.WithStack(err)
+	}
+	return &fileSink{file: file}, nil
+}
+
+// Write appends the record as a line
+func (s *fileSink) Write(record *filter.TrafficRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return perrors.WithStack(err)
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, err = s.file.Write(append(data, '\n')); err != nil {
+		return perrors.WithStack(err)
+	}
+	return nil
+}
+
+// Close closes the file
+func (s *fileSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.file.Close()
+}
+
+// ReadRecords reads the records written by the file sink
+func ReadRecords(r io.Reader) ([]*filter.TrafficRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+	var records []*filter.TrafficRecord
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := &filter.TrafficRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, perrors.WithStack(err)
+		}
+		records = append(records, record)
+	}
+	return records, perrors.WithStack(scanner.Err())
+}
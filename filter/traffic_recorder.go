@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package filter
+
+// TrafficRecord is an invocation captured by the traffic record filter. The default file sink writes
+// it as one JSON object per line, which can be replayed by `dubbogo-cli replay`.
+type TrafficRecord struct {
+	// Timestamp is the unix time in milliseconds when the invocation started
+	Timestamp int64  `json:"timestamp"`
+	Side      string `json:"side"`
+	Protocol  string `json:"protocol"`
+	Interface string `json:"interface"`
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Method    string `json:"method"`
+	// ParameterTypes are the java names of the arguments, which are required by generic invocations
+	ParameterTypes []string `json:"parameterTypes,omitempty"`
+	// Arguments and Result are generalized into maps, the same as generic invocations do
+	Arguments   []any             `json:"arguments,omitempty"`
+	Attachments map[string]string `json:"attachments,omitempty"`
+	Result      any               `json:"result,omitempty"`
+	Error       string            `json:"error,omitempty"`
+	// Elapsed is the cost of the invocation in milliseconds
+	Elapsed int64 `json:"elapsed"`
+}
+
+// TrafficRecordSink stores the records captured by the traffic record filter.
+//
+// please register your implementation by invoking extension.SetTrafficRecordSink
+// The usage, for example:
+// "UserProvider":
+//
+//	filter: "traffic_record"
+//	params:
+//	  traffic.record.sink: "the name of sink" # default is file
+//	  traffic.record.path: "/path/to/capture.jsonl"
+//	  traffic.record.sample.rate: "0.01"
+type TrafficRecordSink interface {
+	// Write stores the record, it may be called concurrently.
+	Write(record *TrafficRecord) error
+	// Close flushes and releases the sink.
+	Close() error
+}
@@ -39,6 +39,11 @@ type CenterConfig struct {
 
 	//FileExtension the suffix of config dataId, also the file extension of config content
 	FileExtension string `default:"yaml" yaml:"file-extension" json:"file-extension" `
+
+	// Fallbacks are the config centers in priority order, the configs not found in the ones before are got from them
+	Fallbacks []*CenterConfig `yaml:"fallbacks" json:"fallbacks,omitempty"`
+	// SnapshotDir persists the configs got last time, they are used when all the config centers are unavailable
+	SnapshotDir string `yaml:"snapshot-dir" json:"snapshot-dir,omitempty"`
 }
 
 func DefaultCenterConfig() *CenterConfig {
@@ -58,6 +63,11 @@ func (c *CenterConfig) Clone() *CenterConfig {
 		newParams[k] = v
 	}
 
+	newFallbacks := make([]*CenterConfig, 0, len(c.Fallbacks))
+	for _, fallback := range c.Fallbacks {
+		newFallbacks = append(newFallbacks, fallback.Clone())
+	}
+
 	return &CenterConfig{
 		Protocol:      c.Protocol,
 		Address:       c.Address,
@@ -71,5 +81,7 @@ func (c *CenterConfig) Clone() *CenterConfig {
 		Timeout:       c.Timeout,
 		Params:        newParams,
 		FileExtension: c.FileExtension,
+		Fallbacks:     newFallbacks,
+		SnapshotDir:   c.SnapshotDir,
 	}
 }
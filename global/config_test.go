@@ -319,3 +319,19 @@ func CheckCompleteInequality(t *testing.T, origin any, clone any) {
 		}
 	}
 }
+
+func TestShutdownConfigProtocolActiveCount(t *testing.T) {
+	c := DefaultShutdownConfig()
+	c.IncProtocolActiveCount("tri")
+	c.IncProtocolActiveCount("tri")
+	c.IncProtocolActiveCount("dubbo")
+	c.DecProtocolActiveCount("tri")
+	assert.EqualValues(t, 1, c.ProtocolActiveCount("tri"))
+	assert.EqualValues(t, 0, c.ProtocolActiveCount("rest"))
+	assert.Equal(t, map[string]int32{"tri": 1, "dubbo": 1}, c.ProtocolActiveCounts())
+
+	clone := c.Clone()
+	clone.IncProtocolActiveCount("tri")
+	assert.EqualValues(t, 2, clone.ProtocolActiveCount("tri"))
+	assert.EqualValues(t, 1, c.ProtocolActiveCount("tri"))
+}
@@ -34,6 +34,20 @@ type ProtocolConfig struct {
 	// TODO: maybe Params is useless, find a ideal way to config dubbo protocol, ref: TripleConfig.
 	Params any `yaml:"params" json:"params,omitempty" property:"params"`
 
+	// ThreadPool, Threads, CoreThreads, Queues and ThreadPoolRejectedHandler configure the thread pools of
+	// the services exported by the protocol, they are overridden by the ones of the services. CoreThreads is
+	// kept for the configurations of dubbo java, it takes no effect since the thread pools don't own threads.
+	ThreadPool                string `yaml:"threadpool" json:"threadpool,omitempty" property:"threadpool"`
+	Threads                   string `yaml:"threads" json:"threads,omitempty" property:"threads"`
+	CoreThreads               string `yaml:"corethreads" json:"corethreads,omitempty" property:"corethreads"`
+	Queues                    string `yaml:"queues" json:"queues,omitempty" property:"queues"`
+	ThreadPoolRejectedHandler string `yaml:"threadpool.rejected.handler" json:"threadpool.rejected.handler,omitempty" property:"threadpool.rejected.handler"`
+
+	// UnixSocket is the unix domain socket served besides the tcp address, e.g. unix:///var/run/dubbo/tri.sock,
+	// it's registered with the provider urls and only dialed by the consumers on the same host.
+	// The triple protocol and the dubbo protocol serve it, except the dubbo protocol with TLS.
+	UnixSocket string `yaml:"unix-socket" json:"unix-socket,omitempty" property:"unix-socket"`
+
 	TripleConfig *TripleConfig `yaml:"triple" json:"triple,omitempty" property:"triple"`
 
 	// TODO: remove MaxServerSendMsgSize and MaxServerRecvMsgSize when version 4.0.0
@@ -65,12 +79,18 @@ func (c *ProtocolConfig) Clone() *ProtocolConfig {
 	}
 
 	return &ProtocolConfig{
-		Name:                 c.Name,
-		Ip:                   c.Ip,
-		Port:                 c.Port,
-		Params:               c.Params,
-		TripleConfig:         c.TripleConfig.Clone(),
-		MaxServerSendMsgSize: c.MaxServerSendMsgSize,
-		MaxServerRecvMsgSize: c.MaxServerRecvMsgSize,
+		Name:                      c.Name,
+		Ip:                        c.Ip,
+		Port:                      c.Port,
+		Params:                    c.Params,
+		ThreadPool:                c.ThreadPool,
+		Threads:                   c.Threads,
+		CoreThreads:               c.CoreThreads,
+		Queues:                    c.Queues,
+		ThreadPoolRejectedHandler: c.ThreadPoolRejectedHandler,
+		UnixSocket:                c.UnixSocket,
+		TripleConfig:              c.TripleConfig.Clone(),
+		MaxServerSendMsgSize:      c.MaxServerSendMsgSize,
+		MaxServerRecvMsgSize:      c.MaxServerRecvMsgSize,
 	}
 }
@@ -41,6 +41,11 @@ type ServiceConfig struct {
 	TpsLimitRejectedHandler     string            `yaml:"tps.limit.rejected.handler" json:"tps.limit.rejected.handler,omitempty" property:"tps.limit.rejected.handler"`
 	ExecuteLimit                string            `yaml:"execute.limit" json:"execute.limit,omitempty" property:"execute.limit"`
 	ExecuteLimitRejectedHandler string            `yaml:"execute.limit.rejected.handler" json:"execute.limit.rejected.handler,omitempty" property:"execute.limit.rejected.handler"`
+	ThreadPool                  string            `yaml:"threadpool" json:"threadpool,omitempty" property:"threadpool"`
+	Threads                     string            `yaml:"threads" json:"threads,omitempty" property:"threads"`
+	CoreThreads                 string            `yaml:"corethreads" json:"corethreads,omitempty" property:"corethreads"`
+	Queues                      string            `yaml:"queues" json:"queues,omitempty" property:"queues"`
+	ThreadPoolRejectedHandler   string            `yaml:"threadpool.rejected.handler" json:"threadpool.rejected.handler,omitempty" property:"threadpool.rejected.handler"`
 	Auth                        string            `yaml:"auth" json:"auth,omitempty" property:"auth"`
 	NotRegister                 bool              `yaml:"not_register" json:"not_register,omitempty" property:"not_register"`
 	ParamSign                   string            `yaml:"param.sign" json:"param.sign,omitempty" property:"param.sign"`
@@ -119,6 +124,11 @@ func (c *ServiceConfig) Clone() *ServiceConfig {
 		TpsLimitRejectedHandler:     c.TpsLimitRejectedHandler,
 		ExecuteLimit:                c.ExecuteLimit,
 		ExecuteLimitRejectedHandler: c.ExecuteLimitRejectedHandler,
+		ThreadPool:                  c.ThreadPool,
+		Threads:                     c.Threads,
+		CoreThreads:                 c.CoreThreads,
+		Queues:                      c.Queues,
+		ThreadPoolRejectedHandler:   c.ThreadPoolRejectedHandler,
 		Auth:                        c.Auth,
 		RCProtocolsMap:              newRCProtocolsMap,
 		RCRegistriesMap:             newRCRegistriesMap,
@@ -17,6 +17,10 @@
 
 package global
 
+import (
+	"sync"
+)
+
 import (
 	"github.com/creasty/defaults"
 
@@ -52,6 +56,10 @@ type ShutdownConfig struct {
 	InternalSignal *bool `default:"true" yaml:"internal-signal" json:"internal.signal,omitempty" property:"internal.signal"`
 	// offline request window length
 	OfflineRequestWindowTimeout string `yaml:"offline-request-window-timeout" json:"offlineRequestWindowTimeout,omitempty" property:"offlineRequestWindowTimeout"`
+	// port of the http readiness endpoint, the endpoint is disabled if it is empty
+	ReadinessPort string `yaml:"readiness-port" json:"readiness-port,omitempty" property:"readiness-port"`
+	// path of the http readiness endpoint
+	ReadinessPath string `default:"/ready" yaml:"readiness-path" json:"readiness-path,omitempty" property:"readiness-path"`
 	// true -> new request will be rejected.
 	RejectRequest atomic.Bool
 	// active invocation
@@ -60,6 +68,9 @@ type ShutdownConfig struct {
 
 	// provider last received request timestamp
 	ProviderLastReceivedRequestTime atomic.Time
+
+	// active provider invocation grouped by protocol, protocol name -> *atomic.Int32
+	protocolActiveCount sync.Map
 }
 
 func DefaultShutdownConfig() *ShutdownConfig {
@@ -88,12 +99,50 @@ func (c *ShutdownConfig) Clone() *ShutdownConfig {
 		RejectRequestHandler:        c.RejectRequestHandler,
 		InternalSignal:              newInternalSignal,
 		OfflineRequestWindowTimeout: c.OfflineRequestWindowTimeout,
+		ReadinessPort:               c.ReadinessPort,
+		ReadinessPath:               c.ReadinessPath,
 	}
 
 	newShutdownConfig.RejectRequest.Store(c.RejectRequest.Load())
 	newShutdownConfig.ConsumerActiveCount.Store(c.ConsumerActiveCount.Load())
 	newShutdownConfig.ProviderActiveCount.Store(c.ProviderActiveCount.Load())
 	newShutdownConfig.ProviderLastReceivedRequestTime.Store(c.ProviderLastReceivedRequestTime.Load())
+	for protocol, count := range c.ProtocolActiveCounts() {
+		newShutdownConfig.protocolCounter(protocol).Store(count)
+	}
 
 	return newShutdownConfig
 }
+
+// IncProtocolActiveCount increases the active provider invocation count of the protocol
+func (c *ShutdownConfig) IncProtocolActiveCount(protocol string) {
+	c.protocolCounter(protocol).Inc()
+}
+
+// DecProtocolActiveCount decreases the active provider invocation count of the protocol
+func (c *ShutdownConfig) DecProtocolActiveCount(protocol string) {
+	c.protocolCounter(protocol).Dec()
+}
+
+// ProtocolActiveCount returns the active provider invocation count of the protocol
+func (c *ShutdownConfig) ProtocolActiveCount(protocol string) int32 {
+	if counter, ok := c.protocolActiveCount.Load(protocol); ok {
+		return counter.(*atomic.Int32).Load()
+	}
+	return 0
+}
+
+// ProtocolActiveCounts returns a snapshot of the active provider invocation count of every protocol
+func (c *ShutdownConfig) ProtocolActiveCounts() map[string]int32 {
+	counts := make(map[string]int32)
+	c.protocolActiveCount.Range(func(key, value any) bool {
+		counts[key.(string)] = value.(*atomic.Int32).Load()
+		return true
+	})
+	return counts
+}
+
+func (c *ShutdownConfig) protocolCounter(protocol string) *atomic.Int32 {
+	counter, _ := c.protocolActiveCount.LoadOrStore(protocol, atomic.NewInt32(0))
+	return counter.(*atomic.Int32)
+}
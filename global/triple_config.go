@@ -40,6 +40,9 @@ type TripleConfig struct {
 
 	KeepAliveInterval string `yaml:"keep-alive-interval" json:"keep-alive-interval,omitempty" property:"keep-alive-interval"`
 	KeepAliveTimeout  string `yaml:"keep-alive-timeout" json:"keep-alive-timeout,omitempty" property:"keep-alive-timeout"`
+
+	// the config of the connection pools
+	ConnPool *TripleConnPoolConfig `yaml:"conn-pool" json:"conn-pool,omitempty" property:"conn-pool"`
 }
 
 // DefaultTripleConfig returns a default TripleConfig instance.
@@ -62,5 +65,6 @@ func (t *TripleConfig) Clone() *TripleConfig {
 
 		KeepAliveInterval: t.KeepAliveInterval,
 		KeepAliveTimeout:  t.KeepAliveTimeout,
+		ConnPool:          t.ConnPool.Clone(),
 	}
 }
@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package global
+
+// TripleConnPoolConfig represents the config of the connection pools of the triple clients over HTTP/2.
+// A pool opens multiple connections to an address, so the requests aren't bottlenecked on a single connection.
+type TripleConnPoolConfig struct {
+	// MaxConnections is the max number of the connections to an address.
+	// The default value is 1.
+	MaxConnections int `yaml:"max-connections" json:"max-connections,omitempty" property:"max-connections"`
+
+	// MaxConcurrentStreams is the max number of the concurrent streams of a connection, a new connection is opened
+	// once all the connections reach it until there are MaxConnections ones.
+	// The default value is 0, which means the max concurrent streams advertised by the server.
+	MaxConcurrentStreams int `yaml:"max-concurrent-streams" json:"max-concurrent-streams,omitempty" property:"max-concurrent-streams"`
+
+	// IdleTimeout is how long an idle connection is kept before it's closed, e.g. 5m.
+	// The default value is empty, which means the idle connections are never closed.
+	IdleTimeout string `yaml:"idle-timeout" json:"idle-timeout,omitempty" property:"idle-timeout"`
+
+	// Warmup is the number of the connections opened when the invoker is created, it's at most MaxConnections.
+	// The default value is 0.
+	Warmup int `yaml:"warmup" json:"warmup,omitempty" property:"warmup"`
+}
+
+// Clone a new TripleConnPoolConfig
+func (c *TripleConnPoolConfig) Clone() *TripleConnPoolConfig {
+	if c == nil {
+		return nil
+	}
+
+	return &TripleConnPoolConfig{
+		MaxConnections:       c.MaxConnections,
+		MaxConcurrentStreams: c.MaxConcurrentStreams,
+		IdleTimeout:          c.IdleTimeout,
+		Warmup:               c.Warmup,
+	}
+}
@@ -84,6 +84,19 @@ func WithOfflineRequestWindowTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithReadinessPort exposes the readiness endpoint on the port, the endpoint reflects the shutdown phase.
+func WithReadinessPort(port string) Option {
+	return func(opts *Options) {
+		opts.Shutdown.ReadinessPort = port
+	}
+}
+
+func WithReadinessPath(path string) Option {
+	return func(opts *Options) {
+		opts.Shutdown.ReadinessPath = path
+	}
+}
+
 func WithRejectRequest() Option {
 	return func(opts *Options) {
 		opts.Shutdown.RejectRequest.Store(true)
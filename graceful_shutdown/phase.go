@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graceful_shutdown
+
+import (
+	"sync"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	"go.uber.org/atomic"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/internal"
+)
+
+// Phase is the stage that graceful shutdown is in. Phases are entered in ascending order.
+type Phase int32
+
+const (
+	// PhaseRunning means the application is serving normally
+	PhaseRunning Phase = iota
+	// PhasePreStop runs the pre-stop hooks, e.g. waiting for the load balancer to remove the instance
+	PhasePreStop
+	// PhaseReadinessDrop marks the application as not ready and sets the health status of every service to NOT_SERVING
+	PhaseReadinessDrop
+	// PhaseUnregister unregisters all services from registries and waits for consumers to update their invokers
+	PhaseUnregister
+	// PhaseDrain rejects new requests and waits for in-flight requests to finish
+	PhaseDrain
+	// PhaseCloseListeners destroys all protocols, which closes their listeners and connections
+	PhaseCloseListeners
+	// PhaseTerminated means all shutdown steps have been executed
+	PhaseTerminated
+)
+
+var phaseNames = map[Phase]string{
+	PhaseRunning:        constant.ShutdownPhaseRunning,
+	PhasePreStop:        constant.ShutdownPhasePreStop,
+	PhaseReadinessDrop:  constant.ShutdownPhaseReadinessDrop,
+	PhaseUnregister:     constant.ShutdownPhaseUnregister,
+	PhaseDrain:          constant.ShutdownPhaseDrain,
+	PhaseCloseListeners: constant.ShutdownPhaseCloseListeners,
+	PhaseTerminated:     constant.ShutdownPhaseTerminated,
+}
+
+func (p Phase) String() string {
+	if name, ok := phaseNames[p]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+func init() {
+	// the legacy config.Load path enters the phases by their names
+	internal.GracefulShutdownEnterPhase = func(name string) {
+		for phase, phaseName := range phaseNames {
+			if phaseName == name {
+				enterPhase(phase)
+				return
+			}
+		}
+		logger.Warnf("Graceful shutdown --- Unknown phase %s.", name)
+	}
+}
+
+var (
+	currentPhase atomic.Int32
+
+	hookMu     sync.RWMutex
+	phaseHooks = make(map[Phase][]func())
+)
+
+// CurrentPhase returns the phase graceful shutdown is currently in.
+func CurrentPhase() Phase {
+	return Phase(currentPhase.Load())
+}
+
+// AddPhaseHook registers a hook which is invoked when graceful shutdown enters the phase.
+// Hooks of the same phase are invoked in the order of registration, before the built-in
+// actions of that phase. Use PhasePreStop for pre-stop hooks.
+func AddPhaseHook(phase Phase, hook func()) {
+	hookMu.Lock()
+	defer hookMu.Unlock()
+	phaseHooks[phase] = append(phaseHooks[phase], hook)
+}
+
+// enterPhase switches to the phase and invokes the hooks registered on it.
+func enterPhase(phase Phase) {
+	currentPhase.Store(int32(phase))
+	logger.Infof("Graceful shutdown --- Enter phase %s.", phase)
+
+	hookMu.RLock()
+	hooks := make([]func(), len(phaseHooks[phase]))
+	copy(hooks, phaseHooks[phase])
+	hookMu.RUnlock()
+
+	for _, hook := range hooks {
+		runPhaseHook(phase, hook)
+	}
+}
+
+func runPhaseHook(phase Phase, hook func()) {
+	defer func() {
+		if err := recover(); err != nil {
+			logger.Errorf("Graceful shutdown --- Hook of phase %s panics: %v", phase, err)
+		}
+	}()
+	hook()
+}
@@ -0,0 +1,111 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graceful_shutdown
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/internal"
+)
+
+func TestEnterPhase(t *testing.T) {
+	defer currentPhase.Store(int32(PhaseRunning))
+
+	var entered []Phase
+	AddPhaseHook(PhasePreStop, func() {
+		entered = append(entered, CurrentPhase())
+	})
+	AddPhaseHook(PhasePreStop, func() {
+		panic("broken hook")
+	})
+	AddPhaseHook(PhaseDrain, func() {
+		entered = append(entered, CurrentPhase())
+	})
+
+	enterPhase(PhasePreStop)
+	enterPhase(PhaseReadinessDrop)
+	enterPhase(PhaseDrain)
+	assert.Equal(t, []Phase{PhasePreStop, PhaseDrain}, entered)
+	assert.Equal(t, PhaseDrain, CurrentPhase())
+	assert.Equal(t, "drain", CurrentPhase().String())
+}
+
+func TestReadinessHandler(t *testing.T) {
+	defer currentPhase.Store(int32(PhaseRunning))
+
+	handler := ReadinessHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, defaultReadinessPath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "running", rec.Body.String())
+
+	currentPhase.Store(int32(PhaseReadinessDrop))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, defaultReadinessPath, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "readiness-drop", rec.Body.String())
+}
+
+func TestEnterPhaseByName(t *testing.T) {
+	defer currentPhase.Store(int32(PhaseRunning))
+
+	// the legacy config.Load path enters the phases by their names
+	internal.GracefulShutdownEnterPhase(constant.ShutdownPhaseUnregister)
+	assert.Equal(t, PhaseUnregister, CurrentPhase())
+	internal.GracefulShutdownEnterPhase("unknown")
+	assert.Equal(t, PhaseUnregister, CurrentPhase())
+}
+
+func TestReadinessServerClosedOnTerminated(t *testing.T) {
+	defer currentPhase.Store(int32(PhaseRunning))
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, l.Close())
+
+	internal.GracefulShutdownServeReadiness(port, "/readyz")
+	url := "http://127.0.0.1:" + port + "/readyz"
+	assert.Eventually(t, func() bool {
+		rsp, err := http.Get(url)
+		if err != nil {
+			return false
+		}
+		_ = rsp.Body.Close()
+		return rsp.StatusCode == http.StatusOK
+	}, 3*time.Second, 10*time.Millisecond)
+
+	enterPhase(PhaseTerminated)
+	assert.Nil(t, readinessServer)
+	_, err = http.Get(url)
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package graceful_shutdown
+
+import (
+	"net/http"
+	"sync"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/global"
+	"dubbo.apache.org/dubbo-go/v3/internal"
+)
+
+const defaultReadinessPath = "/ready"
+
+// ReadinessHandler returns a http.Handler reporting whether the application is ready to accept traffic.
+// It responds 200 while the application is running and 503 once graceful shutdown has started,
+// the body is the name of the current phase.
+func ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		phase := CurrentPhase()
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if phase == PhaseRunning {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_, _ = w.Write([]byte(phase.String()))
+	})
+}
+
+func init() {
+	internal.GracefulShutdownServeReadiness = serveReadiness
+}
+
+var (
+	readinessMu     sync.Mutex
+	readinessServer *http.Server
+)
+
+// startReadinessServer exposes ReadinessHandler if a readiness port is configured.
+func startReadinessServer(shutdown *global.ShutdownConfig) {
+	serveReadiness(shutdown.ReadinessPort, shutdown.ReadinessPath)
+}
+
+// serveReadiness exposes ReadinessHandler on the port and path, the server is closed once graceful
+// shutdown enters PhaseTerminated.
+func serveReadiness(port, path string) {
+	if port == "" {
+		return
+	}
+	if path == "" {
+		path = defaultReadinessPath
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, ReadinessHandler())
+	srv := &http.Server{Addr: ":" + port, Handler: mux}
+
+	readinessMu.Lock()
+	if readinessServer != nil {
+		readinessMu.Unlock()
+		logger.Warnf("Graceful shutdown --- Readiness endpoint listens on %s already.", readinessServer.Addr)
+		return
+	}
+	readinessServer = srv
+	readinessMu.Unlock()
+	AddPhaseHook(PhaseTerminated, stopReadinessServer)
+
+	go func() {
+		logger.Infof("Graceful shutdown --- Readiness endpoint listens on %s%s", srv.Addr, path)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Errorf("Graceful shutdown --- Readiness endpoint stops unexpectedly: %v", err)
+		}
+	}()
+}
+
+// stopReadinessServer closes the readiness endpoint started by serveReadiness.
+func stopReadinessServer() {
+	readinessMu.Lock()
+	srv := readinessServer
+	readinessServer = nil
+	readinessMu.Unlock()
+	if srv == nil {
+		return
+	}
+	if err := srv.Close(); err != nil {
+		logger.Warnf("Graceful shutdown --- Error closing the readiness endpoint: %v", err)
+	}
+}
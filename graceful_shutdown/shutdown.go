@@ -34,6 +34,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/config"
 	"dubbo.apache.org/dubbo-go/v3/global"
+	"dubbo.apache.org/dubbo-go/v3/internal"
 )
 
 const (
@@ -81,6 +82,8 @@ func Init(opts ...Option) {
 			filter.Set(constant.GracefulShutdownFilterShutdownConfig, newOpts.Shutdown)
 		}
 
+		startReadinessServer(newOpts.Shutdown)
+
 		if newOpts.Shutdown.InternalSignal != nil && *newOpts.Shutdown.InternalSignal {
 			signals := make(chan os.Signal, 1)
 			signal.Notify(signals, ShutdownSignals...)
@@ -125,15 +128,25 @@ func totalTimeout(shutdown *global.ShutdownConfig) time.Duration {
 }
 
 func beforeShutdown(shutdown *global.ShutdownConfig) {
+	// run the pre-stop hooks registered by users
+	enterPhase(PhasePreStop)
+
+	// make readiness probes and health checks fail so that the traffic is moved away
+	enterPhase(PhaseReadinessDrop)
+	internal.HealthShutdown()
+
+	enterPhase(PhaseUnregister)
 	destroyRegistries()
 	// waiting for a short time so that the clients have enough time to get the notification that server shutdowns
 	// The value of configuration depends on how long the clients will get notification.
 	waitAndAcceptNewRequests(shutdown)
 
 	// reject sending/receiving the new request but keeping waiting for accepting requests
+	enterPhase(PhaseDrain)
 	waitForSendingAndReceivingRequests(shutdown)
 
 	// destroy all protocols
+	enterPhase(PhaseCloseListeners)
 	destroyProtocols()
 
 	logger.Info("Graceful shutdown --- Execute the custom callbacks.")
@@ -141,6 +154,7 @@ func beforeShutdown(shutdown *global.ShutdownConfig) {
 	for callback := customCallbacks.Front(); callback != nil; callback = callback.Next() {
 		callback.Value.(func())()
 	}
+	enterPhase(PhaseTerminated)
 }
 
 // destroyRegistries destroys RegistryProtocol directly.
@@ -174,8 +188,8 @@ func waitingProviderProcessedTimeout(shutdown *global.ShutdownConfig, timeout ti
 		(shutdown.ProviderActiveCount.Load() > 0 || time.Now().Before(shutdown.ProviderLastReceivedRequestTime.Load().Add(offlineRequestWindowTimeout))) {
 		// sleep 10 ms and then we check it again
 		time.Sleep(10 * time.Millisecond)
-		logger.Infof("waiting for provider active invocation count = %d, provider last received request time: %v",
-			shutdown.ProviderActiveCount.Load(), shutdown.ProviderLastReceivedRequestTime.Load())
+		logger.Infof("waiting for provider active invocation count = %d, per protocol = %v, provider last received request time: %v",
+			shutdown.ProviderActiveCount.Load(), shutdown.ProtocolActiveCounts(), shutdown.ProviderLastReceivedRequestTime.Load())
 	}
 }
 
@@ -194,10 +208,12 @@ func waitingConsumerProcessedTimeout(shutdown *global.ShutdownConfig) {
 	}
 	deadline := time.Now().Add(stepTimeout)
 
-	for time.Now().Before(deadline) && shutdown.ConsumerActiveCount.Load() > 0 {
+	// new requests have been rejected, so the in-flight requests of both sides are drained here
+	for time.Now().Before(deadline) && (shutdown.ConsumerActiveCount.Load() > 0 || shutdown.ProviderActiveCount.Load() > 0) {
 		// sleep 10 ms and then we check it again
 		time.Sleep(10 * time.Millisecond)
-		logger.Infof("waiting for consumer active invocation count = %d", shutdown.ConsumerActiveCount.Load())
+		logger.Infof("waiting for consumer active invocation count = %d, provider active invocation count per protocol = %v",
+			shutdown.ConsumerActiveCount.Load(), shutdown.ProtocolActiveCounts())
 	}
 }
 
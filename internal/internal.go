@@ -28,6 +28,15 @@ var (
 	// HealthSetServingStatusServing is used to set service serving status
 	// the initialization place is in /protocol/triple/health/healthServer.go
 	HealthSetServingStatusServing = func(service string) {}
+	// HealthShutdown is used to set all services serving status to NOT_SERVING during graceful shutdown
+	// the initialization place is in /protocol/triple/health/healthServer.go
+	HealthShutdown = func() {}
+	// GracefulShutdownEnterPhase is used to enter the graceful shutdown phase with the name by the config.Load path
+	// the initialization place is in /graceful_shutdown/phase.go
+	GracefulShutdownEnterPhase = func(name string) {}
+	// GracefulShutdownServeReadiness is used to expose the readiness endpoint by the config.Load path
+	// the initialization place is in /graceful_shutdown/readiness.go
+	GracefulShutdownServeReadiness = func(port, path string) {}
 	// ReflectionRegister is used to register reflection service provider
 	// the initialization place is in /protocol/triple/reflection/serverreflection.go
 	ReflectionRegister = func(reflection reflection.ServiceInfoProvider) {}
@@ -29,6 +29,7 @@ import (
 
 	"github.com/knadh/koanf"
 	"github.com/knadh/koanf/parsers/json"
+	"github.com/knadh/koanf/parsers/toml"
 	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/knadh/koanf/providers/confmap"
 	"github.com/knadh/koanf/providers/rawbytes"
@@ -39,6 +40,9 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/constant/file"
+	"dubbo.apache.org/dubbo-go/v3/config"
+	"dubbo.apache.org/dubbo-go/v3/config/parsers/properties"
+	"dubbo.apache.org/dubbo-go/v3/config/secret"
 )
 
 var (
@@ -62,18 +66,65 @@ func Load(opts ...LoaderConfOption) error {
 	if err := instanceOptions.init(); err != nil {
 		return err
 	}
+	secret.Watch(conf.secrets, dynamicUpdateSecrets(instanceOptions))
 
 	instance := &Instance{insOpts: instanceOptions}
 	return instance.start()
 }
 
+// dynamicUpdateSecrets returns the watcher updating the options by the rotated secrets, values are the flattened
+// keys like dubbo.registries.nacos.password and the new secrets. The clients of the registries with the rotated
+// credentials are re-created, the other secrets take effect after restarted.
+func dynamicUpdateSecrets(opts *InstanceOptions) secret.Watcher {
+	return func(values map[string]string) {
+		flattened := make(map[string]any, len(values))
+		for k, v := range values {
+			flattened[k] = v
+		}
+		koan := koanf.New(".")
+		if err := koan.Load(confmap.Provider(flattened, "."), nil); err != nil {
+			logger.Errorf("[Secret] failed to load the rotated secrets: %v", err)
+			return
+		}
+		updated := &InstanceOptions{}
+		if err := koan.UnmarshalWithConf(opts.Prefix(), updated, koanf.UnmarshalConf{Tag: "yaml"}); err != nil {
+			logger.Errorf("[Secret] failed to unmarshal the rotated secrets: %v", err)
+			return
+		}
+		for id, updateRegistry := range updated.Registries {
+			reg, ok := opts.Registries[id]
+			if !ok || updateRegistry == nil {
+				continue
+			}
+			username, password := reg.Username, reg.Password
+			if updateRegistry.Username != "" {
+				reg.Username = updateRegistry.Username
+			}
+			if updateRegistry.Password != "" {
+				reg.Password = updateRegistry.Password
+			}
+			if reg.Username != username || reg.Password != password {
+				logger.Infof("[Secret] the credentials of the registry %s are rotated", id)
+				config.UpdateRegistryCredentials(id, reg.Username, reg.Password)
+			}
+		}
+		registriesPrefix := opts.Prefix() + ".registries."
+		for k := range values {
+			if !strings.HasPrefix(k, registriesPrefix) {
+				logger.Warnf("[Secret] the rotated secret of %s takes effect after restarted", k)
+			}
+		}
+	}
+}
+
 type loaderConf struct {
-	suffix string           // loaderConf file extension default yaml
-	path   string           // loaderConf file path default ./conf/dubbogo.yaml
-	delim  string           // loaderConf file delim default .
-	bytes  []byte           // config bytes
-	opts   *InstanceOptions // user provide InstanceOptions built by WithXXX api
-	name   string           // config file name
+	suffix  string            // loaderConf file extension default yaml
+	path    string            // loaderConf file path default ./conf/dubbogo.yaml
+	delim   string            // loaderConf file delim default .
+	bytes   []byte            // config bytes
+	opts    *InstanceOptions  // user provide InstanceOptions built by WithXXX api
+	name    string            // config file name
+	secrets map[string]string // raw values of the keys with secret placeholders
 }
 
 func NewLoaderConf(opts ...LoaderConfOption) *loaderConf {
@@ -199,7 +250,7 @@ func userHomeDir() string {
 
 // checkFileSuffix check file suffix
 func checkFileSuffix(suffix string) error {
-	for _, g := range []string{"json", "yaml", "yml"} {
+	for _, g := range []string{"json", "toml", "yaml", "yml", "properties"} {
 		if g == suffix {
 			return nil
 		}
@@ -238,6 +289,16 @@ func (conf *loaderConf) MergeConfig(koan *koanf.Koanf) *koanf.Koanf {
 		if err := koan.Merge(activeKoan); err != nil {
 			logger.Debugf("Config merge err %s", err)
 		}
+		// the secrets overridden by the active profile are replaced
+		for _, k := range activeKoan.Keys() {
+			delete(conf.secrets, k)
+		}
+		if len(activeConf.secrets) > 0 && conf.secrets == nil {
+			conf.secrets = make(map[string]string, len(activeConf.secrets))
+		}
+		for k, v := range activeConf.secrets {
+			conf.secrets[k] = v
+		}
 	}
 	return koan
 }
@@ -286,6 +347,10 @@ func GetConfigResolver(conf *loaderConf) *koanf.Koanf {
 		err = k.Load(rawbytes.Provider(bytes), yaml.Parser())
 	case "json":
 		err = k.Load(rawbytes.Provider(bytes), json.Parser())
+	case "toml":
+		err = k.Load(rawbytes.Provider(bytes), toml.Parser())
+	case "properties":
+		err = k.Load(rawbytes.Provider(bytes), properties.Parser())
 	default:
 		err = errors.Errorf("no support %s file suffix", conf.suffix)
 	}
@@ -293,9 +358,22 @@ func GetConfigResolver(conf *loaderConf) *koanf.Koanf {
 	if err != nil {
 		panic(err)
 	}
+	if conf.secrets, err = resolveSecrets(k); err != nil {
+		panic(err)
+	}
 	return resolvePlaceholder(k)
 }
 
+// resolveSecrets replaces the secret placeholders like ${secret:vault:path#key} with the secrets,
+// and returns the raw values of the keys with secrets
+func resolveSecrets(resolver *koanf.Koanf) (map[string]string, error) {
+	resolved, raw, err := secret.ResolveAll(resolver.All())
+	if err != nil || len(resolved) == 0 {
+		return raw, err
+	}
+	return raw, resolver.Load(confmap.Provider(resolved, resolver.Delim()), nil)
+}
+
 // resolvePlaceholder replace ${xx} with real value
 func resolvePlaceholder(resolver *koanf.Koanf) *koanf.Koanf {
 	m := make(map[string]any)
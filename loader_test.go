@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dubbo
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/global"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+)
+
+// credentialsProtocol records the credentials of the registries updated
+type credentialsProtocol struct {
+	base.Protocol
+	updated map[string][2]string
+}
+
+func (p *credentialsProtocol) UpdateRegistryCredentials(registryId, username, password string) {
+	p.updated[registryId] = [2]string{username, password}
+}
+
+func TestDynamicUpdateSecrets(t *testing.T) {
+	regProtocol := &credentialsProtocol{updated: make(map[string][2]string)}
+	extension.SetProtocol(constant.RegistryProtocol, func() base.Protocol {
+		return regProtocol
+	})
+	defer extension.UnregisterProtocol(constant.RegistryProtocol)
+
+	opts := defaultInstanceOptions()
+	opts.Registries = map[string]*global.RegistryConfig{
+		"nacos": {Address: "127.0.0.1:8848", Username: "nacos", Password: "nacos"},
+		"zk":    {Address: "127.0.0.1:2181"},
+	}
+	dynamicUpdateSecrets(opts)(map[string]string{
+		"dubbo.registries.nacos.password":  "rotated",
+		"dubbo.registries.absent.password": "unknown",
+	})
+
+	// the options of the instance are updated and the clients of the registry are re-created
+	assert.Equal(t, "rotated", opts.Registries["nacos"].Password)
+	assert.Equal(t, "nacos", opts.Registries["nacos"].Username)
+	assert.Equal(t, map[string][2]string{"nacos": {"nacos", "rotated"}}, regProtocol.updated)
+}
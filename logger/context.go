@@ -0,0 +1,167 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+import (
+	dubbogoLogger "github.com/dubbogo/gost/log/logger"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The keys of the fields attached to the logs of the context-aware API
+const (
+	FieldTraceID    = "trace_id"
+	FieldSpanID     = "span_id"
+	FieldInterface  = "interface"
+	FieldMethod     = "method"
+	FieldRemoteAddr = "remote"
+)
+
+// The levels of LogContext
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+// CtxLogger is implemented by the loggers which log the fields as structured attributes, e.g. the slog logger.
+// The fields of the others are appended to the messages.
+type CtxLogger interface {
+	Logger
+	// LogContext logs the message of the level with the fields, which are key-value pairs
+	LogContext(ctx context.Context, level string, msg string, fields ...any)
+}
+
+type fieldsKey struct{}
+
+// WithFields returns the context carrying the fields in addition to the ones of ctx, they are key-value pairs
+// attached to the logs of the context-aware API. The providers attach the interface, the method and the remote
+// address of the invocations to the contexts passed to the filters and the handlers.
+func WithFields(ctx context.Context, fields ...any) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	parent, _ := ctx.Value(fieldsKey{}).([]any)
+	merged := make([]any, 0, len(parent)+len(fields))
+	merged = append(append(merged, parent...), fields...)
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// Fields returns the fields of ctx, the trace id and the span id of the span in ctx come first
+func Fields(ctx context.Context) []any {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).([]any)
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return fields
+	}
+	all := make([]any, 0, len(fields)+4)
+	all = append(all, FieldTraceID, spanCtx.TraceID().String(), FieldSpanID, spanCtx.SpanID().String())
+	return append(all, fields...)
+}
+
+// CtxDebug logs the message at debug level with the fields of ctx
+func CtxDebug(ctx context.Context, args ...any) {
+	logContext(ctx, LevelDebug, fmt.Sprint(args...))
+}
+
+// CtxDebugf logs the formatted message at debug level with the fields of ctx
+func CtxDebugf(ctx context.Context, template string, args ...any) {
+	logContext(ctx, LevelDebug, fmt.Sprintf(template, args...))
+}
+
+// CtxInfo logs the message at info level with the fields of ctx
+func CtxInfo(ctx context.Context, args ...any) {
+	logContext(ctx, LevelInfo, fmt.Sprint(args...))
+}
+
+// CtxInfof logs the formatted message at info level with the fields of ctx
+func CtxInfof(ctx context.Context, template string, args ...any) {
+	logContext(ctx, LevelInfo, fmt.Sprintf(template, args...))
+}
+
+// CtxWarn logs the message at warn level with the fields of ctx
+func CtxWarn(ctx context.Context, args ...any) {
+	logContext(ctx, LevelWarn, fmt.Sprint(args...))
+}
+
+// CtxWarnf logs the formatted message at warn level with the fields of ctx
+func CtxWarnf(ctx context.Context, template string, args ...any) {
+	logContext(ctx, LevelWarn, fmt.Sprintf(template, args...))
+}
+
+// CtxError logs the message at error level with the fields of ctx
+func CtxError(ctx context.Context, args ...any) {
+	logContext(ctx, LevelError, fmt.Sprint(args...))
+}
+
+// CtxErrorf logs the formatted message at error level with the fields of ctx
+func CtxErrorf(ctx context.Context, template string, args ...any) {
+	logContext(ctx, LevelError, fmt.Sprintf(template, args...))
+}
+
+func logContext(ctx context.Context, level string, msg string) {
+	log := currentLogger()
+	if log == nil {
+		return
+	}
+	fields := Fields(ctx)
+	if ctxLogger, ok := log.(CtxLogger); ok {
+		ctxLogger.LogContext(ctx, level, msg, fields...)
+		return
+	}
+
+	if len(fields) > 0 {
+		var builder strings.Builder
+		builder.WriteString(msg)
+		for i := 0; i+1 < len(fields); i += 2 {
+			_, _ = fmt.Fprintf(&builder, " %v=%v", fields[i], fields[i+1])
+		}
+		msg = builder.String()
+	}
+	switch level {
+	case LevelDebug:
+		log.Debug(msg)
+	case LevelWarn:
+		log.Warn(msg)
+	case LevelError:
+		log.Error(msg)
+	default:
+		log.Info(msg)
+	}
+}
+
+// currentLogger returns the logger set by SetLogger, or the one of dubbogo/gost which the framework logs with
+func currentLogger() Logger {
+	if logger != nil {
+		return logger
+	}
+	if log, ok := dubbogoLogger.GetLogger().(Logger); ok {
+		return log
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+import (
+	"go.opentelemetry.io/otel/trace"
+)
+
+type recordLogger struct {
+	mockLogger
+	messages []string
+}
+
+func (r *recordLogger) Warn(args ...any) {
+	r.messages = append(r.messages, fmt.Sprint(args...))
+}
+
+func TestWithFields(t *testing.T) {
+	ctx := WithFields(context.Background(), FieldInterface, "org.apache.dubbo.Greeter")
+	if WithFields(ctx) != ctx {
+		t.Fatalf("expected WithFields without fields to return the context")
+	}
+	child := WithFields(ctx, FieldMethod, "Greet")
+	fields := Fields(child)
+	if len(fields) != 4 || fields[1] != "org.apache.dubbo.Greeter" || fields[3] != "Greet" {
+		t.Fatalf("expected the fields of the parent and the child, got %v", fields)
+	}
+	if len(Fields(ctx)) != 2 {
+		t.Fatalf("expected the fields of the parent to be unchanged, got %v", Fields(ctx))
+	}
+}
+
+func TestFieldsWithSpan(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+	fields := Fields(WithFields(ctx, FieldMethod, "Greet"))
+	expected := []any{FieldTraceID, traceID.String(), FieldSpanID, spanID.String(), FieldMethod, "Greet"}
+	if fmt.Sprint(fields) != fmt.Sprint(expected) {
+		t.Fatalf("expected %v, got %v", expected, fields)
+	}
+}
+
+func TestCtxLogAppendsFields(t *testing.T) {
+	r := &recordLogger{}
+	SetLogger(r)
+	defer SetLogger(nil)
+
+	CtxWarnf(WithFields(context.Background(), FieldMethod, "Greet", FieldRemoteAddr, "127.0.0.1:20000"), "slow %dms", 100)
+	if len(r.messages) != 1 || r.messages[0] != "slow 100ms method=Greet remote=127.0.0.1:20000" {
+		t.Fatalf("expected the fields to be appended to the message, got %v", r.messages)
+	}
+}
@@ -0,0 +1,318 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package slog provides the logger driver of log/slog. The level of the driver is either a level like info,
+// or a comma separated list of the level and the per-package overrides, for example:
+//
+//	info,dubbo.apache.org/dubbo-go/v3/registry=debug,github.com/apache/dubbo-getty=warn
+//
+// An override applies to the package and its sub packages, and the longest one matching the package of the caller
+// wins. The level and the overrides are replaced at runtime by logger.SetLoggerLevel with the same syntax.
+package slog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+import (
+	"github.com/mattn/go-colorable"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/logger"
+	"dubbo.apache.org/dubbo-go/v3/logger/core"
+)
+
+// LevelFatal is the level of Fatal and Fatalf, the process exits after the message is logged
+const LevelFatal = slog.Level(12)
+
+// loggingPackages are the packages whose frames are skipped to find the callers
+var loggingPackages = []string{
+	"github.com/dubbogo/gost/log/logger.",
+	"dubbo.apache.org/dubbo-go/v3/logger.",
+	"dubbo.apache.org/dubbo-go/v3/logger/core/slog.",
+}
+
+func init() {
+	extension.SetLogger("slog", instantiate)
+}
+
+func instantiate(config *common.URL) (logger.Logger, error) {
+	var writers []io.Writer
+	for _, apt := range strings.Split(config.GetParam(constant.LoggerAppenderKey, constant.LoggerAppender), ",") {
+		switch apt {
+		case "console":
+			writers = append(writers, os.Stdout)
+		case "file":
+			file := core.FileConfig(config)
+			writers = append(writers, colorable.NewNonColorable(file))
+		}
+	}
+
+	opts := &slog.HandlerOptions{
+		// the levels are checked by Logger
+		Level:       slog.LevelDebug,
+		ReplaceAttr: replaceLevel,
+	}
+	var handler slog.Handler
+	switch strings.ToLower(config.GetParam(constant.LoggerFormatKey, constant.LoggerFormat)) {
+	case "json":
+		handler = slog.NewJSONHandler(io.MultiWriter(writers...), opts)
+	default:
+		handler = slog.NewTextHandler(io.MultiWriter(writers...), opts)
+	}
+	return New(handler, config.GetParam(constant.LoggerLevelKey, constant.LoggerLevel))
+}
+
+// Logger logs by the slog.Handler with the levels of the packages of the callers
+type Logger struct {
+	handler slog.Handler
+	levels  atomic.Pointer[levels]
+}
+
+// levels are the level and the per-package overrides
+type levels struct {
+	root      slog.Level
+	overrides map[string]slog.Level
+	// min is the min one of root and overrides, the logs below it are dropped without finding the callers
+	min slog.Level
+	// cache is the levels of the packages found so far
+	cache sync.Map
+}
+
+// New returns the Logger of the handler, level is in the syntax of the package document
+func New(handler slog.Handler, level string) (*Logger, error) {
+	lv, err := parseLevels(level)
+	if err != nil {
+		return nil, err
+	}
+	l := &Logger{handler: handler}
+	l.levels.Store(lv)
+	return l, nil
+}
+
+// SetLoggerLevel replaces the level and the per-package overrides, it returns false if level is invalid
+func (l *Logger) SetLoggerLevel(level string) bool {
+	lv, err := parseLevels(level)
+	if err != nil {
+		return false
+	}
+	l.levels.Store(lv)
+	return true
+}
+
+// Enabled returns true if the logs of the level are logged by the caller in the package
+func (l *Logger) Enabled(pkg string, level slog.Level) bool {
+	return level >= l.levels.Load().levelOf(pkg)
+}
+
+func (l *Logger) Debug(args ...any) {
+	l.log(context.Background(), slog.LevelDebug, fmt.Sprint(args...))
+}
+
+func (l *Logger) Debugf(template string, args ...any) {
+	l.log(context.Background(), slog.LevelDebug, fmt.Sprintf(template, args...))
+}
+
+func (l *Logger) Info(args ...any) {
+	l.log(context.Background(), slog.LevelInfo, fmt.Sprint(args...))
+}
+
+func (l *Logger) Infof(template string, args ...any) {
+	l.log(context.Background(), slog.LevelInfo, fmt.Sprintf(template, args...))
+}
+
+func (l *Logger) Warn(args ...any) {
+	l.log(context.Background(), slog.LevelWarn, fmt.Sprint(args...))
+}
+
+func (l *Logger) Warnf(template string, args ...any) {
+	l.log(context.Background(), slog.LevelWarn, fmt.Sprintf(template, args...))
+}
+
+func (l *Logger) Error(args ...any) {
+	l.log(context.Background(), slog.LevelError, fmt.Sprint(args...))
+}
+
+func (l *Logger) Errorf(template string, args ...any) {
+	l.log(context.Background(), slog.LevelError, fmt.Sprintf(template, args...))
+}
+
+func (l *Logger) Fatal(args ...any) {
+	l.log(context.Background(), LevelFatal, fmt.Sprint(args...))
+	os.Exit(1)
+}
+
+func (l *Logger) Fatalf(template string, args ...any) {
+	l.log(context.Background(), LevelFatal, fmt.Sprintf(template, args...))
+	os.Exit(1)
+}
+
+// LogContext logs the message with the fields as the attributes
+func (l *Logger) LogContext(ctx context.Context, level string, msg string, fields ...any) {
+	var lv slog.Level
+	if err := lv.UnmarshalText([]byte(level)); err != nil {
+		lv = slog.LevelInfo
+	}
+	l.log(ctx, lv, msg, fields...)
+}
+
+func (l *Logger) log(ctx context.Context, level slog.Level, msg string, fields ...any) {
+	lv := l.levels.Load()
+	if level < lv.min || !l.handler.Enabled(ctx, level) {
+		return
+	}
+	frame := callerFrame()
+	if level < lv.levelOf(packageOf(frame.Function)) {
+		return
+	}
+
+	record := slog.NewRecord(time.Now(), level, msg, 0)
+	if frame.File != "" {
+		record.AddAttrs(slog.String("line", shortFile(frame.File)+":"+strconv.Itoa(frame.Line)))
+	}
+	record.Add(fields...)
+	_ = l.handler.Handle(ctx, record)
+}
+
+// callerFrame returns the frame calling the logger, the frames of the logging packages are skipped
+func callerFrame() runtime.Frame {
+	var pcs [16]uintptr
+	// skip runtime.Callers, callerFrame and log
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !isLoggingFrame(frame) || !more {
+			return frame
+		}
+	}
+}
+
+func isLoggingFrame(frame runtime.Frame) bool {
+	if strings.HasSuffix(frame.File, "_test.go") {
+		return false
+	}
+	for _, pkg := range loggingPackages {
+		if strings.HasPrefix(frame.Function, pkg) {
+			return true
+		}
+	}
+	return false
+}
+
+// packageOf returns the package of the function name like dubbo.apache.org/dubbo-go/v3/registry.(*Registry).Do
+func packageOf(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return function
+}
+
+// shortFile returns the file with its directory like registry/registry.go
+func shortFile(file string) string {
+	if i := strings.LastIndex(file, "/"); i >= 0 {
+		if j := strings.LastIndex(file[:i], "/"); j >= 0 {
+			return file[j+1:]
+		}
+	}
+	return file
+}
+
+// replaceLevel names LevelFatal FATAL
+func replaceLevel(groups []string, attr slog.Attr) slog.Attr {
+	if attr.Key == slog.LevelKey && len(groups) == 0 {
+		if level, ok := attr.Value.Any().(slog.Level); ok && level >= LevelFatal {
+			return slog.String(slog.LevelKey, "FATAL")
+		}
+	}
+	return attr
+}
+
+// parseLevels parses the level like info,dubbo.apache.org/dubbo-go/v3/registry=debug
+func parseLevels(spec string) (*levels, error) {
+	lv := &levels{root: slog.LevelInfo, overrides: make(map[string]slog.Level)}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		pkg, name, isOverride := strings.Cut(item, "=")
+		if !isOverride {
+			name = pkg
+		}
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(strings.TrimSpace(parseLevelName(name)))); err != nil {
+			return nil, fmt.Errorf("invalid logger level %q: %w", item, err)
+		}
+		if isOverride {
+			lv.overrides[strings.TrimSpace(pkg)] = level
+		} else {
+			lv.root = level
+		}
+	}
+	lv.min = lv.root
+	for _, level := range lv.overrides {
+		if level < lv.min {
+			lv.min = level
+		}
+	}
+	return lv, nil
+}
+
+// parseLevelName maps the level names of zap and logrus to the ones of slog
+func parseLevelName(name string) string {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "warning":
+		return "warn"
+	case "fatal", "panic", "dpanic":
+		return "error+4"
+	}
+	return name
+}
+
+// levelOf returns the level of the package, which is the one of the longest override matching it or the root level
+func (lv *levels) levelOf(pkg string) slog.Level {
+	if len(lv.overrides) == 0 {
+		return lv.root
+	}
+	if cached, ok := lv.cache.Load(pkg); ok {
+		return cached.(slog.Level)
+	}
+	level, matched := lv.root, -1
+	for prefix, override := range lv.overrides {
+		if len(prefix) > matched && (pkg == prefix || strings.HasPrefix(pkg, prefix+"/")) {
+			level, matched = override, len(prefix)
+		}
+	}
+	lv.cache.Store(pkg, level)
+	return level
+}
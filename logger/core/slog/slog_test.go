@@ -0,0 +1,175 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package slog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/logger"
+)
+
+const testPackage = "dubbo.apache.org/dubbo-go/v3/logger/core/slog"
+
+func newTestLogger(t *testing.T, level string) (*Logger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	lg, err := New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: replaceLevel}), level)
+	if err != nil {
+		t.Fatalf("expected slog logger, err=%v", err)
+	}
+	return lg, buf
+}
+
+func TestInstantiateSlog(t *testing.T) {
+	u := &common.URL{}
+	u.ReplaceParams(url.Values{
+		constant.LoggerLevelKey:    []string{"info"},
+		constant.LoggerAppenderKey: []string{"console"},
+		constant.LoggerFormatKey:   []string{"json"},
+	})
+	lg, err := instantiate(u)
+	if err != nil || lg == nil {
+		t.Fatalf("expected slog logger, err=%v", err)
+	}
+	if _, ok := lg.(logger.OpsLogger); !ok {
+		t.Fatalf("expected slog logger to be an OpsLogger")
+	}
+	if _, ok := lg.(logger.CtxLogger); !ok {
+		t.Fatalf("expected slog logger to be a CtxLogger")
+	}
+
+	u.SetParam(constant.LoggerLevelKey, "verbose")
+	if _, err = instantiate(u); err == nil {
+		t.Fatalf("expected error for invalid level")
+	}
+}
+
+func TestLoggerLevel(t *testing.T) {
+	lg, buf := newTestLogger(t, "info")
+	lg.Debug("dropped")
+	if buf.Len() != 0 {
+		t.Fatalf("expected debug log to be dropped, got %s", buf.String())
+	}
+	lg.Infof("hello %s", "world")
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected json log, err=%v", err)
+	}
+	if record["msg"] != "hello world" || record["level"] != "INFO" {
+		t.Fatalf("unexpected log %v", record)
+	}
+	if line, _ := record["line"].(string); !strings.HasPrefix(line, "slog/slog_test.go:") {
+		t.Fatalf("expected the caller to be the test, got %v", record["line"])
+	}
+
+	if !lg.SetLoggerLevel("debug") {
+		t.Fatalf("expected SetLoggerLevel to accept debug")
+	}
+	buf.Reset()
+	lg.Debug("kept")
+	if !strings.Contains(buf.String(), "kept") {
+		t.Fatalf("expected debug log after SetLoggerLevel, got %s", buf.String())
+	}
+	if lg.SetLoggerLevel("info,registry=verbose") {
+		t.Fatalf("expected SetLoggerLevel to reject an invalid override")
+	}
+}
+
+func TestLoggerPackageOverrides(t *testing.T) {
+	lg, buf := newTestLogger(t, "warn,"+testPackage+"=debug")
+	lg.Debug("kept")
+	if !strings.Contains(buf.String(), "kept") {
+		t.Fatalf("expected the override of the package to apply, got %s", buf.String())
+	}
+
+	// the override of the parent package applies to the sub packages
+	lg.SetLoggerLevel("debug,dubbo.apache.org/dubbo-go/v3/logger/core=error")
+	buf.Reset()
+	lg.Warn("dropped")
+	if buf.Len() != 0 {
+		t.Fatalf("expected the override of the parent package to apply, got %s", buf.String())
+	}
+
+	// the longest override wins, and the overrides match the whole path segments
+	lg.SetLoggerLevel("error,dubbo.apache.org/dubbo-go/v3/logger=debug,dubbo.apache.org/dubbo-go/v3/logger/co=error")
+	lg.Info("kept")
+	if !strings.Contains(buf.String(), "kept") {
+		t.Fatalf("expected the longest override to apply, got %s", buf.String())
+	}
+
+	if lg.Enabled("dubbo.apache.org/dubbo-go/v3/registry", slog.LevelWarn) {
+		t.Fatalf("expected the root level to apply to the other packages")
+	}
+	if !lg.Enabled("dubbo.apache.org/dubbo-go/v3/logger/core/zap", slog.LevelDebug) {
+		t.Fatalf("expected the override to apply to the sub packages")
+	}
+}
+
+func TestLogContext(t *testing.T) {
+	lg, buf := newTestLogger(t, "info")
+	logger.SetLogger(lg)
+	defer logger.SetLogger(nil)
+
+	ctx := logger.WithFields(context.Background(), logger.FieldInterface, "org.apache.dubbo.Greeter", logger.FieldMethod, "Greet")
+	logger.CtxWarnf(ctx, "slow %dms", 100)
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected json log, err=%v", err)
+	}
+	if record["msg"] != "slow 100ms" || record["level"] != "WARN" ||
+		record[logger.FieldInterface] != "org.apache.dubbo.Greeter" || record[logger.FieldMethod] != "Greet" {
+		t.Fatalf("unexpected log %v", record)
+	}
+	if line, _ := record["line"].(string); !strings.HasPrefix(line, "slog/slog_test.go:") {
+		t.Fatalf("expected the caller to be the test, got %v", record["line"])
+	}
+
+	if !logger.SetLoggerLevel("error") {
+		t.Fatalf("expected logger.SetLoggerLevel to update the slog logger")
+	}
+	buf.Reset()
+	logger.CtxWarn(ctx, "dropped")
+	if buf.Len() != 0 {
+		t.Fatalf("expected warn log to be dropped, got %s", buf.String())
+	}
+}
+
+func TestParseLevels(t *testing.T) {
+	lv, err := parseLevels(" warning , a/b = debug ,c=fatal")
+	if err != nil {
+		t.Fatalf("expected levels, err=%v", err)
+	}
+	if lv.root != slog.LevelWarn || lv.overrides["a/b"] != slog.LevelDebug || lv.overrides["c"] != LevelFatal {
+		t.Fatalf("unexpected levels %+v", lv)
+	}
+	if lv.min != slog.LevelDebug {
+		t.Fatalf("expected min level debug, got %v", lv.min)
+	}
+	if lv.levelOf("a/b/c") != slog.LevelDebug || lv.levelOf("a/bc") != slog.LevelWarn {
+		t.Fatalf("unexpected levels of the packages")
+	}
+}
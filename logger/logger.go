@@ -40,7 +40,7 @@ func GetLogger() Logger {
 
 // SetLoggerLevel is used to set the logger level.
 func SetLoggerLevel(level string) bool {
-	if l, ok := logger.(OpsLogger); ok {
+	if l, ok := currentLogger().(OpsLogger); ok {
 		return l.SetLoggerLevel(level)
 	}
 	return false
@@ -51,6 +51,12 @@ func WithZap() Option {
 	}
 }
 
+func WithSlog() Option {
+	return func(opts *Options) {
+		opts.Logger.Driver = "slog"
+	}
+}
+
 func WithLevel(level string) Option {
 	return func(opts *Options) {
 		opts.Logger.Level = level
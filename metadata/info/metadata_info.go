@@ -55,7 +55,8 @@ var IncludeKeys = gxset.NewSet(
 	constant.VersionKey,
 	constant.WarmupKey,
 	constant.WeightKey,
-	constant.ReleaseKey)
+	constant.ReleaseKey,
+	constant.UnixSocketKey)
 
 // MetadataInfo the metadata information of instance
 type MetadataInfo struct {
@@ -17,6 +17,10 @@
 
 package metrics
 
+import (
+	"time"
+)
+
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
@@ -28,6 +32,8 @@ const eventType = constant.MetricsConfigCenter
 
 var ch = make(chan metrics.MetricsEvent, 10)
 var info = metrics.NewMetricKey("dubbo_configcenter_total", "Config Changed Total")
+var snapshotStaleness = metrics.NewMetricKey("dubbo_configcenter_snapshot_staleness_seconds",
+	"Staleness of the Config Served From the Local Snapshot")
 
 func init() {
 	metrics.AddCollector("config_center", func(mr metrics.MetricRegistry, url *common.URL) {
@@ -46,8 +52,11 @@ func (c *configCenterCollector) start() {
 	metrics.Subscribe(eventType, ch)
 	go func() {
 		for e := range ch {
-			if event, ok := e.(*ConfigCenterMetricEvent); ok {
+			switch event := e.(type) {
+			case *ConfigCenterMetricEvent:
 				c.handleDataChange(event)
+			case *SnapshotMetricEvent:
+				c.handleSnapshot(event)
 			}
 		}
 	}()
@@ -58,10 +67,18 @@ func (c *configCenterCollector) handleDataChange(event *ConfigCenterMetricEvent)
 	c.r.Counter(id).Add(event.size)
 }
 
+// handleSnapshot sets the staleness of the config served from the local snapshot, it's 0 after the config is
+// fetched from the config centers again
+func (c *configCenterCollector) handleSnapshot(event *SnapshotMetricEvent) {
+	id := metrics.NewMetricId(snapshotStaleness, metrics.NewConfigCenterLevel(event.key, event.group, Snapshot, ""))
+	c.r.Gauge(id).Set(event.staleness.Seconds())
+}
+
 const (
 	Nacos     = "nacos"
 	Apollo    = "apollo"
 	Zookeeper = "zookeeper"
+	Snapshot  = "snapshot"
 )
 
 type ConfigCenterMetricEvent struct {
@@ -93,3 +110,19 @@ func (*ConfigCenterMetricEvent) Type() string {
 func NewIncMetricEvent(key, group string, changeType remoting.EventType, c string) *ConfigCenterMetricEvent {
 	return &ConfigCenterMetricEvent{key: key, group: group, changeType: changeType, configCenter: c, size: 1}
 }
+
+// SnapshotMetricEvent reports the staleness of the config served from the local snapshot when all the config
+// centers are unavailable
+type SnapshotMetricEvent struct {
+	key       string
+	group     string
+	staleness time.Duration
+}
+
+func (*SnapshotMetricEvent) Type() string {
+	return eventType
+}
+
+func NewSnapshotMetricEvent(key, group string, staleness time.Duration) *SnapshotMetricEvent {
+	return &SnapshotMetricEvent{key: key, group: group, staleness: staleness}
+}
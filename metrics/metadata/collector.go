@@ -58,6 +58,14 @@ func (c *MetadataMetricCollector) start() {
 					c.handleMetadataSub(event)
 				case SubscribeServiceRt:
 					c.handleSubscribeService(event)
+				case MetadataCacheHit:
+					c.R.Counter(metrics.NewMetricId(metadataCacheHit, metrics.GetApplicationLevel())).Inc()
+				case MetadataCacheMiss:
+					c.R.Counter(metrics.NewMetricId(metadataCacheMiss, metrics.GetApplicationLevel())).Inc()
+				case MetadataCacheEvicted:
+					c.handleMetadataCacheEvicted(event)
+				case MetadataFetch:
+					c.handleMetadataFetch(event)
 				default:
 				}
 			}
@@ -88,6 +96,30 @@ func (c *MetadataMetricCollector) handleSubscribeService(event *MetadataMetricEv
 	c.R.Rt(metrics.NewMetricId(subscribeServiceRt, level), &metrics.RtOpts{}).Observe(event.CostMs())
 }
 
+func (c *MetadataMetricCollector) handleMetadataCacheEvicted(event *MetadataMetricEvent) {
+	level := &attachmentLevel{metrics.GetApplicationLevel(), event.Attachment}
+	c.R.Counter(metrics.NewMetricId(metadataCacheEvicted, level)).Inc()
+}
+
+func (c *MetadataMetricCollector) handleMetadataFetch(event *MetadataMetricEvent) {
+	level := &attachmentLevel{metrics.GetApplicationLevel(), event.Attachment}
+	c.StateCount(metadataFetchNum, metadataFetchSucceed, metadataFetchFailed, level, event.Succ)
+}
+
+// attachmentLevel is the application level with the attachment of the event as the tags
+type attachmentLevel struct {
+	*metrics.ApplicationMetricLevel
+	attachment map[string]string
+}
+
+func (l *attachmentLevel) Tags() map[string]string {
+	tags := l.ApplicationMetricLevel.Tags()
+	for k, v := range l.attachment {
+		tags[k] = v
+	}
+	return tags
+}
+
 type MetadataMetricEvent struct {
 	Name       MetricName
 	Succ       bool
@@ -107,3 +139,21 @@ func (e *MetadataMetricEvent) CostMs() float64 {
 func NewMetadataMetricTimeEvent(n MetricName) *MetadataMetricEvent {
 	return &MetadataMetricEvent{Name: n, Start: time.Now(), Attachment: make(map[string]string)}
 }
+
+// NewMetadataCacheEvent returns the event of the hit or the miss of the metadata cache
+func NewMetadataCacheEvent(hit bool) *MetadataMetricEvent {
+	if hit {
+		return &MetadataMetricEvent{Name: MetadataCacheHit, Attachment: make(map[string]string)}
+	}
+	return &MetadataMetricEvent{Name: MetadataCacheMiss, Attachment: make(map[string]string)}
+}
+
+// NewMetadataCacheEvictedEvent returns the event of the revision evicted from the metadata cache for the reason
+func NewMetadataCacheEvictedEvent(reason string) *MetadataMetricEvent {
+	return &MetadataMetricEvent{Name: MetadataCacheEvicted, Attachment: map[string]string{constant.TagReason: reason}}
+}
+
+// NewMetadataFetchEvent returns the event of fetching the metadata in the mode, which is rpc or report
+func NewMetadataFetchEvent(mode string, succ bool) *MetadataMetricEvent {
+	return &MetadataMetricEvent{Name: MetadataFetch, Succ: succ, Attachment: map[string]string{constant.TagMode: mode}}
+}
@@ -31,6 +31,10 @@ const (
 	// SubscribeRt
 	// StoreProviderInterfaceRt
 	SubscribeServiceRt
+	MetadataCacheHit
+	MetadataCacheMiss
+	MetadataCacheEvicted
+	MetadataFetch
 )
 
 const (
@@ -41,6 +45,8 @@ const (
 	dubboMetadataStoreProvider    = "dubbo_metadata_store_provider"
 	dubboStoreProviderInterfaceRt = "dubbo_store_provider_interface_rt_milliseconds"
 	dubboSubscribeServiceRt       = "dubbo_subscribe_service_rt_milliseconds"
+	dubboMetadataCache            = "dubbo_metadata_cache"
+	dubboMetadataFetch            = "dubbo_metadata_fetch"
 )
 
 const (
@@ -84,4 +90,14 @@ var (
 	storeProviderInterfaceRt = metrics.NewMetricKey(dubboStoreProviderInterfaceRt, "Store Provider Interface Time")
 
 	subscribeServiceRt = metrics.NewMetricKey(dubboSubscribeServiceRt, "Subscribe Service Time")
+
+	// app level, the revisions of the metadata cached by the consumers
+	metadataCacheHit  = metrics.NewMetricKey(dubboMetadataCache+"_hit"+totalSuffix, "Total Metadata Cache Hit Num")
+	metadataCacheMiss = metrics.NewMetricKey(dubboMetadataCache+"_miss"+totalSuffix, "Total Metadata Cache Miss Num")
+	// app level, with the reason of the eviction, which is expired or capacity
+	metadataCacheEvicted = metrics.NewMetricKey(dubboMetadataCache+"_evicted"+totalSuffix, "Total Metadata Cache Evicted Num")
+	// app level, with the mode of the fetch, which is rpc or report
+	metadataFetchNum     = metrics.NewMetricKey(dubboMetadataFetch+totalSuffix, "Total Metadata Fetch Num")
+	metadataFetchSucceed = metrics.NewMetricKey(dubboMetadataFetch+succSuffix, "Succeed Metadata Fetch Num")
+	metadataFetchFailed  = metrics.NewMetricKey(dubboMetadataFetch+failedSuffix, "Failed Metadata Fetch Num")
 )
@@ -62,6 +62,20 @@ func (c *rpcCollector) start() {
 				c.beforeInvokeHandler(rpcEvent)
 			case AfterInvoke:
 				c.afterInvokeHandler(rpcEvent)
+			case Queued:
+				c.queuedHandler(rpcEvent)
+			case Rejected:
+				c.rejectedHandler(rpcEvent)
+			case AuthFailed:
+				c.authFailedHandler(rpcEvent)
+			case LocalitySelected:
+				c.localitySelectedHandler(rpcEvent)
+			case ConnectionOpened:
+				c.metricSet.consumer.connections.Inc(buildConnLabels(rpcEvent))
+			case ConnectionClosed:
+				c.metricSet.consumer.connections.Dec(buildConnLabels(rpcEvent))
+			case ConnPoolExhausted:
+				c.metricSet.consumer.connPoolExhaustedTotal.Inc(buildConnLabels(rpcEvent))
 			default:
 			}
 		} else {
@@ -103,6 +117,41 @@ func (c *rpcCollector) afterInvokeHandler(event *metricsEvent) {
 	c.reportRTMilliseconds(role, labels, event.costTime.Milliseconds())
 }
 
+func (c *rpcCollector) queuedHandler(event *metricsEvent) {
+	url := event.invoker.GetURL()
+	if !isProvider(url) {
+		return
+	}
+	labels := buildLabels(url, event.invocation)
+	c.metricSet.provider.queueTimeMilliseconds.Record(labels, float64(event.costTime.Milliseconds()))
+}
+
+func (c *rpcCollector) rejectedHandler(event *metricsEvent) {
+	url := event.invoker.GetURL()
+	if !isProvider(url) {
+		return
+	}
+	labels := buildLabels(url, event.invocation)
+	c.metricSet.provider.requestsRejectedTotal.Inc(labels)
+}
+
+func (c *rpcCollector) authFailedHandler(event *metricsEvent) {
+	url := event.invoker.GetURL()
+	if !isProvider(url) {
+		return
+	}
+	labels := buildLabels(url, event.invocation)
+	labels[constant.TagReason] = event.reason
+	c.metricSet.provider.authFailedTotal.Inc(labels)
+}
+
+func (c *rpcCollector) localitySelectedHandler(event *metricsEvent) {
+	labels := buildLabels(event.invoker.GetURL(), event.invocation)
+	labels[constant.TagZone] = event.zone
+	labels[constant.TagLocality] = event.locality
+	c.metricSet.consumer.localityRequestsTotal.Inc(labels)
+}
+
 func (c *rpcCollector) recordQps(role string, labels map[string]string) {
 	switch role {
 	case constant.SideProvider:
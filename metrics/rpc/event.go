@@ -22,6 +22,7 @@ import (
 )
 
 import (
+	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/metrics"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
@@ -35,6 +36,14 @@ type metricsEvent struct {
 	invocation base.Invocation
 	costTime   time.Duration
 	result     result.Result
+	// reason is why the invocation failed the authentication
+	reason string
+	// zone and locality are of the provider selected by the locality load balance
+	zone     string
+	locality string
+	// url and address are of the connections of the consumer, url is the one of the invoker
+	url     *common.URL
+	address string
 }
 
 // Type returns the type of the event, it is used for metrics bus to dispatch the event to rpc collector
@@ -47,6 +56,13 @@ type metricsName uint8
 const (
 	BeforeInvoke metricsName = iota
 	AfterInvoke
+	Queued
+	Rejected
+	AuthFailed
+	LocalitySelected
+	ConnectionOpened
+	ConnectionClosed
+	ConnPoolExhausted
 )
 
 func NewBeforeInvokeEvent(invoker base.Invoker, invocation base.Invocation) metrics.MetricsEvent {
@@ -66,3 +82,61 @@ func NewAfterInvokeEvent(invoker base.Invoker, invocation base.Invocation, costT
 		result:     result,
 	}
 }
+
+// NewQueuedEvent is published by the provider when the invocation waited queueTime in the thread pool
+// before it's run
+func NewQueuedEvent(invoker base.Invoker, invocation base.Invocation, queueTime time.Duration) metrics.MetricsEvent {
+	return &metricsEvent{
+		name:       Queued,
+		invoker:    invoker,
+		invocation: invocation,
+		costTime:   queueTime,
+	}
+}
+
+// NewRejectedEvent is published by the provider when the invocation is rejected by the exhausted thread pool
+func NewRejectedEvent(invoker base.Invoker, invocation base.Invocation) metrics.MetricsEvent {
+	return &metricsEvent{
+		name:       Rejected,
+		invoker:    invoker,
+		invocation: invocation,
+	}
+}
+
+// NewAuthFailedEvent is published by the provider when the invocation fails the authentication for the reason
+func NewAuthFailedEvent(invoker base.Invoker, invocation base.Invocation, reason string) metrics.MetricsEvent {
+	return &metricsEvent{
+		name:       AuthFailed,
+		invoker:    invoker,
+		invocation: invocation,
+		reason:     reason,
+	}
+}
+
+// NewLocalitySelectedEvent is published by the consumer when the locality load balance selects the invoker in the
+// zone, locality is the relation between the zone and the consumer, e.g. local
+func NewLocalitySelectedEvent(invoker base.Invoker, invocation base.Invocation, zone, locality string) metrics.MetricsEvent {
+	return &metricsEvent{
+		name:       LocalitySelected,
+		invoker:    invoker,
+		invocation: invocation,
+		zone:       zone,
+		locality:   locality,
+	}
+}
+
+// NewConnectionOpenedEvent is published by the consumer when a connection to the address is opened
+func NewConnectionOpenedEvent(url *common.URL, address string) metrics.MetricsEvent {
+	return &metricsEvent{name: ConnectionOpened, url: url, address: address}
+}
+
+// NewConnectionClosedEvent is published by the consumer when a connection to the address is closed
+func NewConnectionClosedEvent(url *common.URL, address string) metrics.MetricsEvent {
+	return &metricsEvent{name: ConnectionClosed, url: url, address: address}
+}
+
+// NewConnPoolExhaustedEvent is published by the consumer when a request is sent over a connection whose streams
+// reach the limit, because the connections to the address reach the max
+func NewConnPoolExhaustedEvent(url *common.URL, address string) metrics.MetricsEvent {
+	return &metricsEvent{name: ConnPoolExhausted, url: url, address: address}
+}
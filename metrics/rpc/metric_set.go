@@ -29,10 +29,20 @@ type metricSet struct {
 
 type providerMetrics struct {
 	rpcCommonMetrics
+	// queueTimeMilliseconds is the time the requests wait in the thread pools
+	queueTimeMilliseconds metrics.RtVec
+	requestsRejectedTotal metrics.CounterVec
+	// authFailedTotal is labeled by the reason of the failures
+	authFailedTotal metrics.CounterVec
 }
 
 type consumerMetrics struct {
 	rpcCommonMetrics
+	// localityRequestsTotal is labeled by the zones of the providers selected by the locality load balance
+	localityRequestsTotal metrics.CounterVec
+	// connections and connPoolExhaustedTotal are labeled by the addresses of the connections
+	connections            metrics.GaugeVec
+	connPoolExhaustedTotal metrics.CounterVec
 }
 
 // rpcCommonMetrics is the common metrics for both provider and consumer
@@ -84,6 +94,12 @@ func (pm *providerMetrics) init(registry metrics.MetricRegistry) {
 		metrics.NewMetricKey("dubbo_provider_rt_milliseconds_p95", "The total response time spent by providers processing 95% of requests"),
 		metrics.NewMetricKey("dubbo_provider_rt_milliseconds_p99", "The total response time spent by providers processing 99% of requests"),
 	}, []float64{0.5, 0.9, 0.95, 0.99})
+	pm.queueTimeMilliseconds = metrics.NewRtVec(registry,
+		metrics.NewMetricKey("dubbo_provider_queue_time_milliseconds", "The time requests wait in the thread pools of the provider"),
+		&metrics.RtOpts{Aggregate: false},
+	)
+	pm.requestsRejectedTotal = metrics.NewCounterVec(registry, metrics.NewMetricKey("dubbo_provider_requests_rejected_total", "The number of requests rejected by the exhausted thread pools of the provider"))
+	pm.authFailedTotal = metrics.NewCounterVec(registry, metrics.NewMetricKey("dubbo_provider_auth_failed_total", "The number of requests failing the authentication of the provider"))
 }
 
 func (cm *consumerMetrics) init(registry metrics.MetricRegistry) {
@@ -109,4 +125,7 @@ func (cm *consumerMetrics) init(registry metrics.MetricRegistry) {
 		metrics.NewMetricKey("dubbo_consumer_rt_milliseconds_p95", "The total response time spent by consumers processing 95% of requests"),
 		metrics.NewMetricKey("dubbo_consumer_rt_milliseconds_p99", "The total response time spent by consumers processing 99% of requests"),
 	}, []float64{0.5, 0.9, 0.95, 0.99})
+	cm.localityRequestsTotal = metrics.NewCounterVec(registry, metrics.NewMetricKey("dubbo_consumer_locality_requests_total", "The number of requests sent by consumers to the providers of each zone by the locality load balance"))
+	cm.connections = metrics.NewGaugeVec(registry, metrics.NewMetricKey("dubbo_consumer_connections", "The number of the connections opened by the consumer to each address"))
+	cm.connPoolExhaustedTotal = metrics.NewCounterVec(registry, metrics.NewMetricKey("dubbo_consumer_conn_pool_exhausted_total", "The number of requests sent over the busy connections because the connections to the address reach the max"))
 }
@@ -46,6 +46,16 @@ func buildLabels(url *common.URL, invocation base.Invocation) map[string]string
 	}
 }
 
+// buildConnLabels will build the labels for the metrics of the connections
+func buildConnLabels(event *metricsEvent) map[string]string {
+	return map[string]string{
+		constant.TagApplicationName: event.url.GetParam(constant.ApplicationKey, ""),
+		constant.TagHostname:        common.GetLocalHostName(),
+		constant.TagIp:              common.GetLocalIp(),
+		constant.TagAddress:         event.address,
+	}
+}
+
 // getRole will get the application role from the url
 func getRole(url *common.URL) (role string) {
 	if isProvider(url) {
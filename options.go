@@ -85,6 +85,10 @@ func (rc *InstanceOptions) init(opts ...InstanceOption) error {
 	for _, opt := range opts {
 		opt(rc)
 	}
+	// the environment variables and the command-line arguments take precedence over the options
+	if err := config.ApplyOverlays(rc); err != nil {
+		return err
+	}
 
 	// remaining procedure is like RootConfig.Init() without RootConfig.Start()
 	// tasks of RootConfig.Start() would be decomposed to Client and Server
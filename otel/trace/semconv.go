@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trace
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// The names of the spans of the framework operations
+const (
+	SpanClusterAttempt    = "dubbo.cluster.attempt"
+	SpanRegistrySubscribe = "dubbo.registry.subscribe"
+	SpanRegistryNotify    = "dubbo.registry.notify"
+	SpanConfigCenterFetch = "dubbo.config_center.fetch"
+)
+
+// The attributes of the spans of the framework operations
+var (
+	ClusterKey           = attribute.Key("dubbo.cluster")
+	ClusterAttemptKey    = attribute.Key("dubbo.cluster.attempt")
+	RegistryKey          = attribute.Key("dubbo.registry")
+	ServiceKey           = attribute.Key("dubbo.service")
+	EventTypeKey         = attribute.Key("dubbo.event.type")
+	EventCountKey        = attribute.Key("dubbo.event.count")
+	ConfigCenterKey      = attribute.Key("dubbo.config_center")
+	ConfigCenterGroupKey = attribute.Key("dubbo.config_center.group")
+	ConfigCenterDataKey  = attribute.Key("dubbo.config_center.data_id")
+)
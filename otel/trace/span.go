@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trace
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"google.golang.org/protobuf/proto"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+)
+
+// Tracer returns the tracer of dubbo from the global tracer provider, which is set by the otel config
+func Tracer() trace.Tracer {
+	return otel.GetTracerProvider().Tracer(
+		constant.OtelPackageName,
+		trace.WithInstrumentationVersion(sdk.Version()),
+	)
+}
+
+// StartSpan starts the internal span of the framework operation like the registry subscriptions and the config
+// center fetches. It's a non-recording span if the tracing is disabled.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithSpanKind(trace.SpanKindInternal), trace.WithAttributes(attrs...))
+}
+
+// EndSpan records err on the span and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// AddMessageEvent records a message sent or received as the event of the span, the uncompressed size is recorded
+// for the protobuf messages
+func AddMessageEvent(span trace.Span, sent bool, id int64, msg any) {
+	if !span.IsRecording() {
+		return
+	}
+	attrs := make([]attribute.KeyValue, 0, 3)
+	if sent {
+		attrs = append(attrs, semconv.MessageTypeSent)
+	} else {
+		attrs = append(attrs, semconv.MessageTypeReceived)
+	}
+	attrs = append(attrs, semconv.MessageIDKey.Int64(id))
+	if size, ok := MessageSize(msg); ok {
+		attrs = append(attrs, semconv.MessageUncompressedSizeKey.Int(size))
+	}
+	span.AddEvent("message", trace.WithAttributes(attrs...))
+}
+
+// MessageSize returns the size of the message if it's a protobuf message
+func MessageSize(msg any) (int, bool) {
+	if m, ok := msg.(proto.Message); ok {
+		return proto.Size(m), true
+	}
+	return 0, false
+}
+
+type streamSpanKey struct{}
+
+// StreamSpan holds the span of a triple stream, the messages of the stream are recorded as its events.
+// On the server side the stream is accepted before the span is started by the tracing filter, so the span is
+// bound to the holder once it's started. On the client side the stream outlives the invocation which returns it,
+// so the stream claims the span bound by the tracing filter and ends it once it's closed.
+type StreamSpan struct {
+	span     atomic.Pointer[trace.Span]
+	claimed  atomic.Bool
+	ended    atomic.Bool
+	sent     atomic.Int64
+	received atomic.Int64
+}
+
+// WithStreamSpan returns the context carrying the holder of the span of the stream
+func WithStreamSpan(ctx context.Context) (context.Context, *StreamSpan) {
+	s := &StreamSpan{}
+	return context.WithValue(ctx, streamSpanKey{}, s), s
+}
+
+// StreamSpanFromContext returns the holder of the span of the stream in ctx, it's nil if ctx isn't of a stream
+func StreamSpanFromContext(ctx context.Context) *StreamSpan {
+	s, _ := ctx.Value(streamSpanKey{}).(*StreamSpan)
+	return s
+}
+
+// Bind binds the span to the holder
+func (s *StreamSpan) Bind(span trace.Span) {
+	s.span.Store(&span)
+}
+
+// Claim makes the stream end the span bound once it's closed, it returns false if no span is bound
+func (s *StreamSpan) Claim() bool {
+	if s.span.Load() == nil {
+		return false
+	}
+	s.claimed.Store(true)
+	return true
+}
+
+// Claimed returns true if the span is ended by the stream
+func (s *StreamSpan) Claimed() bool {
+	return s.claimed.Load()
+}
+
+// End ends the span bound with err like EndSpan, the span is ended only once
+func (s *StreamSpan) End(err error) {
+	if span := s.span.Load(); span != nil && s.ended.CompareAndSwap(false, true) {
+		EndSpan(*span, err)
+	}
+}
+
+// Sent records the message sent on the span bound, the message is dropped if the span isn't bound yet
+func (s *StreamSpan) Sent(msg any) {
+	if span := s.span.Load(); span != nil {
+		AddMessageEvent(*span, true, s.sent.Add(1), msg)
+	}
+}
+
+// Received records the message received on the span bound, the message is dropped if the span isn't bound yet
+func (s *StreamSpan) Received(msg any) {
+	if span := s.span.Load(); span != nil {
+		AddMessageEvent(*span, false, s.received.Add(1), msg)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestEndSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "ok")
+	EndSpan(span, nil)
+	_, span = tracer.Start(context.Background(), "failed")
+	EndSpan(span, errors.New("timeout"))
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "timeout", spans[1].Status().Description)
+	assert.Len(t, spans[1].Events(), 1)
+}
+
+func TestStreamSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	ctx, stream := WithStreamSpan(context.Background())
+	assert.Equal(t, stream, StreamSpanFromContext(ctx))
+	assert.Nil(t, StreamSpanFromContext(context.Background()))
+
+	// the messages before the span is bound are dropped
+	stream.Received(wrapperspb.String("dropped"))
+	assert.False(t, stream.Claim())
+
+	_, span := tracer.Start(ctx, "stream")
+	stream.Bind(span)
+	assert.True(t, stream.Claim())
+	assert.True(t, stream.Claimed())
+	stream.Received(wrapperspb.String("hello"))
+	stream.Sent(wrapperspb.String("world"))
+	stream.Sent(nil)
+	stream.End(nil)
+	stream.End(errors.New("ended twice"))
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	events := spans[0].Events()
+	assert.Len(t, events, 3)
+	assert.Contains(t, events[0].Attributes, semconv.MessageTypeReceived)
+	assert.Contains(t, events[0].Attributes, semconv.MessageIDKey.Int64(1))
+	assert.Contains(t, events[0].Attributes, semconv.MessageUncompressedSizeKey.Int(7))
+	assert.Contains(t, events[1].Attributes, semconv.MessageTypeSent)
+	assert.Contains(t, events[2].Attributes, semconv.MessageIDKey.Int64(2))
+	// the size of the message isn't known if it's not a protobuf message
+	assert.Len(t, events[2].Attributes, 2)
+}
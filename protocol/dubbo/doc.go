@@ -16,4 +16,11 @@
  */
 
 // Package dubbo implements dubbo rpc protocol.
+//
+// Besides unary calls, the protocol supports server streaming and bidirectional streaming calls, see
+// ServerStream and BidiStream. Streams are a dubbo-go extension carried by the dubbo.stream.* attachments
+// of ordinary dubbo requests and responses, so they only work between dubbo-go consumers and providers.
+// Dubbo java doesn't support them: a stream call to a dubbo java provider fails with
+// remoting.ErrStreamUnsupported, and an unary call of a stream method from a dubbo java consumer is
+// rejected. Use the triple protocol for streaming calls across languages.
 package dubbo
@@ -29,6 +29,8 @@ import (
 	"github.com/dubbogo/gost/log/logger"
 
 	"github.com/opentracing/opentracing-go"
+
+	perrors "github.com/pkg/errors"
 )
 
 import (
@@ -136,7 +138,16 @@ func (di *DubboInvoker) Invoke(ctx context.Context, ivc base.Invocation) result.
 	}
 	// response := NewResponse(inv.Reply(), nil)
 	rest := &result.RPCResult{}
-	timeout := di.getTimeout(inv)
+	timeout := di.getTimeout(ctx, inv)
+	if _, ok := ctx.Deadline(); ok && timeout <= 0 {
+		// the deadline of ctx, which may be propagated from the upstream, is exceeded
+		res.SetError(perrors.Wrapf(context.DeadlineExceeded, "invoke %s.%s", url.Service(), inv.MethodName()))
+		return &res
+	}
+	if callType, ok := inv.GetAttribute(constant.CallTypeKey); ok &&
+		(callType == constant.CallServerStream || callType == constant.CallBidiStream) {
+		return di.invokeStream(ctx, client, inv, callType.(string), url, timeout)
+	}
 	if async {
 		if callBack, ok := inv.CallBack().(func(response common.CallbackResponse)); ok {
 			err = client.AsyncRequest(&ivc, url, timeout, callBack, rest)
@@ -161,11 +172,34 @@ func (di *DubboInvoker) Invoke(ctx context.Context, ivc base.Invocation) result.
 	return &res
 }
 
-// get timeout including methodConfig
-func (di *DubboInvoker) getTimeout(ivc *invocation.RPCInvocation) time.Duration {
+// invokeStream opens a stream, the result is *ServerStreamForClient or *BidiStreamForClient according to callType.
+func (di *DubboInvoker) invokeStream(ctx context.Context, client *remoting.ExchangeClient, inv *invocation.RPCInvocation,
+	callType string, url *common.URL, timeout time.Duration) result.Result {
+	var res result.RPCResult
+	window := int(url.GetParamInt(constant.StreamWindowKey, constant.DefaultStreamWindow))
+	inv.SetAttachment(constant.StreamVersionKey, constant.StreamVersion)
+	inv.SetAttachment(constant.StreamTypeKey, callType)
+	inv.SetAttachment(constant.StreamWindowKey, strconv.Itoa(window))
+
+	stream, err := client.NewStream(ctx, inv, url, timeout, window)
+	if err != nil {
+		res.SetError(err)
+		return &res
+	}
+	if callType == constant.CallServerStream {
+		res.SetResult(&ServerStreamForClient{stream: stream})
+	} else {
+		res.SetResult(&BidiStreamForClient{stream: stream})
+	}
+	return &res
+}
+
+// get timeout including methodConfig, it's shortened to the remaining time before the deadline of ctx,
+// so the deadline propagated from the upstream is carried by the timeout attachment to the downstream
+func (di *DubboInvoker) getTimeout(ctx context.Context, ivc *invocation.RPCInvocation) time.Duration {
 	timeout := di.timeout                                                //default timeout
 	if attachTimeout, ok := ivc.GetAttachment(constant.TimeoutKey); ok { //check invocation timeout
-		timeout, _ = time.ParseDuration(attachTimeout)
+		timeout, _ = parseTimeout(attachTimeout)
 	} else { // check method timeout
 		methodName := ivc.MethodName()
 		if di.GetURL().GetParamBool(constant.GenericKey, false) {
@@ -176,11 +210,25 @@ func (di *DubboInvoker) getTimeout(ivc *invocation.RPCInvocation) time.Duration
 			timeout, _ = time.ParseDuration(mTimeout)
 		}
 	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < timeout {
+			timeout = remaining
+		}
+	}
 	// set timeout into invocation
 	ivc.SetAttachment(constant.TimeoutKey, strconv.Itoa(int(timeout.Milliseconds())))
 	return timeout
 }
 
+// parseTimeout parses the timeout attachment, it's in milliseconds as dubbo java sends,
+// or the duration set by users
+func parseTimeout(timeout string) (time.Duration, error) {
+	if millis, err := strconv.ParseInt(timeout, 10, 64); err == nil {
+		return time.Duration(millis) * time.Millisecond, nil
+	}
+	return time.ParseDuration(timeout)
+}
+
 func (di *DubboInvoker) IsAvailable() bool {
 	client := di.getClient()
 	if client != nil {
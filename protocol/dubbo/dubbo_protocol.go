@@ -20,6 +20,7 @@ package dubbo
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sync"
 	"time"
 )
@@ -115,7 +116,7 @@ func (dp *DubboProtocol) Destroy() {
 }
 
 func (dp *DubboProtocol) openServer(url *common.URL) {
-	_, ok := dp.serverMap[url.Location]
+	srv, ok := dp.serverMap[url.Location]
 	if !ok {
 		_, ok := dp.ExporterMap().Load(url.ServiceKey())
 		if !ok {
@@ -123,17 +124,21 @@ func (dp *DubboProtocol) openServer(url *common.URL) {
 		}
 
 		dp.serverLock.Lock()
-		_, ok = dp.serverMap[url.Location]
+		srv, ok = dp.serverMap[url.Location]
 		if !ok {
 			handler := func(invocation *invocation.RPCInvocation) result.RPCResult {
 				return doHandleRequest(invocation)
 			}
-			srv := remoting.NewExchangeServer(url, getty.NewServer(url, handler))
+			srv = remoting.NewExchangeServer(url, getty.NewServer(url, handler))
 			dp.serverMap[url.Location] = srv
 			srv.Start()
 		}
 		dp.serverLock.Unlock()
 	}
+	// the unix domain socket is served by the server of the first exported service on the address
+	if gettyServer, ok := srv.Server.(*getty.Server); ok {
+		gettyServer.SyncUnixSocketParams(url)
+	}
 }
 
 // GetProtocol get a single dubbo protocol.
@@ -158,6 +163,18 @@ func doHandleRequest(rpcInvocation *invocation.RPCInvocation) result.RPCResult {
 	if invoker != nil {
 		// FIXME
 		ctx := rebuildCtx(rpcInvocation)
+		if stream, ok := rpcInvocation.GetAttribute(constant.StreamKey); ok {
+			appendStreamArgument(rpcInvocation, stream.(*remoting.Stream))
+		} else if isStreamMethod(rpcInvocation) {
+			// e.g. dubbo java consumers, which don't support dubbo-go streams
+			result.Err = fmt.Errorf("%s.%s is a dubbo-go stream method, it can only be called by dubbo-go consumers "+
+				"by stream calls", rpcInvocation.ServiceKey(), rpcInvocation.MethodName())
+			return result
+		} else {
+			var cancel context.CancelFunc
+			ctx, cancel = withDeadline(ctx, rpcInvocation)
+			defer cancel()
+		}
 
 		invokeResult := invoker.Invoke(ctx, rpcInvocation)
 		if err := invokeResult.Error(); err != nil {
@@ -225,7 +242,12 @@ func getExchangeClient(url *common.URL) *remoting.ExchangeClient {
 // Once we decided to transfer more context's key-value, we should change this.
 // now we only support rebuild the tracing context
 func rebuildCtx(inv *invocation.RPCInvocation) context.Context {
-	ctx := context.WithValue(context.Background(), constant.DubboCtxKey("attachment"), inv.Attachments())
+	parent := context.Background()
+	// the context of a stream is canceled together with the stream
+	if stream, ok := inv.GetAttribute(constant.StreamKey); ok {
+		parent = stream.(*remoting.Stream).Context()
+	}
+	ctx := context.WithValue(parent, constant.DubboCtxKey("attachment"), inv.Attachments())
 
 	// actually, if user do not use any opentracing framework, the err will not be nil.
 	spanCtx, err := opentracing.GlobalTracer().Extract(opentracing.TextMap,
@@ -235,3 +257,50 @@ func rebuildCtx(inv *invocation.RPCInvocation) context.Context {
 	}
 	return ctx
 }
+
+// withDeadline applies the timeout sent by the consumer as the deadline of the invocation, so the invocations
+// made by the provider with the context inherit the remaining time
+func withDeadline(ctx context.Context, inv *invocation.RPCInvocation) (context.Context, context.CancelFunc) {
+	attachTimeout, ok := inv.GetAttachment(constant.TimeoutKey)
+	if !ok {
+		return ctx, func() {}
+	}
+	timeout, err := parseTimeout(attachTimeout)
+	if err != nil || timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// isStreamMethod tells whether the handler of the invocation takes a stream as the last argument.
+func isStreamMethod(inv *invocation.RPCInvocation) bool {
+	interfaceName := inv.GetAttachmentWithDefaultValue(constant.InterfaceKey, inv.GetAttachmentWithDefaultValue(constant.PathKey, ""))
+	svc := common.ServiceMap.GetService(DUBBO, interfaceName, inv.GetAttachmentWithDefaultValue(constant.GroupKey, ""),
+		inv.GetAttachmentWithDefaultValue(constant.VersionKey, ""))
+	if svc == nil {
+		return false
+	}
+	method, ok := svc.Method()[inv.MethodName()]
+	if !ok || len(method.ArgsType()) == 0 {
+		return false
+	}
+	switch method.ArgsType()[len(method.ArgsType())-1] {
+	case reflect.TypeOf(&ServerStream{}), reflect.TypeOf(&BidiStream{}):
+		return true
+	}
+	return false
+}
+
+// appendStreamArgument passes the stream to the handler as the last argument.
+func appendStreamArgument(inv *invocation.RPCInvocation, stream *remoting.Stream) {
+	args := inv.Arguments()
+	switch inv.GetAttachmentWithDefaultValue(constant.StreamTypeKey, "") {
+	case constant.CallServerStream:
+		args = append(args, &ServerStream{stream: stream})
+	case constant.CallBidiStream:
+		args = append(args, &BidiStream{stream: stream})
+	default:
+		return
+	}
+	inv.SetArguments(args)
+}
@@ -18,7 +18,10 @@
 package dubbo
 
 import (
+	"context"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 import (
@@ -29,6 +32,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/proxy/proxy_factory"
 	"dubbo.apache.org/dubbo-go/v3/remoting/getty"
 )
@@ -115,6 +119,32 @@ func TestDubboProtocol_Export(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestDubboProtocol_ExportUnixSocket(t *testing.T) {
+	initDubboInvokerTest()
+	proto := GetProtocol()
+	defer proto.Destroy()
+	socket := "unix://" + filepath.Join(t.TempDir(), "dubbo.sock")
+	url, err := common.NewURL(mockCommonUrl,
+		common.WithParamsValue(constant.UnixSocketKey, socket),
+		common.WithParamsValue(constant.HostIdKey, "node-1"))
+	assert.NoError(t, err)
+	url.Location = "127.0.0.1:0"
+	proto.Export(base.NewBaseInvoker(url))
+	assert.Equal(t, socket, url.GetParam(constant.UnixSocketKey, ""))
+	assert.Equal(t, "node-1", url.GetParam(constant.HostIdKey, ""))
+
+	// the other services on the address share the socket of the server
+	url2, err := common.NewURL(mockCommonUrl,
+		common.WithParamsValue(constant.VersionKey, "v1.1"),
+		common.WithParamsValue(constant.UnixSocketKey, "unix://"+filepath.Join(t.TempDir(), "another.sock")),
+		common.WithParamsValue(constant.HostIdKey, "node-1"))
+	assert.NoError(t, err)
+	url2.Location = url.Location
+	proto.Export(base.NewBaseInvoker(url2))
+	assert.Empty(t, url2.GetParam(constant.UnixSocketKey, ""))
+	assert.Empty(t, url2.GetParam(constant.HostIdKey, ""))
+}
+
 func TestDubboProtocolReferNoConnect(t *testing.T) {
 	// Refer
 	initDubboInvokerTest()
@@ -149,3 +179,37 @@ func TestDubboProtocol_Refer(t *testing.T) {
 	invokersLen = len(proto.(*DubboProtocol).Invokers())
 	assert.Equal(t, 0, invokersLen)
 }
+
+func TestGetTimeout(t *testing.T) {
+	url, err := common.NewURL(mockCommonUrl)
+	assert.NoError(t, err)
+	invoker := NewDubboInvoker(url, nil)
+
+	inv := invocation.NewRPCInvocationWithOptions(invocation.WithMethodName("GetUser"))
+	assert.Equal(t, 3*time.Second, invoker.getTimeout(context.Background(), inv))
+	assert.Equal(t, "3000", inv.GetAttachmentWithDefaultValue(constant.TimeoutKey, ""))
+	// the timeout attachment in milliseconds is reused by the retries
+	assert.Equal(t, 3*time.Second, invoker.getTimeout(context.Background(), inv))
+
+	// the timeout is shortened to the remaining time of the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	timeout := invoker.getTimeout(ctx, inv)
+	assert.True(t, timeout > 0 && timeout <= time.Second)
+	assert.NotEqual(t, "3000", inv.GetAttachmentWithDefaultValue(constant.TimeoutKey, ""))
+}
+
+func TestWithDeadline(t *testing.T) {
+	inv := invocation.NewRPCInvocationWithOptions(invocation.WithMethodName("GetUser"))
+	ctx, cancel := withDeadline(context.Background(), inv)
+	cancel()
+	_, ok := ctx.Deadline()
+	assert.False(t, ok)
+
+	inv.SetAttachment(constant.TimeoutKey, "1000")
+	ctx, cancel = withDeadline(context.Background(), inv)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+}
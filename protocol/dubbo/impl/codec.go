@@ -183,8 +183,15 @@ func (c *ProtocolCodec) Decode(p *DubboPackage) error {
 		return perrors.New("Codec serializer is nil")
 	}
 	if p.IsResponse() {
+		var rspObj any = new(any)
+		// every frame of a stream carries a message of its own, and the late frames of
+		// a canceled stream are discarded
+		if pendingResponse := remoting.GetPendingResponse(remoting.SequenceType(p.Header.ID)); pendingResponse != nil &&
+			pendingResponse.Stream == nil {
+			rspObj = pendingResponse.Reply
+		}
 		p.Body = &ResponsePayload{
-			RspObj: remoting.GetPendingResponse(remoting.SequenceType(p.Header.ID)).Reply,
+			RspObj: rspObj,
 		}
 	}
 	return c.serializer.Unmarshal(body, p)
@@ -0,0 +1,172 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dubbo
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+// ServerStream is the handler side of a server streaming method. Providers declare the method as
+//
+//	func (s *Service) Method(ctx context.Context, req *Request, stream *dubbo.ServerStream) error
+//
+// and push responses by Send.
+type ServerStream struct {
+	stream *remoting.Stream
+}
+
+// Context returns the context of the stream, which is done when the consumer cancels the stream.
+func (s *ServerStream) Context() context.Context {
+	return s.stream.Context()
+}
+
+// Send sends a message to the consumer, it blocks if the consumer doesn't keep up.
+func (s *ServerStream) Send(msg any) error {
+	return s.stream.Send(msg)
+}
+
+// BidiStream is the handler side of a bidirectional streaming method. Providers declare the method as
+//
+//	func (s *Service) Method(ctx context.Context, stream *dubbo.BidiStream) error
+type BidiStream struct {
+	stream *remoting.Stream
+}
+
+// Context returns the context of the stream, which is done when the consumer cancels the stream.
+func (b *BidiStream) Context() context.Context {
+	return b.stream.Context()
+}
+
+// Receive receives a message from the consumer into msg. It returns io.EOF once the consumer
+// closes the sending side.
+func (b *BidiStream) Receive(msg any) error {
+	return receiveMessage(b.stream, msg)
+}
+
+// Send sends a message to the consumer, it blocks if the consumer doesn't keep up.
+func (b *BidiStream) Send(msg any) error {
+	return b.stream.Send(msg)
+}
+
+// ServerStreamForClient is the consumer side of a server streaming method,
+// it is returned by client.Connection.CallServerStream.
+type ServerStreamForClient struct {
+	stream *remoting.Stream
+	msg    any
+	err    error
+}
+
+// Receive advances the stream to the next message and reflects it into msg if msg is not nil.
+// It returns false when the stream is finished or an error occurs, call Err to check the error.
+func (s *ServerStreamForClient) Receive(msg any) bool {
+	if s.err != nil {
+		return false
+	}
+	raw, err := s.stream.Recv()
+	if err != nil {
+		s.err = err
+		return false
+	}
+	s.msg = raw
+	if msg != nil {
+		if err = hessian.ReflectResponse(raw, msg); err != nil {
+			s.err = err
+			return false
+		}
+		s.msg = msg
+	}
+	return true
+}
+
+// Msg returns the most recent message unmarshaled by a call to Receive.
+func (s *ServerStreamForClient) Msg() any {
+	return s.msg
+}
+
+// Err returns the first non-EOF error that was encountered by Receive.
+func (s *ServerStreamForClient) Err() error {
+	if errors.Is(s.err, io.EOF) {
+		return nil
+	}
+	return s.err
+}
+
+// Trailer returns the attachments sent by the provider when the stream finished.
+func (s *ServerStreamForClient) Trailer() map[string]any {
+	return s.stream.Trailer()
+}
+
+// Close cancels the stream if it is not finished.
+func (s *ServerStreamForClient) Close() error {
+	s.stream.Cancel()
+	return nil
+}
+
+// BidiStreamForClient is the consumer side of a bidirectional streaming method,
+// it is returned by client.Connection.CallBidiStream.
+type BidiStreamForClient struct {
+	stream *remoting.Stream
+}
+
+// Send sends a message to the provider, it blocks if the provider doesn't keep up.
+func (b *BidiStreamForClient) Send(msg any) error {
+	return b.stream.Send(msg)
+}
+
+// CloseRequest tells the provider that no more messages will be sent.
+func (b *BidiStreamForClient) CloseRequest() error {
+	return b.stream.CloseSend()
+}
+
+// Receive receives a message from the provider into msg. It returns io.EOF once the provider
+// finishes the stream normally.
+func (b *BidiStreamForClient) Receive(msg any) error {
+	return receiveMessage(b.stream, msg)
+}
+
+// Trailer returns the attachments sent by the provider when the stream finished.
+func (b *BidiStreamForClient) Trailer() map[string]any {
+	return b.stream.Trailer()
+}
+
+// CloseResponse cancels the stream if it is not finished.
+func (b *BidiStreamForClient) CloseResponse() error {
+	b.stream.Cancel()
+	return nil
+}
+
+func receiveMessage(stream *remoting.Stream, msg any) error {
+	raw, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if msg == nil {
+		return nil
+	}
+	return hessian.ReflectResponse(raw, msg)
+}
@@ -0,0 +1,141 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dubbo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/proxy/proxy_factory"
+)
+
+const mockStreamUrl = "dubbo://127.0.0.1:20010/com.test.StreamProvider?anyhost=true&" +
+	"interface=com.test.StreamProvider&side=provider&timeout=3000&dubbo.stream.window=2"
+
+type StreamProvider struct{}
+
+func (p *StreamProvider) Count(ctx context.Context, n int64, stream *ServerStream) error {
+	for i := int64(0); i < n; i++ {
+		if err := stream.Send(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *StreamProvider) Echo(ctx context.Context, stream *BidiStream) error {
+	for {
+		var msg string
+		err := stream.Receive(&msg)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = stream.Send("echo " + msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *StreamProvider) Fail(ctx context.Context, n int64, stream *ServerStream) error {
+	return errors.New("fail on purpose")
+}
+
+func TestDubboStream(t *testing.T) {
+	initDubboInvokerTest()
+	_, err := common.ServiceMap.Register("com.test.StreamProvider", constant.DubboProtocol, "", "", &StreamProvider{})
+	require.NoError(t, err)
+
+	proto := GetProtocol()
+	defer proto.Destroy()
+	url, err := common.NewURL(mockStreamUrl)
+	require.NoError(t, err)
+	proto.Export(&proxy_factory.ProxyInvoker{BaseInvoker: *base.NewBaseInvoker(url)})
+	invoker := proto.Refer(url)
+	require.NotNil(t, invoker)
+
+	t.Run("server stream", func(t *testing.T) {
+		inv := invocation.NewRPCInvocationWithOptions(invocation.WithMethodName("Count"),
+			invocation.WithArguments([]any{int64(10)}))
+		inv.SetAttribute(constant.CallTypeKey, constant.CallServerStream)
+		res := invoker.Invoke(context.Background(), inv)
+		require.NoError(t, res.Error())
+
+		stream := res.Result().(*ServerStreamForClient)
+		var got []int64
+		for stream.Receive(nil) {
+			got = append(got, stream.Msg().(int64))
+		}
+		assert.NoError(t, stream.Err())
+		assert.Equal(t, []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, got)
+	})
+
+	t.Run("bidi stream", func(t *testing.T) {
+		inv := invocation.NewRPCInvocationWithOptions(invocation.WithMethodName("Echo"),
+			invocation.WithArguments([]any{}))
+		inv.SetAttribute(constant.CallTypeKey, constant.CallBidiStream)
+		res := invoker.Invoke(context.Background(), inv)
+		require.NoError(t, res.Error())
+
+		stream := res.Result().(*BidiStreamForClient)
+		for _, msg := range []string{"a", "b", "c", "d", "e"} {
+			require.NoError(t, stream.Send(msg))
+			var reply string
+			require.NoError(t, stream.Receive(&reply))
+			assert.Equal(t, "echo "+msg, reply)
+		}
+		require.NoError(t, stream.CloseRequest())
+		assert.ErrorIs(t, stream.Receive(nil), io.EOF)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		inv := invocation.NewRPCInvocationWithOptions(invocation.WithMethodName("Fail"),
+			invocation.WithArguments([]any{int64(1)}))
+		inv.SetAttribute(constant.CallTypeKey, constant.CallServerStream)
+		res := invoker.Invoke(context.Background(), inv)
+		require.NoError(t, res.Error())
+
+		stream := res.Result().(*ServerStreamForClient)
+		assert.False(t, stream.Receive(nil))
+		assert.ErrorContains(t, stream.Err(), "fail on purpose")
+	})
+
+	t.Run("unary call of stream method", func(t *testing.T) {
+		// the way a dubbo java consumer calls the method
+		var reply any
+		inv := invocation.NewRPCInvocationWithOptions(invocation.WithMethodName("Count"),
+			invocation.WithArguments([]any{int64(1)}), invocation.WithReply(&reply))
+		res := invoker.Invoke(context.Background(), inv)
+		assert.ErrorContains(t, res.Error(), "dubbo-go stream method")
+	})
+}
@@ -122,6 +122,11 @@ func (r *RPCInvocation) Arguments() []any {
 	return r.arguments
 }
 
+// SetArguments sets RPC arguments.
+func (r *RPCInvocation) SetArguments(args []any) {
+	r.arguments = args
+}
+
 // Reply gets response of RPC request.
 func (r *RPCInvocation) Reply() any {
 	return r.reply
@@ -267,6 +267,20 @@ func WithPort(port int) ServerOption {
 	return &portOption{strconv.Itoa(port)}
 }
 
+type unixSocketOption struct {
+	UnixSocket string
+}
+
+func (o *unixSocketOption) applyToServer(config *ServerOptions) {
+	config.Protocol.UnixSocket = o.UnixSocket
+}
+
+// WithUnixSocket specifies the unix domain socket served besides the port, e.g. unix:///var/run/dubbo/tri.sock,
+// which is preferred by the consumers on the same host. The triple protocol and the dubbo protocol support it.
+func WithUnixSocket(socket string) ServerOption {
+	return &unixSocketOption{socket}
+}
+
 type paramsOption struct {
 	Params any
 }
@@ -312,3 +326,67 @@ func (o *maxServerRecvMsgSize) applyToServer(config *ServerOptions) {
 func WithMaxServerRecvMsgSize(size string) ServerOption {
 	return &maxServerRecvMsgSize{size}
 }
+
+type threadPoolOption struct {
+	ThreadPool string
+}
+
+func (o *threadPoolOption) applyToServer(config *ServerOptions) {
+	config.Protocol.ThreadPool = o.ThreadPool
+}
+
+// WithThreadPool specifies the default thread pool of the services exported by the protocol,
+// it's one of fixed, cached and eager.
+func WithThreadPool(threadPool string) ServerOption {
+	return &threadPoolOption{threadPool}
+}
+
+type threadsOption struct {
+	Threads string
+}
+
+func (o *threadsOption) applyToServer(config *ServerOptions) {
+	config.Protocol.Threads = o.Threads
+}
+
+func WithThreads(threads int) ServerOption {
+	return &threadsOption{strconv.Itoa(threads)}
+}
+
+type coreThreadsOption struct {
+	CoreThreads string
+}
+
+func (o *coreThreadsOption) applyToServer(config *ServerOptions) {
+	config.Protocol.CoreThreads = o.CoreThreads
+}
+
+// WithCoreThreads is kept for the configurations of dubbo java, it takes no effect since the thread pools
+// don't own threads.
+func WithCoreThreads(coreThreads int) ServerOption {
+	return &coreThreadsOption{strconv.Itoa(coreThreads)}
+}
+
+type queuesOption struct {
+	Queues string
+}
+
+func (o *queuesOption) applyToServer(config *ServerOptions) {
+	config.Protocol.Queues = o.Queues
+}
+
+func WithQueues(queues int) ServerOption {
+	return &queuesOption{strconv.Itoa(queues)}
+}
+
+type threadPoolRejectedHandlerOption struct {
+	Handler string
+}
+
+func (o *threadPoolRejectedHandlerOption) applyToServer(config *ServerOptions) {
+	config.Protocol.ThreadPoolRejectedHandler = o.Handler
+}
+
+func WithThreadPoolRejectedHandler(handler string) ServerOption {
+	return &threadPoolRejectedHandlerOption{handler}
+}
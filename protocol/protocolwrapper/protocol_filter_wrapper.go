@@ -31,6 +31,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/filter"
+	dubboLogger "dubbo.apache.org/dubbo-go/v3/logger"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
@@ -92,6 +93,8 @@ func BuildInvokerChain(invoker base.Invoker, key string) base.Invoker {
 	}
 	switch key {
 	case constant.ServiceFilterKey:
+		// the head of the provider chain attaches the fields of the invocations to the contexts of the logs
+		next.(*FilterInvoker).logFields = true
 		logger.Debugf("[BuildInvokerChain] The provider invocation link is %s, invoker: %s",
 			strings.Join(append(filterNames, "proxyInvoker"), " -> "), invoker)
 	case constant.ReferenceFilterKey:
@@ -112,6 +115,8 @@ type FilterInvoker struct {
 	next    base.Invoker
 	invoker base.Invoker
 	filter  filter.Filter
+	// logFields is true if the invocations are attached to the contexts by logger.WithFields
+	logFields bool
 }
 
 // GetURL is used to get url from FilterInvoker
@@ -126,11 +131,22 @@ func (fi *FilterInvoker) IsAvailable() bool {
 
 // Invoke is used to call service method by invocation
 func (fi *FilterInvoker) Invoke(ctx context.Context, invocation base.Invocation) result.Result {
+	if fi.logFields {
+		ctx = dubboLogger.WithFields(ctx,
+			dubboLogger.FieldInterface, fi.invoker.GetURL().Service(),
+			dubboLogger.FieldMethod, invocation.MethodName(),
+			dubboLogger.FieldRemoteAddr, invocation.GetAttachmentWithDefaultValue(constant.RemoteAddr, ""))
+	}
 	result := fi.filter.Invoke(ctx, fi.next, invocation)
 	return fi.filter.OnResponse(ctx, result, fi.invoker, invocation)
 }
 
-// Destroy will destroy invoker
+// Destroy will destroy invoker, the filters of the chain implementing filter.InvokerDestroyListener are notified
 func (fi *FilterInvoker) Destroy() {
+	for next := fi; next != nil; next, _ = next.next.(*FilterInvoker) {
+		if listener, ok := next.filter.(filter.InvokerDestroyListener); ok {
+			listener.OnInvokerDestroyed(fi.invoker)
+		}
+	}
 	fi.invoker.Destroy()
 }
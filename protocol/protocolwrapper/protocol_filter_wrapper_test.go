@@ -34,11 +34,17 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
 	"dubbo.apache.org/dubbo-go/v3/filter"
+	dubboLogger "dubbo.apache.org/dubbo-go/v3/logger"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
 
-const mockFilterKey = "mockEcho"
+const (
+	mockFilterKey    = "mockEcho"
+	fieldsFilterKey  = "mockFields"
+	destroyFilterKey = "mockDestroy"
+)
 
 func TestProtocolFilterWrapperExport(t *testing.T) {
 	filtProto := extension.GetProtocol(FILTER)
@@ -64,9 +70,71 @@ func TestProtocolFilterWrapperRefer(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestProviderChainLogFields(t *testing.T) {
+	u := common.NewURLWithOptions(
+		common.WithParams(url.Values{}),
+		common.WithInterface("org.apache.dubbo.Greeter"),
+		common.WithParamsValue(constant.ServiceFilterKey, fieldsFilterKey+","+fieldsFilterKey))
+	invoker := BuildInvokerChain(base.NewBaseInvoker(u), constant.ServiceFilterKey)
+	inv := invocation.NewRPCInvocation("Greet", nil, map[string]any{constant.RemoteAddr: "127.0.0.1:20000"})
+	res := invoker.Invoke(context.Background(), inv)
+	assert.Equal(t, []any{
+		dubboLogger.FieldInterface, "org.apache.dubbo.Greeter",
+		dubboLogger.FieldMethod, "Greet",
+		dubboLogger.FieldRemoteAddr, "127.0.0.1:20000",
+	}, res.Result())
+
+	// the consumer chain doesn't attach the fields
+	u.SetParam(constant.ReferenceFilterKey, fieldsFilterKey)
+	invoker = BuildInvokerChain(base.NewBaseInvoker(u), constant.ReferenceFilterKey)
+	assert.Empty(t, invoker.Invoke(context.Background(), inv).Result())
+}
+
+func TestFilterInvokerDestroy(t *testing.T) {
+	u := common.NewURLWithOptions(
+		common.WithParams(url.Values{}),
+		common.WithParamsValue(constant.ServiceFilterKey, mockFilterKey+","+destroyFilterKey))
+	origin := base.NewBaseInvoker(u)
+	invoker := BuildInvokerChain(origin, constant.ServiceFilterKey)
+
+	// the filters in the chain are notified with the origin invoker
+	destroyed = nil
+	invoker.Destroy()
+	assert.Equal(t, []base.Invoker{origin}, destroyed)
+	assert.True(t, origin.IsDestroyed())
+}
+
 // The initialization of mockEchoFilter, for test
 func init() {
 	extension.SetFilter(mockFilterKey, newFilter)
+	extension.SetFilter(fieldsFilterKey, func() filter.Filter {
+		return &mockFieldsFilter{}
+	})
+	extension.SetFilter(destroyFilterKey, func() filter.Filter {
+		return &mockDestroyFilter{}
+	})
+}
+
+// destroyed are the invokers mockDestroyFilter is notified with
+var destroyed []base.Invoker
+
+type mockDestroyFilter struct {
+	mockFieldsFilter
+}
+
+func (f *mockDestroyFilter) OnInvokerDestroyed(invoker base.Invoker) {
+	destroyed = append(destroyed, invoker)
+}
+
+// mockFieldsFilter returns the log fields of the context
+type mockFieldsFilter struct{}
+
+func (f *mockFieldsFilter) Invoke(ctx context.Context, invoker base.Invoker, invocation base.Invocation) result.Result {
+	return &result.RPCResult{Rest: dubboLogger.Fields(ctx)}
+}
+
+func (f *mockFieldsFilter) OnResponse(ctx context.Context, result result.Result, invoker base.Invoker, invocation base.Invocation) result.Result {
+	return result
 }
 
 type mockEchoFilter struct{}
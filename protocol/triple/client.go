@@ -60,6 +60,8 @@ type clientManager struct {
 	isIDL bool
 	// triple_protocol clients, key is method name
 	triClients map[string]*tri.Client
+	// pool is the connection pool of the clients over HTTP/2, it's nil if the pool isn't configured
+	pool *connPool
 }
 
 // TODO: code a triple client between clientManager and triple_protocol client
@@ -124,8 +126,9 @@ func (cm *clientManager) callBidiStream(ctx context.Context, method string) (any
 }
 
 func (cm *clientManager) close() error {
-	// There is no need to release resources right now.
-	// But we leave this function here for future use.
+	if cm.pool != nil {
+		cm.pool.close()
+	}
 	return nil
 }
 
@@ -159,7 +162,8 @@ func newClientManager(url *common.URL) (*clientManager, error) {
 	version := url.GetParam(constant.VersionKey, "")
 	cliOpts = append(cliOpts, tri.WithGroup(group), tri.WithVersion(version))
 
-	// todo(DMwangnima): support opentracing
+	// record the messages of the streams on the spans of the tracing filters
+	cliOpts = append(cliOpts, tri.WithInterceptors(&streamTracingInterceptor{}))
 
 	// handle tls
 	var (
@@ -203,7 +207,10 @@ func newClientManager(url *common.URL) (*clientManager, error) {
 	cliOpts = append(cliOpts, cliKeepAliveOpts...)
 
 	// handle http transport of triple protocol
-	var transport http.RoundTripper
+	var (
+		transport http.RoundTripper
+		pool      *connPool
+	)
 
 	var callProtocol string
 	if tripleConf != nil && tripleConf.Http3 != nil && tripleConf.Http3.Enable {
@@ -224,22 +231,33 @@ func newClientManager(url *common.URL) (*clientManager, error) {
 		cliOpts = append(cliOpts, tri.WithTriple())
 	case constant.CallHTTP2:
 		// TODO: Enrich the http2 transport config for triple protocol.
+		var h2Transport *http2.Transport
+		dial := newDialContext(url)
 		if tlsFlag {
-			transport = &http2.Transport{
+			h2Transport = &http2.Transport{
+				DialTLSContext: func(ctx context.Context, _, addr string, tlsConf *tls.Config) (net.Conn, error) {
+					return dialTLS(ctx, dial, addr, tlsConf)
+				},
 				TLSClientConfig: cfg,
 				ReadIdleTimeout: keepAliveInterval,
 				PingTimeout:     keepAliveTimeout,
 			}
 		} else {
-			transport = &http2.Transport{
-				DialTLSContext: func(_ context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
-					return net.Dial(network, addr)
+			h2Transport = &http2.Transport{
+				DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+					return dial(ctx, network, addr)
 				},
 				AllowHTTP:       true,
 				ReadIdleTimeout: keepAliveInterval,
 				PingTimeout:     keepAliveTimeout,
 			}
 		}
+		if tripleConf != nil && tripleConf.ConnPool != nil {
+			if pool, err = setupConnPool(url, h2Transport, cfg, tripleConf.ConnPool); err != nil {
+				return nil, err
+			}
+		}
+		transport = h2Transport
 	case constant.CallHTTP3:
 		if !tlsFlag {
 			return nil, fmt.Errorf("TRIPLE http3 client must have TLS config, but TLS config is nil")
@@ -282,6 +300,10 @@ func newClientManager(url *common.URL) (*clientManager, error) {
 		baseTriURL = httpPrefix + baseTriURL
 	}
 
+	if pool != nil {
+		pool.warmup(url.Location, tripleConf.ConnPool.Warmup)
+	}
+
 	triClients := make(map[string]*tri.Client)
 
 	if len(url.Methods) != 0 {
@@ -317,9 +339,45 @@ func newClientManager(url *common.URL) (*clientManager, error) {
 	return &clientManager{
 		isIDL:      isIDL,
 		triClients: triClients,
+		pool:       pool,
 	}, nil
 }
 
+// setupConnPool sets the connection pool of the config to the transport, the idle connections are closed
+// after the idle timeout
+func setupConnPool(url *common.URL, transport *http2.Transport, tlsConf *tls.Config, conf *global.TripleConnPoolConfig) (*connPool, error) {
+	if conf.IdleTimeout != "" {
+		idleTimeout, err := time.ParseDuration(conf.IdleTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid idle timeout of the triple connection pool %q: %w", conf.IdleTimeout, err)
+		}
+		transport.IdleConnTimeout = idleTimeout
+	}
+	return newConnPool(url, transport, tlsConf, conf), nil
+}
+
+// dialContextFunc dials the address of the network
+type dialContextFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// newDialContext returns the dial of the tcp address, or the one of the unix socket of the provider if the consumer
+// is on the same host as the provider. The tcp address is dialed if nobody listens on the socket, e.g. the socket
+// is left by the provider exited abnormally.
+func newDialContext(url *common.URL) dialContextFunc {
+	dialer := &net.Dialer{}
+	if path := common.UnixSocketOf(url); path != "" {
+		logger.Infof("TRIPLE clientManager dials the provider %s over the unix socket %s", url.Location, path)
+		return func(ctx context.Context, network, addr string) (net.Conn, error) {
+			conn, err := dialer.DialContext(ctx, "unix", path)
+			if err == nil {
+				return conn, nil
+			}
+			logger.Warnf("TRIPLE clientManager dials the unix socket %s failed, fall back to %s, err: %v", path, addr, err)
+			return dialer.DialContext(ctx, network, addr)
+		}
+	}
+	return dialer.DialContext
+}
+
 func genKeepAliveOptions(url *common.URL, tripleConf *global.TripleConfig) ([]tri.ClientOption, time.Duration, time.Duration, error) {
 	var cliKeepAliveOpts []tri.ClientOption
 
@@ -18,13 +18,23 @@
 package triple
 
 import (
+	"context"
+	"crypto/tls"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
 
 import (
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 )
 
 import (
@@ -94,3 +104,76 @@ func TestDualTransport(t *testing.T) {
 	})
 	assert.True(t, ok, "transport should implement http.RoundTripper")
 }
+
+func TestClientManager_ConnPool(t *testing.T) {
+	url := &common.URL{
+		Location: "localhost:20000",
+		Path:     "com.example.TestService",
+		Methods:  []string{"testMethod"},
+	}
+	url.SetAttribute(constant.TripleConfigKey, &global.TripleConfig{
+		ConnPool: &global.TripleConnPoolConfig{MaxConnections: 4, IdleTimeout: "5m"},
+	})
+
+	clientManager, err := newClientManager(url)
+	assert.NoError(t, err)
+	assert.NotNil(t, clientManager.pool)
+	assert.Equal(t, 4, clientManager.pool.maxConns)
+	assert.Equal(t, 5*time.Minute, clientManager.pool.transport.IdleConnTimeout)
+	assert.NoError(t, clientManager.close())
+
+	url.SetAttribute(constant.TripleConfigKey, &global.TripleConfig{
+		ConnPool: &global.TripleConnPoolConfig{IdleTimeout: "5"},
+	})
+	_, err = newClientManager(url)
+	assert.Error(t, err)
+}
+
+func TestClientManager_UnixSocket(t *testing.T) {
+	t.Setenv(constant.HostIdEnvKey, "node-1")
+	path := filepath.Join(t.TempDir(), "tri.sock")
+	lis, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	server := httptest.NewUnstartedServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}), &http2.Server{}))
+	server.Listener = lis
+	server.Start()
+	defer server.Close()
+
+	// nothing listens on the tcp address, the consumers on the same host dial the unix socket instead
+	url, _ := common.NewURL("tri://127.0.0.1:1/com.example.TestService?unix-socket=unix://" + path + "&host-id=node-1")
+	assert.Equal(t, path, common.UnixSocketOf(url))
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return newDialContext(url)(ctx, network, addr)
+		},
+	}}
+	resp, err := client.Get("http://127.0.0.1:1/")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, 2, resp.ProtoMajor)
+
+	// the unix socket of the provider on another host is ignored
+	url.SetParam(constant.HostIdKey, "node-2")
+	assert.Empty(t, common.UnixSocketOf(url))
+	url.SetParam(constant.HostIdKey, "node-1")
+	url.SetParam(constant.UnixSocketKey, "unix://"+filepath.Join(t.TempDir(), "absent.sock"))
+	assert.Empty(t, common.UnixSocketOf(url))
+
+	// the tcp address is dialed if nobody listens on the socket left by the provider
+	tcpServer := httptest.NewUnstartedServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}), &http2.Server{}))
+	tcpServer.Start()
+	defer tcpServer.Close()
+	stale := filepath.Join(t.TempDir(), "stale.sock")
+	require.NoError(t, os.WriteFile(stale, nil, 0o600))
+	url.SetParam(constant.UnixSocketKey, "unix://"+stale)
+	assert.Equal(t, stale, common.UnixSocketOf(url))
+	resp, err = client.Get(tcpServer.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, 2, resp.ProtoMajor)
+}
@@ -0,0 +1,324 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package triple
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	"golang.org/x/net/http2"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/global"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsRpc "dubbo.apache.org/dubbo-go/v3/metrics/rpc"
+)
+
+const (
+	// connDialTimeout is the timeout of opening a connection
+	connDialTimeout = 3 * time.Second
+	// connShutdownTimeout is how long the in-flight requests are waited for when the pool is closed
+	connShutdownTimeout = 3 * time.Second
+)
+
+// connPool is the http2.ClientConnPool opening multiple connections to an address. A request takes the connection
+// with the fewest streams, and a new connection is opened once the streams of all the connections reach the limit,
+// until there are maxConns connections.
+type connPool struct {
+	transport *http2.Transport
+	dial      func(ctx context.Context, addr string) (net.Conn, error)
+	// url is the url of the invoker, which labels the metrics
+	url        *common.URL
+	maxConns   int
+	maxStreams int
+
+	mu    sync.Mutex
+	conns map[string][]*http2.ClientConn
+	// dials are the connections being opened
+	dials  map[string][]*dialCall
+	closed bool
+}
+
+// dialCall is a connection being opened
+type dialCall struct {
+	done chan struct{}
+	cc   *http2.ClientConn
+	err  error
+}
+
+// newConnPool returns the pool of the transport, the connections are opened over TLS if tlsConf isn't nil
+func newConnPool(url *common.URL, transport *http2.Transport, tlsConf *tls.Config, conf *global.TripleConnPoolConfig) *connPool {
+	p := &connPool{
+		transport:  transport,
+		url:        url,
+		maxConns:   conf.MaxConnections,
+		maxStreams: conf.MaxConcurrentStreams,
+		conns:      make(map[string][]*http2.ClientConn),
+		dials:      make(map[string][]*dialCall),
+	}
+	if p.maxConns <= 0 {
+		p.maxConns = 1
+	}
+	dial := newDialContext(url)
+	if tlsConf == nil {
+		p.dial = func(ctx context.Context, addr string) (net.Conn, error) {
+			return dial(ctx, "tcp", addr)
+		}
+	} else {
+		p.dial = func(ctx context.Context, addr string) (net.Conn, error) {
+			return dialTLS(ctx, dial, addr, tlsConf)
+		}
+	}
+	// the requests over the connections reaching the limit of the server wait for the streams, instead of
+	// failing to be reserved
+	transport.StrictMaxConcurrentStreams = true
+	transport.ConnPool = p
+	return p
+}
+
+// dialTLS opens the TLS connection negotiating HTTP/2 over the connection of the dial
+func dialTLS(ctx context.Context, dial dialContextFunc, addr string, tlsConf *tls.Config) (net.Conn, error) {
+	cfg := tlsConf.Clone()
+	if cfg.ServerName == "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ServerName = host
+	}
+	cfg.NextProtos = []string{http2.NextProtoTLS}
+	rawConn, err := dial(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	conn := tls.Client(rawConn, cfg)
+	if err = conn.HandshakeContext(ctx); err != nil {
+		_ = rawConn.Close()
+		return nil, err
+	}
+	if proto := conn.ConnectionState().NegotiatedProtocol; proto != http2.NextProtoTLS {
+		_ = conn.Close()
+		return nil, fmt.Errorf("http2: unexpected ALPN protocol %q; want %q", proto, http2.NextProtoTLS)
+	}
+	return conn, nil
+}
+
+// GetClientConn returns the connection with the fewest streams, whose stream is reserved for the request.
+// A new connection is opened if all the connections reach the limit of the streams, and the busy connections are
+// shared once the connections reach the max.
+func (p *connPool) GetClientConn(req *http.Request, addr string) (*http2.ClientConn, error) {
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			return nil, http2.ErrNoCachedConn
+		}
+		p.pruneLocked(addr)
+		cc, streams, limit := p.leastBusyLocked(addr)
+		dials := p.dials[addr]
+		full := len(p.conns[addr])+len(dials) >= p.maxConns
+
+		var call *dialCall
+		switch {
+		case cc != nil && (streams < limit || full && len(dials) == 0):
+			if !cc.ReserveNewRequest() {
+				// the connection can't take new requests any more
+				p.removeLocked(addr, cc)
+				p.mu.Unlock()
+				continue
+			}
+			p.mu.Unlock()
+			if streams >= limit {
+				metrics.Publish(metricsRpc.NewConnPoolExhaustedEvent(p.url, addr))
+			}
+			return cc, nil
+		case !full:
+			call = p.startDialLocked(addr)
+		default:
+			// wait for the connection being opened
+			call = dials[0]
+		}
+		p.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		if call.err != nil {
+			return nil, call.err
+		}
+	}
+}
+
+// MarkDead removes the connection from the pool, it's called by the transport when the connection is closed
+func (p *connPool) MarkDead(cc *http2.ClientConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for addr := range p.conns {
+		p.removeLocked(addr, cc)
+	}
+}
+
+// leastBusyLocked returns the connection with the fewest streams, its streams and its limit of the streams
+func (p *connPool) leastBusyLocked(addr string) (*http2.ClientConn, int, int) {
+	var (
+		best               *http2.ClientConn
+		bestStreams, limit int
+	)
+	for _, cc := range p.conns[addr] {
+		state := cc.State()
+		streams := state.StreamsActive + state.StreamsReserved + state.StreamsPending
+		if best == nil || streams < bestStreams {
+			best, bestStreams, limit = cc, streams, p.streamLimit(state.MaxConcurrentStreams)
+		}
+	}
+	return best, bestStreams, limit
+}
+
+// streamLimit returns the limit of the streams of a connection, which is the smaller one of the configured one and
+// the one advertised by the server
+func (p *connPool) streamLimit(advertised uint32) int {
+	limit := math.MaxInt
+	if advertised > 0 {
+		limit = int(advertised)
+	}
+	if p.maxStreams > 0 && p.maxStreams < limit {
+		limit = p.maxStreams
+	}
+	return limit
+}
+
+// startDialLocked opens a new connection in the background, the connection is shared by the requests,
+// so it isn't canceled with the request starting it
+func (p *connPool) startDialLocked(addr string) *dialCall {
+	call := &dialCall{done: make(chan struct{})}
+	p.dials[addr] = append(p.dials[addr], call)
+	go func() {
+		defer close(call.done)
+		ctx, cancel := context.WithTimeout(context.Background(), connDialTimeout)
+		conn, err := p.dial(ctx, addr)
+		cancel()
+		if err == nil {
+			call.cc, err = p.transport.NewClientConn(conn)
+			if err != nil {
+				_ = conn.Close()
+			}
+		}
+		call.err = err
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		dials := p.dials[addr]
+		for i, c := range dials {
+			if c == call {
+				p.dials[addr] = append(dials[:i:i], dials[i+1:]...)
+				break
+			}
+		}
+		if err != nil {
+			logger.Warnf("[Triple Conn Pool] failed to open the connection to %s: %v", addr, err)
+			return
+		}
+		if p.closed {
+			_ = call.cc.Close()
+			return
+		}
+		p.conns[addr] = append(p.conns[addr], call.cc)
+		metrics.Publish(metricsRpc.NewConnectionOpenedEvent(p.url, addr))
+	}()
+	return call
+}
+
+// pruneLocked removes the connections which are closed or closing, e.g. the ones received GOAWAY,
+// the in-flight requests of the closing ones are still served
+func (p *connPool) pruneLocked(addr string) {
+	for _, cc := range p.conns[addr] {
+		if state := cc.State(); state.Closed || state.Closing {
+			p.removeLocked(addr, cc)
+		}
+	}
+}
+
+func (p *connPool) removeLocked(addr string, cc *http2.ClientConn) {
+	conns := p.conns[addr]
+	for i, c := range conns {
+		if c == cc {
+			p.conns[addr] = append(conns[:i:i], conns[i+1:]...)
+			metrics.Publish(metricsRpc.NewConnectionClosedEvent(p.url, addr))
+			return
+		}
+	}
+}
+
+// warmup opens n connections to the address in the background, n is at most the max connections
+func (p *connPool) warmup(addr string, n int) {
+	if n > p.maxConns {
+		n = p.maxConns
+	}
+	if n <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := len(p.conns[addr]) + len(p.dials[addr]); i < n; i++ {
+		p.startDialLocked(addr)
+	}
+}
+
+// size returns the number of the connections to the address
+func (p *connPool) size(addr string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.conns[addr])
+}
+
+// close closes the connections after their in-flight requests are done
+func (p *connPool) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	p.closed = true
+	for addr, conns := range p.conns {
+		for _, cc := range conns {
+			metrics.Publish(metricsRpc.NewConnectionClosedEvent(p.url, addr))
+			go func(cc *http2.ClientConn) {
+				ctx, cancel := context.WithTimeout(context.Background(), connShutdownTimeout)
+				defer cancel()
+				if err := cc.Shutdown(ctx); err != nil {
+					_ = cc.Close()
+				}
+			}(cc)
+		}
+	}
+	p.conns = make(map[string][]*http2.ClientConn)
+}
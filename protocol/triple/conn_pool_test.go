@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package triple
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/global"
+)
+
+func newH2CServer(handler http.HandlerFunc) *httptest.Server {
+	return httptest.NewServer(h2c.NewHandler(handler, &http2.Server{}))
+}
+
+func newTestConnPool(conf *global.TripleConnPoolConfig) (*connPool, *http.Client) {
+	transport := &http2.Transport{AllowHTTP: true}
+	url, _ := common.NewURL("tri://127.0.0.1:20000/org.apache.dubbo.Greeter")
+	pool := newConnPool(url, transport, nil, conf)
+	return pool, &http.Client{Transport: transport}
+}
+
+func TestConnPool(t *testing.T) {
+	release := make(chan struct{})
+	var arrived sync.WaitGroup
+	server := newH2CServer(func(w http.ResponseWriter, r *http.Request) {
+		arrived.Done()
+		<-release
+	})
+	defer server.Close()
+	addr := strings.TrimPrefix(server.URL, "http://")
+
+	pool, client := newTestConnPool(&global.TripleConnPoolConfig{MaxConnections: 2, MaxConcurrentStreams: 1})
+	defer pool.close()
+
+	var done sync.WaitGroup
+	get := func() {
+		arrived.Add(1)
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			resp, err := client.Get(server.URL)
+			if assert.NoError(t, err) {
+				_ = resp.Body.Close()
+			}
+		}()
+		arrived.Wait()
+	}
+
+	// a new connection is opened once the streams of the connection reach the limit
+	get()
+	assert.Equal(t, 1, pool.size(addr))
+	get()
+	assert.Equal(t, 2, pool.size(addr))
+	// the busy connections are shared once the connections reach the max
+	get()
+	assert.Equal(t, 2, pool.size(addr))
+
+	close(release)
+	done.Wait()
+}
+
+func TestConnPoolWarmup(t *testing.T) {
+	server := newH2CServer(func(w http.ResponseWriter, r *http.Request) {})
+	defer server.Close()
+	addr := strings.TrimPrefix(server.URL, "http://")
+
+	pool, client := newTestConnPool(&global.TripleConnPoolConfig{MaxConnections: 3})
+	pool.warmup(addr, 5)
+	require.Eventually(t, func() bool {
+		return pool.size(addr) == 3
+	}, 3*time.Second, 10*time.Millisecond)
+
+	// the requests take the warmed connections
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, 3, pool.size(addr))
+
+	pool.close()
+	assert.Equal(t, 0, pool.size(addr))
+	_, err = client.Get(server.URL)
+	assert.Error(t, err)
+}
@@ -0,0 +1,302 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package generic provides a triple client calling IDL services without compiled stubs. The descriptors
+// of methods are resolved by server reflection or supplied descriptor sets, and messages are built as
+// dynamic protobuf messages from JSON or maps, so gateways can call any triple service dynamically.
+package generic
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+import (
+	"golang.org/x/net/http2"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"google.golang.org/protobuf/proto"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+import (
+	rpb "dubbo.apache.org/dubbo-go/v3/protocol/triple/reflection/triple_reflection"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+// Client calls the methods of triple IDL services by their names. Requests can be JSON in the form
+// of string, []byte or json.RawMessage, maps or structs which are marshaled into JSON first, or proto
+// messages. Responses are returned as maps decoded from the protobuf JSON of messages.
+type Client struct {
+	baseURL    string
+	httpClient tri.HTTPClient
+	cliOpts    []tri.ClientOption
+	source     DescriptorSource
+
+	mu sync.Mutex
+	// triple_protocol clients, key is procedure
+	triClients map[string]*tri.Client
+}
+
+// NewClient creates a generic client calling the triple server at address, e.g. 127.0.0.1:20000.
+func NewClient(address string, opts ...Option) (*Client, error) {
+	options := NewOptions(opts...)
+	if options.err != nil {
+		return nil, options.err
+	}
+
+	baseURL := address
+	if !strings.Contains(address, "://") {
+		if options.TLSConfig != nil {
+			baseURL = "https://" + address
+		} else {
+			baseURL = "http://" + address
+		}
+	}
+	if _, err := url.Parse(baseURL); err != nil {
+		return nil, fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	httpClient := options.HTTPClient
+	if httpClient == nil {
+		httpClient = newHTTPClient(options.TLSConfig)
+	}
+
+	cliOpts := []tri.ClientOption{
+		tri.WithTimeout(options.Timeout),
+		tri.WithGroup(options.Group),
+		tri.WithVersion(options.Version),
+	}
+	if options.ProtoJSON {
+		cliOpts = append(cliOpts, tri.WithProtoJSON())
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpClient,
+		cliOpts:    cliOpts,
+		source:     options.Source,
+		triClients: make(map[string]*tri.Client),
+	}
+	if c.source == nil {
+		// the reflection service is not grouped or versioned
+		reflectionClient := tri.NewClient(httpClient, c.baseURL+rpb.ServerReflectionServerReflectionInfoProcedure)
+		c.source = newReflectionSource(reflectionClient)
+	}
+	return c, nil
+}
+
+func newHTTPClient(cfg *tls.Config) *http.Client {
+	if cfg != nil {
+		return &http.Client{Transport: &http2.Transport{TLSClientConfig: cfg}}
+	}
+	return &http.Client{Transport: &http2.Transport{
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		},
+		AllowHTTP: true,
+	}}
+}
+
+// CallUnary calls the unary method of service, service is the fully-qualified name, e.g. greet.GreetService.
+func (c *Client) CallUnary(ctx context.Context, service, method string, req any) (map[string]any, error) {
+	md, triClient, err := c.resolve(ctx, service, method, tri.StreamTypeUnary)
+	if err != nil {
+		return nil, err
+	}
+	reqMsg, err := c.newMessage(md.Input(), req)
+	if err != nil {
+		return nil, err
+	}
+	respMsg := dynamicpb.NewMessage(md.Output())
+	if err = triClient.CallUnary(ctx, tri.NewRequest(reqMsg), tri.NewResponse(respMsg)); err != nil {
+		return nil, err
+	}
+	return c.toMap(respMsg)
+}
+
+// CallClientStream opens a stream to the client streaming method of service.
+func (c *Client) CallClientStream(ctx context.Context, service, method string) (*ClientStream, error) {
+	md, triClient, err := c.resolve(ctx, service, method, tri.StreamTypeClient)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := triClient.CallClientStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientStream{client: c, method: md, stream: stream}, nil
+}
+
+// CallServerStream calls the server streaming method of service with req.
+func (c *Client) CallServerStream(ctx context.Context, service, method string, req any) (*ServerStream, error) {
+	md, triClient, err := c.resolve(ctx, service, method, tri.StreamTypeServer)
+	if err != nil {
+		return nil, err
+	}
+	reqMsg, err := c.newMessage(md.Input(), req)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := triClient.CallServerStream(ctx, tri.NewRequest(reqMsg))
+	if err != nil {
+		return nil, err
+	}
+	return &ServerStream{client: c, method: md, stream: stream}, nil
+}
+
+// CallBidiStream opens a stream to the bidirectional streaming method of service.
+func (c *Client) CallBidiStream(ctx context.Context, service, method string) (*BidiStream, error) {
+	md, triClient, err := c.resolve(ctx, service, method, tri.StreamTypeBidi)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := triClient.CallBidiStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &BidiStream{client: c, method: md, stream: stream}, nil
+}
+
+// DescriptorSource returns the source resolving the descriptors of the services, which is the server
+// reflection service of the target if WithDescriptorSource isn't given.
+func (c *Client) DescriptorSource() DescriptorSource {
+	return c.source
+}
+
+// MethodDescriptor returns the descriptor of the method of service.
+func (c *Client) MethodDescriptor(ctx context.Context, service, method string) (protoreflect.MethodDescriptor, error) {
+	sd, err := c.source.FindService(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, fmt.Errorf("method %s is not found in service %s", method, service)
+	}
+	return md, nil
+}
+
+// resolve finds the method descriptor and checks whether it is called in the right way
+func (c *Client) resolve(ctx context.Context, service, method string, streamType tri.StreamType) (protoreflect.MethodDescriptor, *tri.Client, error) {
+	md, err := c.MethodDescriptor(ctx, service, method)
+	if err != nil {
+		return nil, nil, err
+	}
+	if actual := methodStreamType(md); actual != streamType {
+		return nil, nil, fmt.Errorf("method %s of service %s is a %s method, but called as a %s method",
+			method, service, streamTypeName(actual), streamTypeName(streamType))
+	}
+
+	procedure := "/" + service + "/" + method
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	triClient, ok := c.triClients[procedure]
+	if !ok {
+		triClient = tri.NewClient(c.httpClient, c.baseURL+procedure, c.cliOpts...)
+		c.triClients[procedure] = triClient
+	}
+	return md, triClient, nil
+}
+
+func methodStreamType(md protoreflect.MethodDescriptor) tri.StreamType {
+	streamType := tri.StreamTypeUnary
+	if md.IsStreamingClient() {
+		streamType |= tri.StreamTypeClient
+	}
+	if md.IsStreamingServer() {
+		streamType |= tri.StreamTypeServer
+	}
+	return streamType
+}
+
+func streamTypeName(streamType tri.StreamType) string {
+	switch streamType {
+	case tri.StreamTypeClient:
+		return "client streaming"
+	case tri.StreamTypeServer:
+		return "server streaming"
+	case tri.StreamTypeBidi:
+		return "bidirectional streaming"
+	default:
+		return "unary"
+	}
+}
+
+// newMessage builds the dynamic message of md from arg
+func (c *Client) newMessage(md protoreflect.MessageDescriptor, arg any) (*dynamicpb.Message, error) {
+	msg := dynamicpb.NewMessage(md)
+	var data []byte
+	switch v := arg.(type) {
+	case nil:
+		return msg, nil
+	case proto.Message:
+		if v.ProtoReflect().Descriptor().FullName() != md.FullName() {
+			return nil, fmt.Errorf("message %s is expected, but got %s", md.FullName(), v.ProtoReflect().Descriptor().FullName())
+		}
+		raw, err := proto.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		if err = proto.Unmarshal(raw, msg); err != nil {
+			return nil, err
+		}
+		return msg, nil
+	case json.RawMessage:
+		data = v
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return nil, fmt.Errorf("marshal %T into JSON failed: %w", arg, err)
+		}
+	}
+	opts := protojson.UnmarshalOptions{Resolver: c.source}
+	if err := opts.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("convert JSON into %s failed: %w", md.FullName(), err)
+	}
+	return msg, nil
+}
+
+// toMap converts msg into a map by its protobuf JSON
+func (c *Client) toMap(msg *dynamicpb.Message) (map[string]any, error) {
+	opts := protojson.MarshalOptions{Resolver: c.source}
+	data, err := opts.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]any)
+	if err = json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,179 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generic
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/health/triple_health"
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/reflection"
+	rpb "dubbo.apache.org/dubbo-go/v3/protocol/triple/reflection/triple_reflection"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+// the names of services in the descriptors, which are different from the names in procedures
+var (
+	healthService     = string(triple_health.File_health_proto.Services().Get(0).FullName())
+	reflectionService = string(rpb.File_reflection_proto.Services().Get(0).FullName())
+)
+
+func startServer(t *testing.T) string {
+	checkProcedure := "/" + healthService + "/Check"
+	watchProcedure := "/" + healthService + "/Watch"
+	reflectionProcedure := "/" + reflectionService + "/ServerReflectionInfo"
+	reflectionHandler := func(ctx context.Context, stream *tri.BidiStream) error {
+		return reflection.NewServer().ServerReflectionInfo(ctx, &rpb.ServerReflectionServerReflectionInfoServer{BidiStream: stream})
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(checkProcedure, tri.NewUnaryHandler(checkProcedure,
+		func() any { return new(triple_health.HealthCheckRequest) },
+		func(ctx context.Context, req *tri.Request) (*tri.Response, error) {
+			msg := req.Msg.(*triple_health.HealthCheckRequest)
+			if msg.Service == "unknown" {
+				return nil, tri.NewError(tri.CodeNotFound, errors.New("unknown service"))
+			}
+			return tri.NewResponse(&triple_health.HealthCheckResponse{Status: triple_health.HealthCheckResponse_SERVING}), nil
+		}))
+	mux.Handle(watchProcedure, tri.NewServerStreamHandler(watchProcedure,
+		func() any { return new(triple_health.HealthCheckRequest) },
+		func(ctx context.Context, req *tri.Request, stream *tri.ServerStream) error {
+			for _, status := range []triple_health.HealthCheckResponse_ServingStatus{
+				triple_health.HealthCheckResponse_SERVING, triple_health.HealthCheckResponse_NOT_SERVING,
+			} {
+				if err := stream.Send(&triple_health.HealthCheckResponse{Status: status}); err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+	mux.Handle(rpb.ServerReflectionServerReflectionInfoProcedure,
+		tri.NewBidiStreamHandler(rpb.ServerReflectionServerReflectionInfoProcedure, reflectionHandler))
+	mux.Handle(reflectionProcedure, tri.NewBidiStreamHandler(reflectionProcedure, reflectionHandler))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := &http.Server{Handler: h2c.NewHandler(mux, &http2.Server{})}
+	go func() {
+		_ = srv.Serve(listener)
+	}()
+	t.Cleanup(func() {
+		_ = srv.Close()
+	})
+	return listener.Addr().String()
+}
+
+func TestClientWithReflection(t *testing.T) {
+	cli, err := NewClient(startServer(t))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("unary", func(t *testing.T) {
+		res, err := cli.CallUnary(ctx, healthService, "Check", `{"service": "greet"}`)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"status": "SERVING"}, res)
+
+		res, err = cli.CallUnary(ctx, healthService, "Check", map[string]any{"service": "greet"})
+		require.NoError(t, err)
+		assert.Equal(t, "SERVING", res["status"])
+
+		_, err = cli.CallUnary(ctx, healthService, "Check", map[string]any{"service": "unknown"})
+		assert.Equal(t, tri.CodeNotFound, tri.CodeOf(err))
+	})
+
+	t.Run("server stream", func(t *testing.T) {
+		stream, err := cli.CallServerStream(ctx, healthService, "Watch", nil)
+		require.NoError(t, err)
+		var statuses []any
+		for stream.Receive() {
+			statuses = append(statuses, stream.Msg()["status"])
+		}
+		assert.NoError(t, stream.Err())
+		assert.Equal(t, []any{"SERVING", "NOT_SERVING"}, statuses)
+	})
+
+	t.Run("bidi stream", func(t *testing.T) {
+		stream, err := cli.CallBidiStream(ctx, reflectionService, "ServerReflectionInfo")
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(map[string]any{"fileContainingSymbol": healthService}))
+		require.NoError(t, stream.CloseRequest())
+		res, err := stream.Receive()
+		require.NoError(t, err)
+		assert.Contains(t, res, "fileDescriptorResponse")
+		_, err = stream.Receive()
+		assert.ErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("mismatched stream type", func(t *testing.T) {
+		_, err := cli.CallUnary(ctx, healthService, "Watch", nil)
+		assert.ErrorContains(t, err, "server streaming method")
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		_, err := cli.CallUnary(ctx, healthService, "Unknown", nil)
+		assert.Error(t, err)
+		_, err = cli.CallUnary(ctx, "dubbo.health.v1.Unknown", "Check", nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestClientWithDescriptorSet(t *testing.T) {
+	set := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(triple_health.File_health_proto)},
+	}
+	source, err := NewFileDescriptorSetSource(set)
+	require.NoError(t, err)
+	cli, err := NewClient(startServer(t), WithDescriptorSource(source))
+	require.NoError(t, err)
+
+	res, err := cli.CallUnary(context.Background(), healthService, "Check",
+		&triple_health.HealthCheckRequest{Service: "greet"})
+	require.NoError(t, err)
+	assert.Equal(t, "SERVING", res["status"])
+
+	_, err = cli.CallUnary(context.Background(), healthService, "Check", &triple_health.HealthCheckResponse{})
+	assert.ErrorContains(t, err, "HealthCheckRequest is expected")
+}
+
+func TestClientWithInvalidDescriptorSet(t *testing.T) {
+	set := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{{Name: proto.String("a.proto"), Dependency: []string{"missing.proto"}}},
+	}
+	_, err := NewClient("127.0.0.1:20000", WithFileDescriptorSet(set))
+	assert.ErrorContains(t, err, "invalid file descriptor set")
+}
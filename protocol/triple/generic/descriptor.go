@@ -0,0 +1,236 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+import (
+	rpb "dubbo.apache.org/dubbo-go/v3/protocol/triple/reflection/triple_reflection"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+// DescriptorSource resolves the descriptors of services. The types of messages and extensions
+// are resolved as well to convert google.protobuf.Any between JSON and protobuf.
+type DescriptorSource interface {
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+	// FindService returns the descriptor of the service with the fully-qualified name.
+	FindService(ctx context.Context, name string) (protoreflect.ServiceDescriptor, error)
+}
+
+// fileSource is a DescriptorSource backed by a FileDescriptorSet
+type fileSource struct {
+	*dynamicpb.Types
+	files *protoregistry.Files
+}
+
+// NewFileDescriptorSetSource creates a DescriptorSource from set, dependencies missing in set
+// are resolved from the descriptors linked into the binary.
+func NewFileDescriptorSetSource(set *descriptorpb.FileDescriptorSet) (DescriptorSource, error) {
+	files := new(protoregistry.Files)
+	if err := registerFiles(files, set.GetFile()); err != nil {
+		return nil, err
+	}
+	return &fileSource{Types: dynamicpb.NewTypes(files), files: files}, nil
+}
+
+// NewFileDescriptorSetSourceFromFile creates a DescriptorSource from the file generated by
+// `protoc --include_imports --descriptor_set_out`.
+func NewFileDescriptorSetSourceFromFile(path string) (DescriptorSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	set := new(descriptorpb.FileDescriptorSet)
+	if err = proto.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("unmarshal descriptor set %s failed: %w", path, err)
+	}
+	return NewFileDescriptorSetSource(set)
+}
+
+func (s *fileSource) FindService(_ context.Context, name string) (protoreflect.ServiceDescriptor, error) {
+	return findService(s.files, name)
+}
+
+// reflectionSource is a DescriptorSource resolving descriptors by the server reflection service
+// of the target, the resolved descriptors are cached.
+type reflectionSource struct {
+	client *tri.Client
+	mu     sync.Mutex
+	files  *protoregistry.Files
+}
+
+func newReflectionSource(client *tri.Client) *reflectionSource {
+	return &reflectionSource{client: client, files: new(protoregistry.Files)}
+}
+
+func (s *reflectionSource) FindService(ctx context.Context, name string) (protoreflect.ServiceDescriptor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sd, err := findService(s.files, name); err == nil {
+		return sd, nil
+	}
+
+	fdps, err := s.fileContainingSymbol(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if err = registerFiles(s.files, fdps); err != nil {
+		return nil, err
+	}
+	return findService(s.files, name)
+}
+
+func (s *reflectionSource) FindMessageByName(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return dynamicpb.NewTypes(s.files).FindMessageByName(name)
+}
+
+func (s *reflectionSource) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return dynamicpb.NewTypes(s.files).FindMessageByURL(url)
+}
+
+func (s *reflectionSource) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return dynamicpb.NewTypes(s.files).FindExtensionByName(field)
+}
+
+func (s *reflectionSource) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return dynamicpb.NewTypes(s.files).FindExtensionByNumber(message, field)
+}
+
+// fileContainingSymbol asks the server for the file containing symbol and its dependencies
+func (s *reflectionSource) fileContainingSymbol(ctx context.Context, symbol string) ([]*descriptorpb.FileDescriptorProto, error) {
+	stream, err := s.client.CallBidiStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = stream.CloseResponse()
+	}()
+
+	req := &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: symbol},
+	}
+	if err = stream.Send(req); err != nil {
+		return nil, err
+	}
+	if err = stream.CloseRequest(); err != nil {
+		return nil, err
+	}
+	resp := new(rpb.ServerReflectionResponse)
+	if err = stream.Receive(resp); err != nil {
+		return nil, err
+	}
+
+	switch msg := resp.MessageResponse.(type) {
+	case *rpb.ServerReflectionResponse_FileDescriptorResponse:
+		fdps := make([]*descriptorpb.FileDescriptorProto, 0, len(msg.FileDescriptorResponse.FileDescriptorProto))
+		for _, raw := range msg.FileDescriptorResponse.FileDescriptorProto {
+			fdp := new(descriptorpb.FileDescriptorProto)
+			if err = proto.Unmarshal(raw, fdp); err != nil {
+				return nil, err
+			}
+			fdps = append(fdps, fdp)
+		}
+		return fdps, nil
+	case *rpb.ServerReflectionResponse_ErrorResponse:
+		return nil, fmt.Errorf("server reflection of %s failed: %s", symbol, msg.ErrorResponse.ErrorMessage)
+	default:
+		return nil, fmt.Errorf("unexpected server reflection response %T", resp.MessageResponse)
+	}
+}
+
+func findService(files *protoregistry.Files, name string) (protoreflect.ServiceDescriptor, error) {
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("service %s is not found: %w", name, err)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", name)
+	}
+	return sd, nil
+}
+
+// registerFiles registers fdps into files in the order of dependencies, the dependencies which are
+// neither in fdps nor in files are resolved from protoregistry.GlobalFiles.
+func registerFiles(files *protoregistry.Files, fdps []*descriptorpb.FileDescriptorProto) error {
+	byName := make(map[string]*descriptorpb.FileDescriptorProto, len(fdps))
+	for _, fdp := range fdps {
+		byName[fdp.GetName()] = fdp
+	}
+
+	var register func(name string) error
+	register = func(name string) error {
+		if _, err := files.FindFileByPath(name); err == nil {
+			return nil
+		}
+		fdp, ok := byName[name]
+		if !ok {
+			fd, err := protoregistry.GlobalFiles.FindFileByPath(name)
+			if err != nil {
+				return fmt.Errorf("missing the descriptor of %s", name)
+			}
+			return files.RegisterFile(fd)
+		}
+		// avoid endless recursion of invalid cyclic imports
+		delete(byName, name)
+		for _, dep := range fdp.GetDependency() {
+			if err := register(dep); err != nil {
+				return err
+			}
+		}
+		fd, err := protodesc.NewFile(fdp, files)
+		if err != nil {
+			return fmt.Errorf("invalid descriptor of %s: %w", name, err)
+		}
+		return files.RegisterFile(fd)
+	}
+
+	var errs []error
+	for _, fdp := range fdps {
+		if err := register(fdp.GetName()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
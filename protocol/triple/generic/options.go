@@ -0,0 +1,122 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generic
+
+import (
+	"crypto/tls"
+	"fmt"
+	"time"
+)
+
+import (
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+import (
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+type Options struct {
+	// Source resolves the descriptors of services, server reflection is used if it is nil
+	Source     DescriptorSource
+	TLSConfig  *tls.Config
+	HTTPClient tri.HTTPClient
+	Timeout    time.Duration
+	Group      string
+	Version    string
+	ProtoJSON  bool
+
+	// err is the error of the options, which is returned by NewClient
+	err error
+}
+
+func defaultOptions() *Options {
+	return &Options{Timeout: 3 * time.Second}
+}
+
+func NewOptions(opts ...Option) *Options {
+	defOpts := defaultOptions()
+	for _, opt := range opts {
+		opt(defOpts)
+	}
+	return defOpts
+}
+
+type Option func(*Options)
+
+// WithDescriptorSource resolves the descriptors of services by source instead of server reflection.
+func WithDescriptorSource(source DescriptorSource) Option {
+	return func(opts *Options) {
+		opts.Source = source
+	}
+}
+
+// WithFileDescriptorSet resolves the descriptors of services from set, which is usually generated by
+// `protoc --include_imports --descriptor_set_out`. NewClient fails if the set is invalid.
+func WithFileDescriptorSet(set *descriptorpb.FileDescriptorSet) Option {
+	return func(opts *Options) {
+		source, err := NewFileDescriptorSetSource(set)
+		if err != nil {
+			opts.err = fmt.Errorf("invalid file descriptor set: %w", err)
+			return
+		}
+		opts.Source = source
+	}
+}
+
+// WithTLSConfig calls the server over TLS.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(opts *Options) {
+		opts.TLSConfig = cfg
+	}
+}
+
+// WithHTTPClient calls the server by client instead of the default HTTP/2 client.
+func WithHTTPClient(client tri.HTTPClient) Option {
+	return func(opts *Options) {
+		opts.HTTPClient = client
+	}
+}
+
+// WithTimeout sets the timeout of unary calls. If not set, default timeout is 3s.
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *Options) {
+		opts.Timeout = timeout
+	}
+}
+
+// WithGroup sets the group of the service.
+func WithGroup(group string) Option {
+	return func(opts *Options) {
+		opts.Group = group
+	}
+}
+
+// WithVersion sets the version of the service.
+func WithVersion(version string) Option {
+	return func(opts *Options) {
+		opts.Version = version
+	}
+}
+
+// WithProtoJSON encodes messages in protobuf JSON on the wire instead of protobuf binary.
+func WithProtoJSON() Option {
+	return func(opts *Options) {
+		opts.ProtoJSON = true
+	}
+}
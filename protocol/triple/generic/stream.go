@@ -0,0 +1,143 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generic
+
+import (
+	"net/http"
+)
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+import (
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+// ClientStream is the stream of a client streaming method.
+type ClientStream struct {
+	client *Client
+	method protoreflect.MethodDescriptor
+	stream *tri.ClientStreamForClient
+}
+
+// Send sends a request to the server.
+func (s *ClientStream) Send(req any) error {
+	msg, err := s.client.newMessage(s.method.Input(), req)
+	if err != nil {
+		return err
+	}
+	return s.stream.Send(msg)
+}
+
+// CloseAndReceive closes the sending side and receives the response.
+func (s *ClientStream) CloseAndReceive() (map[string]any, error) {
+	msg := dynamicpb.NewMessage(s.method.Output())
+	if err := s.stream.CloseAndReceive(tri.NewResponse(msg)); err != nil {
+		return nil, err
+	}
+	return s.client.toMap(msg)
+}
+
+// ServerStream is the stream of a server streaming method.
+type ServerStream struct {
+	client *Client
+	method protoreflect.MethodDescriptor
+	stream *tri.ServerStreamForClient
+	msg    map[string]any
+	err    error
+}
+
+// Receive advances the stream to the next response, which is then available through Msg.
+// It returns false when the stream is finished or an error occurs, call Err to check the error.
+func (s *ServerStream) Receive() bool {
+	if s.err != nil {
+		return false
+	}
+	msg := dynamicpb.NewMessage(s.method.Output())
+	if !s.stream.Receive(msg) {
+		return false
+	}
+	s.msg, s.err = s.client.toMap(msg)
+	return s.err == nil
+}
+
+// Msg returns the most recent response received by Receive.
+func (s *ServerStream) Msg() map[string]any {
+	return s.msg
+}
+
+// Err returns the first error that was encountered by Receive.
+func (s *ServerStream) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.stream.Err()
+}
+
+// ResponseTrailer returns the trailers received from the server.
+func (s *ServerStream) ResponseTrailer() http.Header {
+	return s.stream.ResponseTrailer()
+}
+
+// Close closes the receiving side of the stream.
+func (s *ServerStream) Close() error {
+	return s.stream.Close()
+}
+
+// BidiStream is the stream of a bidirectional streaming method.
+type BidiStream struct {
+	client *Client
+	method protoreflect.MethodDescriptor
+	stream *tri.BidiStreamForClient
+}
+
+// Send sends a request to the server.
+func (s *BidiStream) Send(req any) error {
+	msg, err := s.client.newMessage(s.method.Input(), req)
+	if err != nil {
+		return err
+	}
+	return s.stream.Send(msg)
+}
+
+// CloseRequest closes the sending side of the stream.
+func (s *BidiStream) CloseRequest() error {
+	return s.stream.CloseRequest()
+}
+
+// Receive receives a response from the server, it returns io.EOF once the server finishes the stream.
+func (s *BidiStream) Receive() (map[string]any, error) {
+	msg := dynamicpb.NewMessage(s.method.Output())
+	if err := s.stream.Receive(msg); err != nil {
+		return nil, err
+	}
+	return s.client.toMap(msg)
+}
+
+// CloseResponse closes the receiving side of the stream.
+func (s *BidiStream) CloseResponse() error {
+	return s.stream.CloseResponse()
+}
+
+// ResponseTrailer returns the trailers received from the server.
+func (s *BidiStream) ResponseTrailer() http.Header {
+	return s.stream.ResponseTrailer()
+}
@@ -173,6 +173,7 @@ func (srv *HealthTripleServer) Resume() {
 func init() {
 	healthServer = NewServer()
 	internal.HealthSetServingStatusServing = SetServingStatusServing
+	internal.HealthShutdown = healthServer.Shutdown
 	server.SetProviderServices(&server.InternalService{
 		Name: "healthCheck",
 		Init: func(options *server.ServiceOptions) (*server.ServiceDefinition, bool) {
@@ -161,3 +161,49 @@ func Http3Negotiation(negotiation bool) Option {
 		opts.Triple.Http3.Negotiation = negotiation
 	}
 }
+
+// WithMaxConnections sets the max number of the connections of the client to an address over HTTP/2.
+// n: The max number of the connections, new connections are opened once the streams of the opened ones reach
+// the limit set by WithMaxConcurrentStreams.
+// If not set, default value is 1.
+func WithMaxConnections(n int) Option {
+	return func(opts *Options) {
+		opts.connPool().MaxConnections = n
+	}
+}
+
+// WithMaxConcurrentStreams sets the max number of the concurrent streams of a connection of the client
+// before a new connection is opened.
+// n: The max number of the concurrent streams of a connection.
+// If not set, the max concurrent streams advertised by the server is used.
+func WithMaxConcurrentStreams(n int) Option {
+	return func(opts *Options) {
+		opts.connPool().MaxConcurrentStreams = n
+	}
+}
+
+// WithConnIdleTimeout sets how long an idle connection of the client is kept before it's closed.
+// timeout: The duration an idle connection is kept.
+// If not set, the idle connections are never closed.
+func WithConnIdleTimeout(timeout time.Duration) Option {
+	return func(opts *Options) {
+		opts.connPool().IdleTimeout = timeout.String()
+	}
+}
+
+// WithConnWarmup sets the number of the connections opened by the client when the invoker is created, so the
+// first requests don't wait for the connections.
+// n: The number of the connections opened, it's at most the max connections.
+// If not set, default value is 0.
+func WithConnWarmup(n int) Option {
+	return func(opts *Options) {
+		opts.connPool().Warmup = n
+	}
+}
+
+func (opts *Options) connPool() *global.TripleConnPoolConfig {
+	if opts.Triple.ConnPool == nil {
+		opts.Triple.ConnPool = &global.TripleConnPoolConfig{}
+	}
+	return opts.Triple.ConnPool
+}
@@ -57,8 +57,10 @@ import (
 type Server struct {
 	triServer *tri.Server
 	cfg       *global.TripleConfig
-	mu        sync.RWMutex
-	services  map[string]grpc.ServiceInfo
+	// unixSocket is the unix domain socket served by triServer, which is empty if it isn't served
+	unixSocket string
+	mu         sync.RWMutex
+	services   map[string]grpc.ServiceInfo
 }
 
 // NewServer creates a new TRIPLE server.
@@ -91,6 +93,7 @@ func (s *Server) Start(invoker base.Invoker, info *common.ServiceInfo) {
 
 	// initialize tri.Server
 	s.triServer = tri.NewServer(addr, tripleConf)
+	s.listenUnix(url, callProtocol)
 
 	serialization := url.GetParam(constant.SerializationKey, constant.ProtobufSerialization)
 	switch serialization {
@@ -169,6 +172,7 @@ func (s *Server) Start(invoker base.Invoker, info *common.ServiceInfo) {
 // RefreshService refreshes Triple Service
 func (s *Server) RefreshService(invoker base.Invoker, info *common.ServiceInfo) {
 	URL := invoker.GetURL()
+	s.syncUnixSocketParams(URL)
 	serialization := URL.GetParam(constant.SerializationKey, constant.ProtobufSerialization)
 	switch serialization {
 	case constant.ProtobufSerialization:
@@ -190,10 +194,38 @@ func (s *Server) RefreshService(invoker base.Invoker, info *common.ServiceInfo)
 	}
 }
 
+// listenUnix listens on the unix domain socket of the url, the params of the socket are removed from the url unless
+// the socket is served, so that the consumers never dial the socket nobody listens on
+func (s *Server) listenUnix(url *common.URL, callProtocol string) {
+	socket := url.GetParam(constant.UnixSocketKey, "")
+	if path, ok := common.ParseUnixSocket(socket); ok {
+		if callProtocol == constant.CallHTTP3 {
+			// the unix domain socket is served by the HTTP/2 server only
+			logger.Warnf("TRIPLE Server serving HTTP/3 only doesn't serve the unix socket %s", socket)
+		} else if err := s.triServer.ListenUnix(path); err != nil {
+			logger.Warnf("TRIPLE Server doesn't serve the unix socket %s, err: %v", socket, err)
+		} else {
+			s.unixSocket = socket
+		}
+	}
+	s.syncUnixSocketParams(url)
+}
+
+// syncUnixSocketParams keeps the params of the unix domain socket on the url only if the socket is served
+func (s *Server) syncUnixSocketParams(url *common.URL) {
+	if s.unixSocket != "" && url.GetParam(constant.UnixSocketKey, "") == s.unixSocket {
+		return
+	}
+	url.DelParam(constant.UnixSocketKey)
+	url.DelParam(constant.HostIdKey)
+}
+
 func getHanOpts(url *common.URL, tripleConf *global.TripleConfig) (hanOpts []tri.HandlerOption) {
 	group := url.GetParam(constant.GroupKey, "")
 	version := url.GetParam(constant.VersionKey, "")
 	hanOpts = append(hanOpts, tri.WithGroup(group), tri.WithVersion(version))
+	// record the messages of the streams on the spans of the tracing filters
+	hanOpts = append(hanOpts, tri.WithInterceptors(&streamTracingInterceptor{}))
 
 	// Deprecated：use TripleConfig
 	// TODO: remove MaxServerSendMsgSize and MaxServerRecvMsgSize when version 4.0.0
@@ -326,6 +358,7 @@ func (s *Server) handleServiceWithInfo(interfaceName string, invoker base.Invoke
 						args = append(args, req.Msg)
 					}
 					attachments := generateAttachments(req.Header())
+					attachments[constant.RemoteAddr] = req.Peer().Addr
 					// inject attachments
 					ctx = context.WithValue(ctx, constant.AttachmentKey, attachments)
 					invo := invocation.NewRPCInvocation(m.Name, args, attachments)
@@ -360,6 +393,7 @@ func (s *Server) handleServiceWithInfo(interfaceName string, invoker base.Invoke
 					var args []any
 					args = append(args, m.StreamInitFunc(stream))
 					attachments := generateAttachments(stream.RequestHeader())
+					attachments[constant.RemoteAddr] = stream.Peer().Addr
 					// inject attachments
 					ctx = context.WithValue(ctx, constant.AttachmentKey, attachments)
 					invo := invocation.NewRPCInvocation(m.Name, args, attachments)
@@ -381,6 +415,7 @@ func (s *Server) handleServiceWithInfo(interfaceName string, invoker base.Invoke
 					var args []any
 					args = append(args, req.Msg, m.StreamInitFunc(stream))
 					attachments := generateAttachments(req.Header())
+					attachments[constant.RemoteAddr] = req.Peer().Addr
 					// inject attachments
 					ctx = context.WithValue(ctx, constant.AttachmentKey, attachments)
 					invo := invocation.NewRPCInvocation(m.Name, args, attachments)
@@ -396,6 +431,7 @@ func (s *Server) handleServiceWithInfo(interfaceName string, invoker base.Invoke
 					var args []any
 					args = append(args, m.StreamInitFunc(stream))
 					attachments := generateAttachments(stream.RequestHeader())
+					attachments[constant.RemoteAddr] = stream.Peer().Addr
 					// inject attachments
 					ctx = context.WithValue(ctx, constant.AttachmentKey, attachments)
 					invo := invocation.NewRPCInvocation(m.Name, args, attachments)
@@ -19,16 +19,20 @@ package triple
 
 import (
 	"net/http"
+	"path/filepath"
 	"testing"
 )
 
 import (
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 import (
+	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/global"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 )
 
 func Test_generateAttachments(t *testing.T) {
@@ -145,3 +149,39 @@ func TestServer_ProtocolSelection(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_listenUnix(t *testing.T) {
+	newURL := func(socket string) *common.URL {
+		url, err := common.NewURL("tri://127.0.0.1:20000/com.example.TestService?unix-socket=" + socket + "&host-id=node-1")
+		require.NoError(t, err)
+		return url
+	}
+	socket := "unix://" + filepath.Join(t.TempDir(), "tri.sock")
+
+	// the params of the served socket are kept
+	s := &Server{triServer: tri.NewServer("127.0.0.1:0", nil)}
+	url := newURL(socket)
+	s.listenUnix(url, constant.CallHTTP2)
+	defer s.triServer.Stop()
+	assert.Equal(t, socket, url.GetParam(constant.UnixSocketKey, ""))
+	assert.Equal(t, "node-1", url.GetParam(constant.HostIdKey, ""))
+	another := newURL(socket)
+	s.syncUnixSocketParams(another)
+	assert.Equal(t, socket, another.GetParam(constant.UnixSocketKey, ""))
+
+	// the params of the socket failing to be listened on are removed
+	s = &Server{triServer: tri.NewServer("127.0.0.1:0", nil)}
+	url = newURL("unix://" + filepath.Join(t.TempDir(), "absent", "tri.sock"))
+	s.listenUnix(url, constant.CallHTTP2)
+	assert.Empty(t, url.GetParam(constant.UnixSocketKey, ""))
+	assert.Empty(t, url.GetParam(constant.HostIdKey, ""))
+	another = newURL(socket)
+	s.syncUnixSocketParams(another)
+	assert.Empty(t, another.GetParam(constant.UnixSocketKey, ""))
+
+	// the socket isn't served by HTTP/3
+	s = &Server{triServer: tri.NewServer("127.0.0.1:0", nil)}
+	url = newURL("unix://" + filepath.Join(t.TempDir(), "tri.sock"))
+	s.listenUnix(url, constant.CallHTTP3)
+	assert.Empty(t, url.GetParam(constant.UnixSocketKey, ""))
+}
@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package triple
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+import (
+	dubbotrace "dubbo.apache.org/dubbo-go/v3/otel/trace"
+	tri "dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
+)
+
+// streamTracingInterceptor records the messages of the streams as the events of the spans started by the tracing
+// filters, it does nothing if the tracing filters are not enabled
+type streamTracingInterceptor struct{}
+
+func (i *streamTracingInterceptor) WrapUnary(next tri.UnaryFunc) tri.UnaryFunc {
+	return next
+}
+
+func (i *streamTracingInterceptor) WrapUnaryHandler(next tri.UnaryHandlerFunc) tri.UnaryHandlerFunc {
+	return next
+}
+
+func (i *streamTracingInterceptor) WrapStreamingClient(next tri.StreamingClientFunc) tri.StreamingClientFunc {
+	return func(ctx context.Context, spec tri.Spec) tri.StreamingClientConn {
+		conn := next(ctx, spec)
+		stream := dubbotrace.StreamSpanFromContext(ctx)
+		if stream == nil || !stream.Claim() {
+			return conn
+		}
+		return &tracingClientConn{StreamingClientConn: conn, stream: stream}
+	}
+}
+
+func (i *streamTracingInterceptor) WrapStreamingHandler(next tri.StreamingHandlerFunc) tri.StreamingHandlerFunc {
+	return func(ctx context.Context, conn tri.StreamingHandlerConn) error {
+		ctx, stream := dubbotrace.WithStreamSpan(ctx)
+		return next(ctx, &tracingHandlerConn{StreamingHandlerConn: conn, stream: stream})
+	}
+}
+
+// tracingClientConn records the messages of the stream and ends the span once the stream is closed
+type tracingClientConn struct {
+	tri.StreamingClientConn
+	stream *dubbotrace.StreamSpan
+}
+
+func (c *tracingClientConn) Send(msg any) error {
+	err := c.StreamingClientConn.Send(msg)
+	if err == nil {
+		c.stream.Sent(msg)
+	}
+	return err
+}
+
+func (c *tracingClientConn) Receive(msg any) error {
+	err := c.StreamingClientConn.Receive(msg)
+	switch {
+	case err == nil:
+		c.stream.Received(msg)
+	case errors.Is(err, io.EOF):
+		c.stream.End(nil)
+	default:
+		c.stream.End(err)
+	}
+	return err
+}
+
+func (c *tracingClientConn) CloseResponse() error {
+	err := c.StreamingClientConn.CloseResponse()
+	c.stream.End(err)
+	return err
+}
+
+// tracingHandlerConn records the messages of the stream, the span is ended by the tracing filter
+type tracingHandlerConn struct {
+	tri.StreamingHandlerConn
+	stream *dubbotrace.StreamSpan
+}
+
+func (c *tracingHandlerConn) Send(msg any) error {
+	err := c.StreamingHandlerConn.Send(msg)
+	if err == nil {
+		c.stream.Sent(msg)
+	}
+	return err
+}
+
+func (c *tracingHandlerConn) Receive(msg any) error {
+	err := c.StreamingHandlerConn.Receive(msg)
+	if err == nil {
+		c.stream.Received(msg)
+	}
+	return err
+}
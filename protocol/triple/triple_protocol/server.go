@@ -20,7 +20,9 @@ package triple_protocol
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 )
 
@@ -39,6 +41,7 @@ import (
 )
 
 import (
+	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/global"
 )
@@ -50,6 +53,22 @@ type Server struct {
 	httpSrv      *http.Server
 	http3Srv     *http3.Server
 	tripleConfig *global.TripleConfig // Configuration for the triple protocol
+	unixListener net.Listener         // Listener of the unix domain socket served besides the tcp address
+}
+
+// ListenUnix listens on the unix domain socket, which is served by the HTTP/2 server besides the tcp address once
+// the server runs. The stale socket left by the former process is removed, but it fails if the path is another file
+// or a socket still being served.
+func (s *Server) ListenUnix(path string) error {
+	if err := common.RemoveStaleUnixSocket(path); err != nil {
+		return err
+	}
+	lis, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("listen on the unix socket %s failed: %w", path, err)
+	}
+	s.unixListener = lis
+	return nil
 }
 
 func (s *Server) RegisterUnaryHandler(
@@ -195,6 +214,8 @@ func (s *Server) startHttp2(tlsConf *tls.Config) error {
 
 	logger.Debugf("TRIPLE HTTP/2 Server starting on %v", s.addr)
 
+	s.serveUnix(tlsConf)
+
 	var err error
 
 	if tlsConf != nil {
@@ -224,6 +245,9 @@ func (s *Server) startHttp3(tlsConf *tls.Config) error {
 
 	logger.Debugf("TRIPLE HTTP/3 Server starting on %v", s.addr)
 
+	// the unix domain socket is served by the HTTP/2 server only
+	s.closeUnix()
+
 	return s.http3Srv.ListenAndServe()
 }
 
@@ -257,6 +281,8 @@ func (s *Server) startHttp2AndHttp3(tlsConf *tls.Config) error {
 
 	logger.Debugf("TRIPLE HTTP/2 and HTTP/3 Server starting on %v", s.addr)
 
+	s.serveUnix(tlsConf)
+
 	// Use errgroup to manage concurrent server startup
 	eg := &errgroup.Group{}
 
@@ -280,6 +306,39 @@ func (s *Server) startHttp2AndHttp3(tlsConf *tls.Config) error {
 	return eg.Wait()
 }
 
+// serveUnix serves the HTTP/2 server on the unix domain socket in the background, the socket is removed when
+// the server is closed.
+func (s *Server) serveUnix(tlsConf *tls.Config) {
+	if s.unixListener == nil {
+		return
+	}
+	lis := s.unixListener
+
+	logger.Debugf("TRIPLE HTTP/2 Server starting on unix socket %v", lis.Addr())
+
+	go func() {
+		var serveErr error
+		if tlsConf != nil {
+			serveErr = s.httpSrv.ServeTLS(lis, "", "")
+		} else {
+			serveErr = s.httpSrv.Serve(lis)
+		}
+		if serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			logger.Errorf("TRIPLE HTTP/2 Server serving on unix socket %s failed: %v", lis.Addr(), serveErr)
+		}
+	}()
+}
+
+// closeUnix closes the listener of the unix domain socket, which removes the socket
+func (s *Server) closeUnix() {
+	if s.unixListener == nil {
+		return
+	}
+	if err := s.unixListener.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		logger.Warnf("TRIPLE Server closing the unix socket %s failed: %v", s.unixListener.Addr(), err)
+	}
+}
+
 // Stop the Triple server for both HTTP/2 and HTTP/3.
 func (s *Server) Stop() error {
 	eg, _ := errgroup.WithContext(context.Background())
@@ -305,7 +364,10 @@ func (s *Server) Stop() error {
 	}
 
 	// Wait for all goroutines to complete and collect any errors
-	return eg.Wait()
+	err := eg.Wait()
+	// the unix socket isn't served if the server never runs
+	s.closeUnix()
+	return err
 }
 
 // Gracefulstop shutdown the Triple server for both HTTP/2 and HTTP/3 gracefully.
@@ -333,7 +395,9 @@ func (s *Server) GracefulStop(ctx context.Context) error {
 	}
 
 	// Wait for all goroutines to complete and collect any errors
-	return eg.Wait()
+	err := eg.Wait()
+	s.closeUnix()
+	return err
 }
 
 func NewServer(addr string, tripleConf *global.TripleConfig) *Server {
@@ -18,16 +18,26 @@
 package triple_protocol
 
 import (
+	"context"
+	"crypto/tls"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 import (
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/net/http2"
 )
 
 import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/global"
 )
 
@@ -98,3 +108,44 @@ func TestServer_RegisterMuxHandle(t *testing.T) {
 		assert.Equal(t, test.path, pattern)
 	}
 }
+
+func TestServer_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tri.sock")
+	// the stale socket left by the former process is removed
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	require.NoError(t, err)
+	stale.SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	srv := NewServer("127.0.0.1:0", nil)
+	require.NoError(t, srv.ListenUnix(path))
+	// the socket being served is kept
+	assert.Error(t, NewServer("127.0.0.1:0", nil).ListenUnix(path))
+	srv.mux.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	})
+	go func() {
+		_ = srv.Run(constant.CallHTTP2, nil)
+	}()
+
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, _, _ string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		},
+	}}
+	assert.Eventually(t, func() bool {
+		resp, err := client.Get("http://localhost/ping")
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode == http.StatusOK && resp.ProtoMajor == 2
+	}, 3*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, srv.Stop())
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+		return os.IsNotExist(err)
+	}, time.Second, 10*time.Millisecond)
+}
@@ -103,6 +103,10 @@ func (pi *ProxyInvoker) Invoke(ctx context.Context, invocation base.Invocation)
 	url := getProviderURL(pi.GetURL())
 
 	methodName := invocation.MethodName()
+	if err := checkDeadline(ctx, url, methodName); err != nil {
+		result.SetError(err)
+		return result
+	}
 	proto := url.Protocol
 	path := strings.TrimPrefix(url.Path, "/")
 	args := invocation.Arguments()
@@ -202,6 +206,14 @@ func (tpi *infoProxyInvoker) Invoke(ctx context.Context, invocation base.Invocat
 	name := invocation.MethodName()
 	args := invocation.Arguments()
 	result := new(result.RPCResult)
+	if err := checkDeadline(ctx, tpi.GetURL(), name); err != nil {
+		code := triple_protocol.CodeDeadlineExceeded
+		if errors.Is(err, context.Canceled) {
+			code = triple_protocol.CodeCanceled
+		}
+		result.SetError(triple_protocol.NewError(code, err))
+		return result
+	}
 	if method, ok := tpi.methodMap[name]; ok {
 		res, err := method.MethodFunc(ctx, args, tpi.svc)
 		result.SetResult(res)
@@ -18,8 +18,11 @@
 package proxy_factory
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 )
 
 import (
@@ -29,6 +32,8 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/triple_protocol"
 )
 
 func TestGetProxy(t *testing.T) {
@@ -58,3 +63,28 @@ func TestGetInvoker(t *testing.T) {
 	invoker := proxyFactory.GetInvoker(url)
 	assert.True(t, invoker.IsAvailable())
 }
+
+func TestInvokeExpired(t *testing.T) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	url := common.NewURLWithOptions(common.WithProtocol("dubbo"))
+	inv := invocation.NewRPCInvocationWithOptions(invocation.WithMethodName("Say"))
+
+	res := NewDefaultProxyFactory().GetInvoker(url).Invoke(ctx, inv)
+	assert.True(t, errors.Is(res.Error(), context.DeadlineExceeded))
+
+	res = NewPassThroughProxyFactory().GetInvoker(url).Invoke(ctx, inv)
+	assert.True(t, errors.Is(res.Error(), context.DeadlineExceeded))
+
+	called := false
+	info := &common.ServiceInfo{Methods: []common.MethodInfo{{
+		Name: "Say",
+		MethodFunc: func(context.Context, []any, any) (any, error) {
+			called = true
+			return nil, nil
+		},
+	}}}
+	res = newInfoInvoker(url, info, nil).Invoke(ctx, inv)
+	assert.False(t, called)
+	assert.Equal(t, triple_protocol.CodeDeadlineExceeded, triple_protocol.CodeOf(res.Error()))
+}
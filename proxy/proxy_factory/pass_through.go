@@ -84,6 +84,10 @@ func (pi *PassThroughProxyInvoker) Invoke(ctx context.Context, invocation base.I
 	result := &result.RPCResult{}
 	result.SetAttachments(invocation.Attachments())
 	url := getProviderURL(pi.GetURL())
+	if err := checkDeadline(ctx, url, invocation.MethodName()); err != nil {
+		result.Err = err
+		return result
+	}
 
 	arguments := invocation.Arguments()
 	srv := common.ServiceMap.GetServiceByServiceKey(url.Protocol, url.ServiceKey())
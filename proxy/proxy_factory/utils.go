@@ -18,14 +18,21 @@
 package proxy_factory
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 )
 
 import (
+	"github.com/dubbogo/gost/log/logger"
+
 	perrors "github.com/pkg/errors"
 )
 
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+)
+
 // CallLocalMethod is used to handle invoke exception in user func.
 func callLocalMethod(method reflect.Method, in []reflect.Value) ([]reflect.Value, error) {
 	var (
@@ -55,3 +62,14 @@ func callLocalMethod(method reflect.Method, in []reflect.Value) ([]reflect.Value
 
 	return returnValues, retErr
 }
+
+// checkDeadline rejects the invocation whose deadline propagated from the consumer is exceeded before the service
+// is invoked, since the consumer has given up waiting for the result.
+func checkDeadline(ctx context.Context, url *common.URL, methodName string) error {
+	if err := ctx.Err(); err != nil {
+		logger.Warnf("The invocation of method %s of service %s is rejected before it's invoked: %v",
+			methodName, url.ServiceKey(), err)
+		return perrors.Wrapf(err, "invocation of method %s of service %s is rejected", methodName, url.ServiceKey())
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package directory
+
+import (
+	"encoding/gob"
+	"strconv"
+	"sync"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	protocolbase "dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+	"dubbo.apache.org/dubbo-go/v3/registry/servicediscovery/store"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+const (
+	// addressCacheDumpInterval is the interval to persist the last known good addresses
+	addressCacheDumpInterval = time.Minute
+	// cachedAddressConfirmTimeout is how long the cached addresses are kept after the registry recovers,
+	// the ones not notified by the registry during this period are dropped.
+	cachedAddressConfirmTimeout = 30 * time.Second
+)
+
+var (
+	addressCaches     = make(map[string]*store.CacheManager)
+	addressCachesLock sync.Mutex
+)
+
+func init() {
+	// the cached addresses are loaded before any dump, which registers the type as well
+	gob.Register([]string{})
+}
+
+// getAddressCache returns the cache of addresses persisted in file, directories with the same file share the cache.
+func getAddressCache(file string) *store.CacheManager {
+	addressCachesLock.Lock()
+	defer addressCachesLock.Unlock()
+	if cache, ok := addressCaches[file]; ok {
+		return cache
+	}
+	cache, err := store.NewCacheManager(constant.DefaultRegistryCacheName, file, addressCacheDumpInterval,
+		constant.DefaultRegistryCacheEntrySize, true)
+	if err != nil {
+		logger.Warnf("[Registry Directory] failed to create the address cache %s: %v", file, err)
+		return nil
+	}
+	addressCaches[file] = cache
+	return cache
+}
+
+// addressProtection guards the addresses of a directory against wrong notifications of the registry,
+// and keeps the last known good addresses to bootstrap the directory when the registry is unreachable.
+type addressProtection struct {
+	emptyProtection bool
+	// notifications shrinking the addresses below ratio of the current ones are ignored
+	ratio     float64
+	cache     *store.CacheManager
+	cacheKey  string
+	lock      sync.Mutex
+	cached    map[string]struct{}
+	confirmed bool
+}
+
+// initAddressProtection reads the options of protection from the registry url
+func (dir *RegistryDirectory) initAddressProtection(url *common.URL) {
+	p := &dir.protection
+	p.emptyProtection = url.GetParamBool(constant.RegistryEmptyProtectionKey, false)
+	if ratio := url.GetParam(constant.RegistryEmptyProtectionRatioKey, ""); ratio != "" {
+		var err error
+		if p.ratio, err = strconv.ParseFloat(ratio, 64); err != nil {
+			logger.Warnf("[Registry Directory] invalid %s %s, only empty notifications are ignored",
+				constant.RegistryEmptyProtectionRatioKey, ratio)
+		}
+	}
+	if url.GetParamBool(constant.RegistryAddressCacheKey, false) {
+		application := url.SubURL.GetParam(constant.ApplicationKey, "")
+		p.cache = getAddressCache(url.GetParam(constant.RegistryAddressCacheFileKey,
+			constant.DefaultRegistryCacheFileName+application))
+		p.cacheKey = url.Location + "/" + url.SubURL.ServiceKey()
+	}
+}
+
+// shouldIgnoreAll returns true if the complete address list of size n is regarded as a wrong notification
+func (dir *RegistryDirectory) shouldIgnoreAll(n int) bool {
+	if !dir.protection.emptyProtection {
+		return false
+	}
+	current := dir.cachedAddressCount()
+	if current == 0 || (n > 0 && float64(n) >= dir.protection.ratio*float64(current)) {
+		return false
+	}
+	logger.Warnf("[Registry Directory] ignore the notification of %s with %d addresses since there are %d addresses now",
+		dir.serviceType, n, current)
+	return true
+}
+
+// shouldIgnore returns true if the event removes the last address
+func (dir *RegistryDirectory) shouldIgnore(event *registry.ServiceEvent) bool {
+	if !dir.protection.emptyProtection || event == nil || event.Action != remoting.EventTypeDel {
+		return false
+	}
+	if _, ok := dir.cacheInvokersMap.Load(event.Key()); !ok || dir.cachedAddressCount() > 1 {
+		return false
+	}
+	logger.Warnf("[Registry Directory] ignore the deletion of the last address %s of %s", event.Service, dir.serviceType)
+	return true
+}
+
+func (dir *RegistryDirectory) cachedAddressCount() int {
+	count := 0
+	dir.cacheInvokersMap.Range(func(_, _ any) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// saveAddresses saves the current addresses as the last known good ones
+func (dir *RegistryDirectory) saveAddresses() {
+	if dir.protection.cache == nil {
+		return
+	}
+	var urls []string
+	dir.cacheInvokersMap.Range(func(_, v any) bool {
+		urls = append(urls, v.(protocolbase.Invoker).GetURL().String())
+		return true
+	})
+	if len(urls) > 0 {
+		dir.protection.cache.Set(dir.protection.cacheKey, urls)
+	}
+}
+
+// loadCachedAddresses bootstraps the directory by the last known good addresses if it has no address yet
+func (dir *RegistryDirectory) loadCachedAddresses() {
+	p := &dir.protection
+	if p.cache == nil || dir.cachedAddressCount() > 0 {
+		return
+	}
+	value, _ := p.cache.Get(p.cacheKey)
+	urls, _ := value.([]string)
+	events := make([]*registry.ServiceEvent, 0, len(urls))
+	for _, u := range urls {
+		url, err := common.NewURL(u)
+		if err != nil {
+			logger.Warnf("[Registry Directory] invalid cached address %s: %v", u, err)
+			continue
+		}
+		events = append(events, &registry.ServiceEvent{Action: remoting.EventTypeAdd, Service: url})
+	}
+	if len(events) == 0 {
+		return
+	}
+	logger.Warnf("[Registry Directory] the registry is unreachable, bootstrap %s by %d cached addresses",
+		dir.serviceType, len(events))
+	dir.refreshAllInvokers(events, func() {})
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.cached = make(map[string]struct{}, len(events))
+	p.confirmed = false
+	for _, event := range events {
+		p.cached[event.Key()] = struct{}{}
+	}
+}
+
+// confirmAddress is called when the registry notifies an address. The cached addresses are replaced by
+// the complete address list, or dropped after a while if they are not notified one by one.
+func (dir *RegistryDirectory) confirmAddress(event *registry.ServiceEvent, complete bool) {
+	p := &dir.protection
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.cached == nil {
+		return
+	}
+	if complete {
+		p.cached = nil
+		return
+	}
+	if event != nil {
+		delete(p.cached, event.Key())
+	}
+	if !p.confirmed {
+		p.confirmed = true
+		time.AfterFunc(cachedAddressConfirmTimeout, dir.dropUnconfirmedAddresses)
+	}
+}
+
+// dropUnconfirmedAddresses drops the cached addresses which are not notified by the registry
+func (dir *RegistryDirectory) dropUnconfirmedAddresses() {
+	p := &dir.protection
+	p.lock.Lock()
+	keys := p.cached
+	p.cached = nil
+	p.lock.Unlock()
+	if len(keys) == 0 || dir.IsDestroyed() {
+		return
+	}
+
+	var oldInvokers []protocolbase.Invoker
+	dir.registerLock.Lock()
+	for key := range keys {
+		if invoker := dir.uncacheInvokerWithKey(key); invoker != nil {
+			oldInvokers = append(oldInvokers, invoker)
+		}
+	}
+	dir.registerLock.Unlock()
+	logger.Infof("[Registry Directory] drop %d cached addresses of %s not notified by the registry",
+		len(oldInvokers), dir.serviceType)
+	dir.setNewInvokers()
+	for _, invoker := range oldInvokers {
+		go invoker.Destroy()
+	}
+}
@@ -18,10 +18,12 @@
 package directory
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -29,6 +31,9 @@ import (
 	"github.com/dubbogo/gost/log/logger"
 
 	perrors "github.com/pkg/errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 import (
@@ -45,6 +50,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/global"
 	"dubbo.apache.org/dubbo-go/v3/metrics"
 	metricsRegistry "dubbo.apache.org/dubbo-go/v3/metrics/registry"
+	dubbotrace "dubbo.apache.org/dubbo-go/v3/otel/trace"
 	protocolbase "dubbo.apache.org/dubbo-go/v3/protocol/base"
 	"dubbo.apache.org/dubbo-go/v3/protocol/protocolwrapper"
 	"dubbo.apache.org/dubbo-go/v3/registry"
@@ -74,6 +80,10 @@ type RegistryDirectory struct {
 	registerLock                   sync.Mutex // this lock if for register
 	SubscribedUrl                  *common.URL
 	RegisteredUrl                  *common.URL
+	protection                     addressProtection
+	removeAppConfiguratorListener  func()
+	// subscribeSpan records the subscription until the first notification
+	subscribeSpan atomic.Pointer[trace.Span]
 }
 
 // NewRegistryDirectory will create a new RegistryDirectory
@@ -100,6 +110,7 @@ func NewRegistryDirectory(url *common.URL, registry registry.Registry) (director
 	}
 
 	dir.consumerURL = dir.getConsumerUrl(url.SubURL)
+	dir.initAddressProtection(url)
 
 	if routerChain, err := chain.NewRouterChain(url); err == nil {
 		dir.SetRouterChain(routerChain)
@@ -110,6 +121,8 @@ func NewRegistryDirectory(url *common.URL, registry registry.Registry) (director
 	dir.consumerConfigurationListener = newConsumerConfigurationListener(dir, url)
 	dir.consumerConfigurationListener.addNotifyListener(dir)
 	dir.referenceConfigurationListener = newReferenceConfigurationListener(dir, url)
+	dir.removeAppConfiguratorListener = config_center.AddAppConfiguratorListener(common.DubboRole[common.CONSUMER],
+		dir.processAppConfigurator)
 
 	if err := dir.registry.LoadSubscribeInstances(url.SubURL, dir); err != nil {
 		return nil, err
@@ -122,10 +135,18 @@ func NewRegistryDirectory(url *common.URL, registry registry.Registry) (director
 func (dir *RegistryDirectory) Subscribe(url *common.URL) error {
 	logger.Infof("Start subscribing for service :%s with a new go routine.", url.Key())
 
+	// the registry may keep watching in Subscribe until it is destroyed, so the span only records the subscription
+	// until the first notification, or until Subscribe returns
+	_, span := dubbotrace.StartSpan(context.Background(), dubbotrace.SpanRegistrySubscribe,
+		dir.spanAttributes(url.ServiceKey())...)
+	dir.subscribeSpan.Store(&span)
 	go func() {
 		dir.SubscribedUrl = url
-		if err := dir.registry.Subscribe(url, dir); err != nil {
+		err := dir.registry.Subscribe(url, dir)
+		dir.endSubscribeSpan(err)
+		if err != nil {
 			logger.Error("registry.Subscribe(url:%v, dir:%v) = error:%v", url, dir, err)
+			dir.loadCachedAddresses()
 		}
 
 	}()
@@ -166,6 +187,7 @@ func (dir *RegistryDirectory) Subscribe(url *common.URL) error {
 		return nil
 	case <-time.After(timeout):
 		logger.Errorf("register timed out for service: %s", url.Key())
+		dir.loadCachedAddresses()
 		return fmt.Errorf("register timed out for service: %s", url.Key())
 	}
 }
@@ -175,15 +197,50 @@ func (dir *RegistryDirectory) Notify(event *registry.ServiceEvent) {
 	if event == nil {
 		return
 	}
+	dir.endSubscribeSpan(nil)
 	start := time.Now()
+	_, span := dubbotrace.StartSpan(context.Background(), dubbotrace.SpanRegistryNotify,
+		append(dir.spanAttributes(dir.serviceKey()), dubbotrace.EventTypeKey.String(event.Action.String()))...)
 	dir.refreshInvokers(event)
+	span.End()
 	metrics.Publish(metricsRegistry.NewNotifyEvent(start))
 }
 
 // NotifyAll notify the events that are complete Service Event List.
 // After notify the address, the callback func will be invoked.
 func (dir *RegistryDirectory) NotifyAll(events []*registry.ServiceEvent, callback func()) {
+	dir.endSubscribeSpan(nil)
+	_, span := dubbotrace.StartSpan(context.Background(), dubbotrace.SpanRegistryNotify,
+		append(dir.spanAttributes(dir.serviceKey()), dubbotrace.EventCountKey.Int(len(events)))...)
+	defer span.End()
 	dir.refreshAllInvokers(events, callback)
+	dir.confirmAddress(nil, true)
+}
+
+// endSubscribeSpan ends the span of the subscription if it's not ended yet
+func (dir *RegistryDirectory) endSubscribeSpan(err error) {
+	if span := dir.subscribeSpan.Swap(nil); span != nil {
+		dubbotrace.EndSpan(*span, err)
+	}
+}
+
+// serviceKey returns the service key of the subscribed url
+func (dir *RegistryDirectory) serviceKey() string {
+	if dir.SubscribedUrl != nil {
+		return dir.SubscribedUrl.ServiceKey()
+	}
+	return dir.consumerURL.ServiceKey()
+}
+
+// spanAttributes returns the attributes of the spans of the registry operations
+func (dir *RegistryDirectory) spanAttributes(serviceKey string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{dubbotrace.ServiceKey.String(serviceKey)}
+	if dir.registry != nil {
+		if url := dir.registry.GetURL(); url != nil {
+			attrs = append(attrs, dubbotrace.RegistryKey.String(url.GetParam(constant.RegistryKey, url.Protocol)))
+		}
+	}
+	return attrs
 }
 
 // refreshInvokers refreshes service's events.
@@ -194,11 +251,16 @@ func (dir *RegistryDirectory) refreshInvokers(event *registry.ServiceEvent) {
 		logger.Debug("refresh invokers with nil")
 	}
 
+	if dir.shouldIgnore(event) {
+		return
+	}
 	var oldInvoker []protocolbase.Invoker
 	if event != nil {
 		oldInvoker, _ = dir.cacheInvokerByEvent(event)
+		dir.confirmAddress(event, false)
 	}
 	dir.setNewInvokers()
+	dir.saveAddresses()
 	for _, v := range oldInvoker {
 		if v != nil {
 			v.Destroy()
@@ -213,6 +275,10 @@ func (dir *RegistryDirectory) refreshAllInvokers(events []*registry.ServiceEvent
 		oldInvokers []protocolbase.Invoker
 		addEvents   []*registry.ServiceEvent
 	)
+	if dir.shouldIgnoreAll(len(events)) {
+		callback()
+		return
+	}
 	dir.overrideUrl(dir.GetDirectoryUrl())
 	referenceUrl := dir.GetDirectoryUrl().SubURL
 
@@ -227,6 +293,7 @@ func (dir *RegistryDirectory) refreshAllInvokers(events []*registry.ServiceEvent
 		newUrl := dir.convertUrl(event)
 		newUrl = newUrl.MergeURL(referenceUrl)
 		dir.overrideUrl(newUrl)
+		doOverrideUrl(dir.appConfigurators(), newUrl)
 		event.Update(newUrl)
 	}
 	// After notify all addresses, do some callback.
@@ -267,6 +334,7 @@ func (dir *RegistryDirectory) refreshAllInvokers(events []*registry.ServiceEvent
 		}
 	}()
 	dir.setNewInvokers()
+	dir.saveAddresses()
 	// destroy unused invokers
 	for _, invoker := range oldInvokers {
 		go invoker.Destroy()
@@ -440,6 +508,7 @@ func (dir *RegistryDirectory) cacheInvoker(url *common.URL, event *registry.Serv
 	if url.Protocol == referenceUrl.Protocol || referenceUrl.Protocol == "" {
 		newUrl := url.MergeURL(referenceUrl)
 		dir.overrideUrl(newUrl)
+		doOverrideUrl(dir.appConfigurators(), newUrl)
 		event.Update(newUrl)
 		if v, ok := dir.doCacheInvoker(newUrl, event); ok {
 			return v
@@ -490,6 +559,13 @@ func (dir *RegistryDirectory) List(invocation protocolbase.Invocation) []protoco
 	return routerChain.Route(dir.consumerURL, invocation)
 }
 
+// AddressCount returns the number of providers known by the directory regardless of routers
+func (dir *RegistryDirectory) AddressCount() int {
+	dir.invokersLock.RLock()
+	defer dir.invokersLock.RUnlock()
+	return len(dir.cacheInvokers)
+}
+
 // IsAvailable  whether the directory is available
 func (dir *RegistryDirectory) IsAvailable() bool {
 	if dir.IsDestroyed() {
@@ -509,6 +585,7 @@ func (dir *RegistryDirectory) IsAvailable() bool {
 func (dir *RegistryDirectory) Destroy() {
 	// TODO:unregister & unsubscribe
 	dir.DoDestroy(func() {
+		dir.removeAppConfiguratorListener()
 		if dir.RegisteredUrl != nil {
 			err := dir.registry.UnRegister(dir.RegisteredUrl)
 			if err != nil {
@@ -538,6 +615,49 @@ func (dir *RegistryDirectory) overrideUrl(targetUrl *common.URL) {
 	doOverrideUrl(dir.referenceConfigurationListener.Configurators(), targetUrl)
 }
 
+// appConfigurators returns the configurators of the application configuration reloaded from the config center
+func (dir *RegistryDirectory) appConfigurators() []config_center.Configurator {
+	return config_center.GetAppConfigurators(common.DubboRole[common.CONSUMER], dir.GetDirectoryUrl().SubURL.ServiceKey())
+}
+
+// processAppConfigurator re-refers the invokers once the application configuration of the reference is reloaded,
+// since some of the params, e.g. timeout, are only read when the invoker is created.
+func (dir *RegistryDirectory) processAppConfigurator(serviceKey string) {
+	if dir.IsDestroyed() || serviceKey != dir.GetDirectoryUrl().SubURL.ServiceKey() {
+		return
+	}
+	configurators := dir.appConfigurators()
+	var oldInvokers []protocolbase.Invoker
+	func() {
+		dir.registerLock.Lock()
+		defer dir.registerLock.Unlock()
+		dir.cacheInvokersMap.Range(func(k, v any) bool {
+			invoker := v.(protocolbase.Invoker)
+			newUrl := invoker.GetURL().Clone()
+			doOverrideUrl(configurators, newUrl)
+			if common.GetCompareURLEqualFunc()(newUrl, invoker.GetURL()) {
+				return true
+			}
+			if newInvoker := extension.GetProtocol(protocolwrapper.FILTER).Refer(newUrl); newInvoker != nil {
+				dir.cacheInvokersMap.Store(k, newInvoker)
+				oldInvokers = append(oldInvokers, invoker)
+			} else {
+				logger.Warnf("[Registry Directory] failed to re-refer %s with the reloaded configuration", newUrl)
+			}
+			return true
+		})
+	}()
+	if len(oldInvokers) == 0 {
+		return
+	}
+	logger.Infof("[Registry Directory] re-refer %d invokers of %s with the reloaded configuration",
+		len(oldInvokers), dir.serviceType)
+	dir.setNewInvokers()
+	for _, invoker := range oldInvokers {
+		go invoker.Destroy()
+	}
+}
+
 func (dir *RegistryDirectory) getConsumerUrl(c *common.URL) *common.URL {
 	processID := fmt.Sprintf("%d", os.Getpid())
 	localIP := common.GetLocalIp()
@@ -647,6 +767,7 @@ func NewServiceDiscoveryRegistryDirectory(url *common.URL, registry registry.Reg
 func (dir *ServiceDiscoveryRegistryDirectory) Subscribe(url *common.URL) error {
 	if err := dir.registry.Subscribe(url, dir); err != nil {
 		logger.Error("registry.Subscribe(url:%v, dir:%v) = error:%v", url, dir, err)
+		dir.loadCachedAddresses()
 		return err
 	}
 
@@ -25,6 +25,11 @@ import (
 
 import (
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 import (
@@ -33,7 +38,9 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
 	"dubbo.apache.org/dubbo-go/v3/global"
+	dubbotrace "dubbo.apache.org/dubbo-go/v3/otel/trace"
 	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/protocol/protocolwrapper"
 	"dubbo.apache.org/dubbo-go/v3/registry"
@@ -226,3 +233,149 @@ func TestToGroupInvokers(t *testing.T) {
 		assert.True(t, len(registryDirectory.toGroupInvokers()) == 2)
 	})
 }
+
+func protectedRegistryDir(t *testing.T, opts ...common.Option) *RegistryDirectory {
+	extension.SetProtocol(protocolwrapper.FILTER, protocolwrapper.NewMockProtocolFilter)
+	url, _ := common.NewURL("mock://127.0.0.1:1111", append(opts,
+		common.WithAttribute(constant.ApplicationKey, &global.ApplicationConfig{Name: "test-application"}))...)
+	url.SubURL, _ = common.NewURL("dubbo://127.0.0.1:20000/org.apache.dubbo-go.mockService",
+		common.WithParamsValue(constant.ClusterKey, "mock"))
+	mockRegistry, _ := registry.NewMockRegistry(&common.URL{})
+	dir, err := NewRegistryDirectory(url, mockRegistry)
+	assert.NoError(t, err)
+	return dir.(*RegistryDirectory)
+}
+
+func providerEvents(ports ...int) []*registry.ServiceEvent {
+	events := make([]*registry.ServiceEvent, 0, len(ports))
+	for _, port := range ports {
+		providerUrl, _ := common.NewURL("dubbo://0.0.0.0:" + strconv.Itoa(port) + "/org.apache.dubbo-go.mockService")
+		events = append(events, &registry.ServiceEvent{Action: remoting.EventTypeUpdate, Service: providerUrl})
+	}
+	return events
+}
+
+func TestEmptyProtection(t *testing.T) {
+	dir := protectedRegistryDir(t,
+		common.WithParamsValue(constant.RegistryEmptyProtectionKey, "true"),
+		common.WithParamsValue(constant.RegistryEmptyProtectionRatioKey, "0.5"))
+	dir.NotifyAll(providerEvents(20001, 20002, 20003, 20004), func() {})
+	assert.Equal(t, 4, dir.AddressCount())
+
+	// empty and sharply shrunk notifications are ignored
+	dir.NotifyAll(providerEvents(), func() {})
+	assert.Equal(t, 4, dir.AddressCount())
+	dir.NotifyAll(providerEvents(20001), func() {})
+	assert.Equal(t, 4, dir.AddressCount())
+	dir.NotifyAll(providerEvents(20001, 20002), func() {})
+	assert.Equal(t, 2, dir.AddressCount())
+
+	// the last address can't be deleted
+	event := providerEvents(20001)[0]
+	event.Action = remoting.EventTypeDel
+	dir.Notify(event)
+	assert.Equal(t, 1, dir.AddressCount())
+	event = providerEvents(20002)[0]
+	event.Action = remoting.EventTypeDel
+	dir.Notify(event)
+	assert.Equal(t, 1, dir.AddressCount())
+}
+
+func TestAddressCache(t *testing.T) {
+	file := t.TempDir() + "/registry.cache"
+	dir := protectedRegistryDir(t,
+		common.WithParamsValue(constant.RegistryAddressCacheKey, "true"),
+		common.WithParamsValue(constant.RegistryAddressCacheFileKey, file))
+	dir.NotifyAll(providerEvents(20001, 20002), func() {})
+	// the empty list is not saved
+	dir.NotifyAll(providerEvents(), func() {})
+	assert.Equal(t, 0, dir.AddressCount())
+
+	// the registry is unreachable, bootstrap by the cached addresses
+	bootstrapped := protectedRegistryDir(t,
+		common.WithParamsValue(constant.RegistryAddressCacheKey, "true"),
+		common.WithParamsValue(constant.RegistryAddressCacheFileKey, file))
+	bootstrapped.loadCachedAddresses()
+	assert.Equal(t, 2, bootstrapped.AddressCount())
+
+	// the addresses not notified by the registry are dropped
+	bootstrapped.Notify(providerEvents(20002)[0])
+	bootstrapped.dropUnconfirmedAddresses()
+	assert.Equal(t, 1, bootstrapped.AddressCount())
+	assert.Equal(t, "20002", bootstrapped.List(invocation.NewRPCInvocation("", nil, nil))[0].GetURL().Port)
+}
+
+func TestAppConfigurator(t *testing.T) {
+	dir := protectedRegistryDir(t)
+	defer dir.Destroy()
+	dir.NotifyAll(providerEvents(20001, 20002), func() {})
+	serviceKey := dir.GetDirectoryUrl().SubURL.ServiceKey()
+	side := common.DubboRole[common.CONSUMER]
+	setOverride := func(params string) {
+		overrideUrl, _ := common.NewURL("override://0.0.0.0:0/org.apache.dubbo-go.mockService?" + params)
+		config_center.SetAppConfigurator(side, serviceKey, extension.GetDefaultConfigurator(overrideUrl))
+	}
+	defer setOverride("")
+
+	// the cached invokers are re-referred with the reloaded configuration
+	setOverride("timeout=5s&methods.Greet.retries=3")
+	invokers := dir.List(invocation.NewRPCInvocation("", nil, nil))
+	assert.Len(t, invokers, 2)
+	for _, invoker := range invokers {
+		assert.Equal(t, "5s", invoker.GetURL().GetParam(constant.TimeoutKey, ""))
+		assert.Equal(t, "3", invoker.GetURL().GetParam("methods.Greet.retries", ""))
+	}
+
+	// and the new addresses as well
+	dir.NotifyAll(providerEvents(20001, 20002, 20003), func() {})
+	for _, invoker := range dir.List(invocation.NewRPCInvocation("", nil, nil)) {
+		assert.Equal(t, "5s", invoker.GetURL().GetParam(constant.TimeoutKey, ""))
+	}
+}
+
+// watchingRegistry notifies once and keeps watching until it's destroyed, like the registries based on BaseRegistry
+type watchingRegistry struct {
+	registry.Registry
+	event *registry.ServiceEvent
+	done  chan struct{}
+}
+
+func (r *watchingRegistry) Subscribe(_ *common.URL, notifyListener registry.NotifyListener) error {
+	notifyListener.Notify(r.event)
+	<-r.done
+	return nil
+}
+
+func TestSubscribeSpan(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	origin := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(origin)
+
+	extension.SetProtocol(protocolwrapper.FILTER, protocolwrapper.NewMockProtocolFilter)
+	url, _ := common.NewURL("mock://127.0.0.1:1111",
+		common.WithAttribute(constant.ApplicationKey, &global.ApplicationConfig{Name: "test-application"}))
+	suburl, _ := common.NewURL("dubbo://127.0.0.1:20000/org.apache.dubbo-go.mockService",
+		common.WithParamsValue(constant.ClusterKey, "mock"))
+	url.SubURL = suburl
+	mockRegistry, _ := registry.NewMockRegistry(&common.URL{})
+	reg := &watchingRegistry{
+		Registry: mockRegistry,
+		event: &registry.ServiceEvent{Action: remoting.EventTypeAdd,
+			Service: common.NewURLWithOptions(common.WithPath("TEST0"), common.WithProtocol("dubbo"))},
+		done: make(chan struct{}),
+	}
+	defer close(reg.done)
+	dir, err := NewRegistryDirectory(url, reg)
+	require.NoError(t, err)
+	require.NoError(t, dir.(*RegistryDirectory).Subscribe(suburl))
+
+	// the subscribe span ends at the first notification though the registry keeps watching
+	assert.Eventually(t, func() bool {
+		var names []string
+		for _, span := range recorder.Ended() {
+			names = append(names, span.Name())
+		}
+		return assert.ObjectsAreEqual([]string{dubbotrace.SpanRegistrySubscribe, dubbotrace.SpanRegistryNotify}, names)
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,237 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migration
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	"go.uber.org/atomic"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/cluster/directory"
+	"dubbo.apache.org/dubbo-go/v3/common"
+	conf "dubbo.apache.org/dubbo-go/v3/common/config"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+// Path is the discovery path serving the invocations
+type Path string
+
+const (
+	PathInterface   Path = "interface"
+	PathApplication Path = "application"
+)
+
+// checkInterval is the interval to compare the addresses of both paths again in APPLICATION_FIRST step,
+// since the addresses keep changing after the subscription.
+const checkInterval = 5 * time.Second
+
+// Target is the cluster invoker of a discovery path and its directory
+type Target struct {
+	Directory directory.Directory
+	Invoker   base.Invoker
+}
+
+// Status reports the state of the migration
+type Status struct {
+	Step                 Step
+	Threshold            float64
+	Active               Path
+	InterfaceAddresses   int
+	ApplicationAddresses int
+}
+
+// Invoker subscribes to both interface-level and application-level discovery, and routes the invocations
+// to one of them according to the migration rule from the config center.
+type Invoker struct {
+	url              *common.URL
+	interfaceTarget  Target
+	appTarget        Target
+	defaultStep      Step
+	defaultThreshold float64
+	ruleKey          string
+	lastCheck        *atomic.Time
+
+	mu     sync.RWMutex
+	status Status
+}
+
+// NewInvoker creates the migration invoker of the consumer url. The default step and threshold are read from
+// the params of registryURL, which are overridden by the migration rule of the application.
+func NewInvoker(registryURL *common.URL, interfaceTarget, appTarget Target) *Invoker {
+	step := Step(strings.ToUpper(registryURL.GetParam(constant.MigrationStepKey, constant.DefaultMigrationStep)))
+	if !step.valid() {
+		logger.Warnf("[Registry Migration] unknown migration step %s, use %s", step, constant.DefaultMigrationStep)
+		step = constant.DefaultMigrationStep
+	}
+	threshold, err := strconv.ParseFloat(registryURL.GetParam(constant.MigrationThresholdKey, ""), 64)
+	if err != nil {
+		threshold = constant.DefaultMigrationThreshold
+	}
+	inv := &Invoker{
+		url:              registryURL.SubURL,
+		interfaceTarget:  interfaceTarget,
+		appTarget:        appTarget,
+		defaultStep:      step,
+		defaultThreshold: threshold,
+		lastCheck:        atomic.NewTime(time.Now()),
+	}
+	inv.apply(nil)
+	inv.subscribe()
+	return inv
+}
+
+// subscribe listens to the migration rule of the application
+func (i *Invoker) subscribe() {
+	application := i.url.GetParam(constant.ApplicationKey, "")
+	dynamicConfiguration := conf.GetEnvInstance().GetDynamicConfiguration()
+	if application == "" || dynamicConfiguration == nil {
+		return
+	}
+	i.ruleKey = application + constant.MigrationRuleSuffix
+	dynamicConfiguration.AddListener(i.ruleKey, i, config_center.WithGroup(constant.MigrationRuleGroup))
+	value, err := dynamicConfiguration.GetRule(i.ruleKey, config_center.WithGroup(constant.MigrationRuleGroup))
+	if err != nil {
+		logger.Warnf("[Registry Migration] failed to get the migration rule %s: %v", i.ruleKey, err)
+		return
+	}
+	if value != "" {
+		i.Process(&config_center.ConfigChangeEvent{Key: i.ruleKey, Value: value, ConfigType: remoting.EventTypeAdd})
+	}
+}
+
+// Process applies the migration rule once it changes
+func (i *Invoker) Process(event *config_center.ConfigChangeEvent) {
+	if event.ConfigType == remoting.EventTypeDel {
+		i.apply(nil)
+		return
+	}
+	content, _ := event.Value.(string)
+	rule, err := ParseRule(content)
+	if err != nil {
+		logger.Warnf("[Registry Migration] invalid migration rule %s, keep the current step: %v", event.Key, err)
+		return
+	}
+	i.apply(rule)
+}
+
+// apply resolves the step of the rule and switches the path if necessary
+func (i *Invoker) apply(rule *Rule) {
+	step, threshold := rule.Resolve(i.url.Service(), i.url.ServiceKey(), i.defaultStep, i.defaultThreshold)
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.status.Step, i.status.Threshold = step, threshold
+	i.refreshLocked()
+}
+
+// refreshLocked compares the addresses of both paths and decides the active path
+func (i *Invoker) refreshLocked() {
+	i.lastCheck.Store(time.Now())
+	i.status.InterfaceAddresses = addressCount(i.interfaceTarget.Directory)
+	i.status.ApplicationAddresses = addressCount(i.appTarget.Directory)
+
+	active := PathInterface
+	switch i.status.Step {
+	case StepForceApplication:
+		active = PathApplication
+	case StepApplicationFirst:
+		app, itf := i.status.ApplicationAddresses, i.status.InterfaceAddresses
+		if app > 0 && (itf == 0 || float64(app)/float64(itf) >= i.status.Threshold) {
+			active = PathApplication
+		}
+	}
+	if active != i.status.Active {
+		logger.Infof("[Registry Migration] %s switches to %s discovery, step: %s, threshold: %v, "+
+			"interface addresses: %d, application addresses: %d", i.url.ServiceKey(), active, i.status.Step,
+			i.status.Threshold, i.status.InterfaceAddresses, i.status.ApplicationAddresses)
+		i.status.Active = active
+	}
+}
+
+// Status returns the current state of the migration
+func (i *Invoker) Status() Status {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.status
+}
+
+// Invoke routes the invocation to the active path
+func (i *Invoker) Invoke(ctx context.Context, inv base.Invocation) result.Result {
+	return i.target().Invoker.Invoke(ctx, inv)
+}
+
+func (i *Invoker) target() Target {
+	i.mu.RLock()
+	status := i.status
+	i.mu.RUnlock()
+	if status.Step == StepApplicationFirst && time.Since(i.lastCheck.Load()) > checkInterval {
+		i.mu.Lock()
+		if time.Since(i.lastCheck.Load()) > checkInterval {
+			i.refreshLocked()
+		}
+		status = i.status
+		i.mu.Unlock()
+	}
+	if status.Active == PathApplication {
+		return i.appTarget
+	}
+	return i.interfaceTarget
+}
+
+// GetURL returns the url of the active path
+func (i *Invoker) GetURL() *common.URL {
+	return i.target().Invoker.GetURL()
+}
+
+// IsAvailable returns whether the active path is available
+func (i *Invoker) IsAvailable() bool {
+	return i.target().Invoker.IsAvailable()
+}
+
+// Destroy stops listening to the migration rule and destroys both paths
+func (i *Invoker) Destroy() {
+	if i.ruleKey != "" {
+		if dynamicConfiguration := conf.GetEnvInstance().GetDynamicConfiguration(); dynamicConfiguration != nil {
+			dynamicConfiguration.RemoveListener(i.ruleKey, i, config_center.WithGroup(constant.MigrationRuleGroup))
+		}
+	}
+	i.interfaceTarget.Invoker.Destroy()
+	i.appTarget.Invoker.Destroy()
+}
+
+// addressCount returns the number of providers in the directory
+func addressCount(dir directory.Directory) int {
+	if counter, ok := dir.(interface{ AddressCount() int }); ok {
+		return counter.AddressCount()
+	}
+	return len(dir.List(invocation.NewRPCInvocation("", nil, nil)))
+}
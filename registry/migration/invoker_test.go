@@ -0,0 +1,140 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package migration
+
+import (
+	"context"
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/cluster/directory/base"
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/config_center"
+	protocolbase "dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+type mockDirectory struct {
+	*base.Directory
+	count int
+}
+
+func (d *mockDirectory) List(protocolbase.Invocation) []protocolbase.Invoker { return nil }
+func (d *mockDirectory) Subscribe(*common.URL) error                         { return nil }
+func (d *mockDirectory) IsAvailable() bool                                   { return d.count > 0 }
+func (d *mockDirectory) AddressCount() int                                   { return d.count }
+func (d *mockDirectory) Destroy()                                            {}
+
+type mockInvoker struct {
+	*protocolbase.BaseInvoker
+	path Path
+	dir  *mockDirectory
+}
+
+func (i *mockInvoker) IsAvailable() bool {
+	return i.dir.IsAvailable()
+}
+
+func (i *mockInvoker) Invoke(context.Context, protocolbase.Invocation) result.Result {
+	return &result.RPCResult{Rest: i.path}
+}
+
+func newTarget(t *testing.T, path Path, count int) (Target, *mockDirectory) {
+	url, err := common.NewURL("mock://127.0.0.1:2181")
+	require.NoError(t, err)
+	dir := &mockDirectory{Directory: base.NewDirectory(url), count: count}
+	return Target{Directory: dir, Invoker: &mockInvoker{BaseInvoker: protocolbase.NewBaseInvoker(url), path: path, dir: dir}}, dir
+}
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule(`
+key: demo-consumer
+step: application_first
+threshold: 0.5
+interfaces:
+  - serviceKey: com.demo.Greeter
+    step: FORCE_APPLICATION
+  - serviceKey: group/com.demo.Counter:1.0
+    threshold: 2
+`)
+	require.NoError(t, err)
+	step, threshold := rule.Resolve("com.demo.User", "com.demo.User", StepForceInterface, 1)
+	assert.Equal(t, StepApplicationFirst, step)
+	assert.Equal(t, 0.5, threshold)
+	step, _ = rule.Resolve("com.demo.Greeter", "com.demo.Greeter", StepForceInterface, 1)
+	assert.Equal(t, StepForceApplication, step)
+	step, threshold = rule.Resolve("com.demo.Counter", "group/com.demo.Counter:1.0", StepForceInterface, 1)
+	assert.Equal(t, StepApplicationFirst, step)
+	assert.Equal(t, float64(2), threshold)
+
+	var empty *Rule
+	step, threshold = empty.Resolve("com.demo.User", "com.demo.User", StepForceInterface, 1)
+	assert.Equal(t, StepForceInterface, step)
+	assert.Equal(t, float64(1), threshold)
+
+	_, err = ParseRule("step: UNKNOWN")
+	assert.Error(t, err)
+}
+
+func TestInvoker(t *testing.T) {
+	registryURL, err := common.NewURL("service-discovery-registry://127.0.0.1:2181?migration.threshold=0.5")
+	require.NoError(t, err)
+	registryURL.SubURL, err = common.NewURL("consumer://127.0.0.1/com.demo.Greeter?interface=com.demo.Greeter")
+	require.NoError(t, err)
+	interfaceTarget, _ := newTarget(t, PathInterface, 4)
+	appTarget, appDir := newTarget(t, PathApplication, 1)
+
+	inv := NewInvoker(registryURL, interfaceTarget, appTarget)
+	invoke := func() any {
+		return inv.Invoke(context.Background(), invocation.NewRPCInvocation("Greet", nil, nil)).Result()
+	}
+	// 1/4 application addresses is below the threshold
+	assert.Equal(t, Status{Step: StepApplicationFirst, Threshold: 0.5, Active: PathInterface,
+		InterfaceAddresses: 4, ApplicationAddresses: 1}, inv.Status())
+	assert.Equal(t, PathInterface, invoke())
+
+	appDir.count = 2
+	inv.Process(&config_center.ConfigChangeEvent{Value: "step: APPLICATION_FIRST", ConfigType: remoting.EventTypeUpdate})
+	assert.Equal(t, PathApplication, inv.Status().Active)
+	assert.Equal(t, PathApplication, invoke())
+
+	inv.Process(&config_center.ConfigChangeEvent{Value: "step: FORCE_INTERFACE", ConfigType: remoting.EventTypeUpdate})
+	assert.Equal(t, PathInterface, invoke())
+
+	// an invalid rule doesn't change the step
+	inv.Process(&config_center.ConfigChangeEvent{Value: "step: UNKNOWN", ConfigType: remoting.EventTypeUpdate})
+	assert.Equal(t, StepForceInterface, inv.Status().Step)
+
+	appDir.count = 0
+	inv.Process(&config_center.ConfigChangeEvent{Value: "step: FORCE_APPLICATION", ConfigType: remoting.EventTypeUpdate})
+	assert.Equal(t, PathApplication, invoke())
+	assert.False(t, inv.IsAvailable())
+
+	// the defaults are restored once the rule is deleted
+	inv.Process(&config_center.ConfigChangeEvent{ConfigType: remoting.EventTypeDel})
+	assert.Equal(t, StepApplicationFirst, inv.Status().Step)
+	assert.Equal(t, PathInterface, invoke())
+}
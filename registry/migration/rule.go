@@ -0,0 +1,116 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package migration migrates consumers from interface-level discovery to application-level discovery.
+package migration
+
+import (
+	"fmt"
+	"strings"
+)
+
+import (
+	"gopkg.in/yaml.v2"
+)
+
+// Step is the stage of the migration
+type Step string
+
+const (
+	// StepForceInterface only uses the addresses of interface-level discovery
+	StepForceInterface Step = "FORCE_INTERFACE"
+	// StepApplicationFirst uses the addresses of application-level discovery if there are enough of them
+	// compared with interface-level discovery, otherwise falls back to interface-level discovery
+	StepApplicationFirst Step = "APPLICATION_FIRST"
+	// StepForceApplication only uses the addresses of application-level discovery
+	StepForceApplication Step = "FORCE_APPLICATION"
+)
+
+func (s Step) valid() bool {
+	return s == StepForceInterface || s == StepApplicationFirst || s == StepForceApplication
+}
+
+// Rule is the migration rule published to the config center with the key {application}.migration
+// and the group MIGRATION, for example:
+//
+//	key: demo-consumer
+//	step: APPLICATION_FIRST
+//	threshold: 0.8
+//	interfaces:
+//	  - serviceKey: org.apache.dubbo.demo.DemoService
+//	    step: FORCE_APPLICATION
+type Rule struct {
+	Key        string           `yaml:"key"`
+	Step       Step             `yaml:"step"`
+	Threshold  *float64         `yaml:"threshold"`
+	Interfaces []*InterfaceRule `yaml:"interfaces"`
+}
+
+// InterfaceRule overrides the rule of application for an interface
+type InterfaceRule struct {
+	// ServiceKey is the interface name, or the service key in the form of group/interface:version
+	ServiceKey string   `yaml:"serviceKey"`
+	Step       Step     `yaml:"step"`
+	Threshold  *float64 `yaml:"threshold"`
+}
+
+// ParseRule parses the migration rule in yaml
+func ParseRule(content string) (*Rule, error) {
+	rule := &Rule{}
+	if err := yaml.Unmarshal([]byte(content), rule); err != nil {
+		return nil, err
+	}
+	rule.Step = Step(strings.ToUpper(string(rule.Step)))
+	if rule.Step != "" && !rule.Step.valid() {
+		return nil, fmt.Errorf("unknown migration step %s", rule.Step)
+	}
+	for _, ir := range rule.Interfaces {
+		ir.Step = Step(strings.ToUpper(string(ir.Step)))
+		if ir.Step != "" && !ir.Step.valid() {
+			return nil, fmt.Errorf("unknown migration step %s of %s", ir.Step, ir.ServiceKey)
+		}
+	}
+	return rule, nil
+}
+
+// Resolve returns the step and the threshold of the service, the interface rule takes precedence over
+// the application rule, and the defaults are used if neither of them is set.
+func (r *Rule) Resolve(interfaceName, serviceKey string, defaultStep Step, defaultThreshold float64) (Step, float64) {
+	step, threshold := defaultStep, defaultThreshold
+	if r == nil {
+		return step, threshold
+	}
+	if r.Step != "" {
+		step = r.Step
+	}
+	if r.Threshold != nil {
+		threshold = *r.Threshold
+	}
+	for _, ir := range r.Interfaces {
+		if ir.ServiceKey != interfaceName && ir.ServiceKey != serviceKey {
+			continue
+		}
+		if ir.Step != "" {
+			step = ir.Step
+		}
+		if ir.Threshold != nil {
+			threshold = *ir.Threshold
+		}
+		break
+	}
+	return step, threshold
+}
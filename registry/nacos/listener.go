@@ -51,6 +51,8 @@ var (
 type callback func(services []model.Instance, err error)
 
 type nacosListener struct {
+	// clientLock guards namingClient, the read lock is held during the calls on it
+	clientLock     sync.RWMutex
 	namingClient   *nacosClient.NacosNamingClient
 	serviceName    string
 	regURL         *common.URL
@@ -174,6 +176,8 @@ func getSubscribeName(url *common.URL) string {
 }
 
 func (nl *nacosListener) listenService(serviceName string) error {
+	nl.clientLock.RLock()
+	defer nl.clientLock.RUnlock()
 	if nl.namingClient == nil {
 		return perrors.New("nacos naming namingClient stopped")
 	}
@@ -190,9 +194,23 @@ func (nl *nacosListener) listenService(serviceName string) error {
 }
 
 func (nl *nacosListener) stopListen() error {
+	nl.clientLock.RLock()
+	defer nl.clientLock.RUnlock()
 	return nl.namingClient.Client().Unsubscribe(nl.subscribeParam)
 }
 
+// switchClient subscribes the service by newClient if the listener is using oldClient, it returns false
+// if the listener is using another client.
+func (nl *nacosListener) switchClient(oldClient, newClient *nacosClient.NacosNamingClient) (bool, error) {
+	nl.clientLock.Lock()
+	defer nl.clientLock.Unlock()
+	if nl.namingClient != oldClient {
+		return false, nil
+	}
+	nl.namingClient = newClient
+	return true, newClient.Client().Subscribe(nl.subscribeParam)
+}
+
 func (nl *nacosListener) process(configType *config_center.ConfigChangeEvent) {
 	nl.events.In() <- configType
 }
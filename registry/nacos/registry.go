@@ -52,13 +52,21 @@ const (
 	checkInterval  = 5 * time.Second
 )
 
+// newNamingClient creates the nacos client with the rotated credentials
+var newNamingClient = nacos.NewUnsharedNacosClientByURL
+
 func init() {
 	extension.SetRegistry(constant.NacosKey, newNacosRegistry)
 }
 
 type nacosRegistry struct {
 	*common.URL
+	// clientLock guards namingClient, the read lock is held during the calls on it, so that the client replaced
+	// by UpdateCredentials is closed after the calls in flight
+	clientLock   sync.RWMutex
 	namingClient *nacosClient.NacosNamingClient
+	// registryLock guards registryUrls, it is held during the registration
+	registryLock sync.Mutex
 	registryUrls []*common.URL
 	done         chan struct{}
 	availability availabilityCache
@@ -137,12 +145,24 @@ func createRegisterParam(url *common.URL, serviceName string, groupName string)
 
 // Register will register the service @url to its nacos registry center.
 func (nr *nacosRegistry) Register(url *common.URL) error {
+	nr.registryLock.Lock()
+	defer nr.registryLock.Unlock()
+	if err := nr.register(url); err != nil {
+		return err
+	}
+	nr.registryUrls = append(nr.registryUrls, url)
+	return nil
+}
+
+func (nr *nacosRegistry) register(url *common.URL) error {
 	start := time.Now()
 	serviceName := getServiceName(url)
 	groupName := nr.GetParam(constant.NacosGroupKey, defaultGroup)
 	param := createRegisterParam(url, serviceName, groupName)
 	logger.Infof("[Nacos Registry] Registry instance with param = %+v", param)
+	nr.clientLock.RLock()
 	isRegistry, err := nr.namingClient.Client().RegisterInstance(param)
+	nr.clientLock.RUnlock()
 	metrics.Publish(metricsRegistry.NewRegisterEvent(err == nil && isRegistry, start))
 	if err != nil {
 		return err
@@ -150,10 +170,63 @@ func (nr *nacosRegistry) Register(url *common.URL) error {
 	if !isRegistry {
 		return perrors.New("registry [" + serviceName + "] to  nacos failed")
 	}
-	nr.registryUrls = append(nr.registryUrls, url)
 	return nil
 }
 
+// UpdateCredentials re-creates the nacos client with the rotated credentials, then registers the urls and
+// subscribes the services again by the new client
+func (nr *nacosRegistry) UpdateCredentials(username, password string) error {
+	url := nr.URL.Clone()
+	url.SetParam(constant.NacosUsername, username)
+	url.SetParam(constant.NacosPassword, password)
+	namingClient, err := newNamingClient(url)
+	if err != nil {
+		return perrors.WithMessage(err, "re-create the nacos client failed")
+	}
+
+	// the registrations in progress are done by the old client, so they are copied after the client is replaced
+	nr.registryLock.Lock()
+	nr.clientLock.Lock()
+	oldClient := nr.namingClient
+	nr.namingClient = namingClient
+	nr.SetParam(constant.NacosUsername, username)
+	nr.SetParam(constant.NacosPassword, password)
+	nr.clientLock.Unlock()
+	registryUrls := make([]*common.URL, len(nr.registryUrls))
+	copy(registryUrls, nr.registryUrls)
+	nr.registryLock.Unlock()
+
+	for _, registryUrl := range registryUrls {
+		if err = nr.register(registryUrl); err != nil {
+			logger.Errorf("[Nacos Registry] Register %s again failed, err: %v", registryUrl.Key(), err)
+		}
+	}
+	listenerCache.Range(func(_, value any) bool {
+		listener, ok := value.(*nacosListener)
+		if !ok {
+			return true
+		}
+		if switched, err := listener.switchClient(oldClient, namingClient); switched && err != nil {
+			logger.Errorf("[Nacos Registry] Subscribe %s again failed, err: %v", listener.serviceName, err)
+		}
+		return true
+	})
+	// neither the registry nor the listeners call the old client anymore
+	if oldClient != nil {
+		closeNamingClient(oldClient)
+	}
+	return err
+}
+
+// closeNamingClient releases the nacos client, which is closed once nobody shares it
+func closeNamingClient(namingClient *nacosClient.NacosNamingClient) {
+	client := namingClient.Client()
+	namingClient.Close()
+	if client != nil && namingClient.Client() == nil {
+		client.CloseClient()
+	}
+}
+
 func createDeregisterParam(url *common.URL, serviceName string, groupName string) vo.DeregisterInstanceParam {
 	common.HandleRegisterIPAndPort(url)
 	port, _ := strconv.Atoi(url.Port)
@@ -171,7 +244,9 @@ func (nr *nacosRegistry) UnRegister(url *common.URL) error {
 	serviceName := getServiceName(url)
 	groupName := nr.GetParam(constant.NacosGroupKey, defaultGroup)
 	param := createDeregisterParam(url, serviceName, groupName)
+	nr.clientLock.RLock()
 	isDeRegistry, err := nr.namingClient.Client().DeregisterInstance(param)
+	nr.clientLock.RUnlock()
 	if err != nil {
 		return err
 	}
@@ -253,9 +328,11 @@ func (nr *nacosRegistry) subscribe(serviceName string, notifyListener registry.N
 		logger.Warnf("event listener game over.")
 		return perrors.New("nacosRegistry is not available.")
 	}
+	// the listener is added to listenerCache when subscribe success, before the client can be replaced
+	nr.clientLock.RLock()
 	listener := NewNacosListenerWithServiceName(serviceName, nr.URL, nr.namingClient)
-	// will add to listenerCache when subscribe success
 	err := listener.listenService(serviceName)
+	nr.clientLock.RUnlock()
 	metrics.Publish(metricsRegistry.NewSubscribeEvent(err == nil))
 	if err != nil {
 		logger.Warnf("subscribe service %s err:%v", serviceName, perrors.WithStack(err))
@@ -272,11 +349,13 @@ func (nr *nacosRegistry) subscribe(serviceName string, notifyListener registry.N
 
 // getAllServices retrieves the list of all services from the registry
 func (nr *nacosRegistry) getAllSubscribeServiceNames(url *common.URL) ([]string, error) {
+	nr.clientLock.RLock()
 	services, err := nr.namingClient.Client().GetAllServicesInfo(vo.GetAllServiceInfoParam{
 		GroupName: nr.GetParam(constant.RegistryGroupKey, defaultGroup),
 		PageNo:    1,
 		PageSize:  math.MaxInt32,
 	})
+	nr.clientLock.RUnlock()
 	if err != nil {
 		logger.Errorf("query services error: %v", err)
 		return nil, err
@@ -322,7 +401,9 @@ func (nr *nacosRegistry) UnSubscribe(url *common.URL, _ registry.NotifyListener)
 	if param == nil {
 		return nil
 	}
+	nr.clientLock.RLock()
 	err := nr.namingClient.Client().Unsubscribe(param)
+	nr.clientLock.RUnlock()
 	if err != nil {
 		return perrors.New("UnSubscribe [" + param.ServiceName + "] to nacos failed")
 	}
@@ -333,10 +414,12 @@ func (nr *nacosRegistry) UnSubscribe(url *common.URL, _ registry.NotifyListener)
 func (nr *nacosRegistry) LoadSubscribeInstances(url *common.URL, notify registry.NotifyListener) error {
 	serviceName := getSubscribeName(url)
 	groupName := nr.GetURL().GetParam(constant.RegistryGroupKey, defaultGroup)
+	nr.clientLock.RLock()
 	instances, err := nr.namingClient.Client().SelectAllInstances(vo.SelectAllInstancesParam{
 		ServiceName: serviceName,
 		GroupName:   groupName,
 	})
+	nr.clientLock.RUnlock()
 	if err != nil {
 		return perrors.New(fmt.Sprintf("could not query the instances for serviceName=%s,groupName=%s,error=%v",
 			serviceName, groupName, err))
@@ -393,6 +476,8 @@ func (nr *nacosRegistry) IsAvailable() bool {
 
 	ac.lastCheckTime = time.Now()
 
+	nr.clientLock.RLock()
+	defer nr.clientLock.RUnlock()
 	if nr.namingClient == nil || nr.namingClient.Client() == nil {
 		ac.lastAvailable = false
 		return false
@@ -419,6 +504,8 @@ func (nr *nacosRegistry) Destroy() {
 
 	nr.wg.Wait()
 
+	nr.registryLock.Lock()
+	defer nr.registryLock.Unlock()
 	for _, url := range nr.registryUrls {
 		err := nr.UnRegister(url)
 		logger.Infof("DeRegister Nacos URL:%+v", url)
@@ -466,6 +553,8 @@ func (nr *nacosRegistry) CloseListener() {
 }
 
 func (nr *nacosRegistry) CloseAndNilClient() {
+	nr.clientLock.Lock()
+	defer nr.clientLock.Unlock()
 	if nr.namingClient != nil && nr.namingClient.Client() != nil {
 		nr.namingClient.Client().CloseClient()
 		nr.namingClient = nil
@@ -33,6 +33,8 @@ import (
 
 	"github.com/nacos-group/nacos-sdk-go/v2/model"
 	"github.com/nacos-group/nacos-sdk-go/v2/vo"
+
+	"github.com/stretchr/testify/assert"
 )
 
 import (
@@ -608,3 +610,46 @@ func TestNacosRegistryCloseListener(t *testing.T) {
 		t.Errorf("nl.done channel was not closed")
 	}
 }
+
+func TestNacosRegistryUpdateCredentials(t *testing.T) {
+	oldClient, newClient := newCredentialClient(), newCredentialClient()
+	replaceNamingClient(t, newClient)
+	nc := &nacosClient.NacosNamingClient{}
+	nc.SetClient(oldClient)
+	regURL, _ := common.NewURL("registry://127.0.0.1:8848", common.WithParamsValue(constant.ClientNameKey, "nacos-client"))
+	nr := newNacosRegistryForTest(fields{URL: regURL, namingClient: nc})
+	defer nr.CloseListener()
+
+	var wg sync.WaitGroup
+	var services []string
+	for i := 0; i < 10; i++ {
+		serviceURL, _ := common.NewURL("dubbo://127.0.0.1:20000/com.example.Service"+strconv.Itoa(i),
+			common.WithParamsValue(constant.InterfaceKey, "com.example.Service"+strconv.Itoa(i)),
+			common.WithParamsValue(constant.RegistryRoleKey, strconv.Itoa(common.PROVIDER)))
+		consumerURL := serviceURL.Clone()
+		consumerURL.SetParam(constant.RegistryRoleKey, strconv.Itoa(common.CONSUMER))
+		services = append(services, getServiceName(serviceURL), getSubscribeName(consumerURL))
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, nr.Register(serviceURL))
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, nr.Subscribe(consumerURL, &testNotify{}))
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, nr.UpdateCredentials("user", "rotated"))
+	}()
+	wg.Wait()
+
+	assert.Equal(t, "rotated", nr.GetParam(constant.NacosPassword, ""))
+	assert.Zero(t, oldClient.lateCalls)
+	for i := 0; i < len(services); i += 2 {
+		assert.True(t, newClient.registered[services[i]], services[i])
+		assert.True(t, newClient.subscribed[services[i+1]], services[i+1])
+	}
+}
@@ -62,8 +62,13 @@ type nacosServiceDiscovery struct {
 	// descriptor is a short string about the basic information of this instance
 	descriptor string
 
+	// clientLock guards namingClient, the read lock is held during the calls on it, so that the client replaced
+	// by UpdateCredentials is closed after the calls in flight
+	clientLock sync.RWMutex
 	// namingClient is the Nacos' namingClient
 	namingClient *nacosClient.NacosNamingClient
+	// registryLock guards registryInstances and serviceNameInstancesMap, it is held during the registration
+	registryLock sync.Mutex
 	// cache registry instances
 	registryInstances []registry.ServiceInstance
 
@@ -71,6 +76,8 @@ type nacosServiceDiscovery struct {
 
 	// registryURL stores the URL used for registration, used to fetch dynamic config like weight
 	registryURL *common.URL
+	// discoveryURL is the URL creating the nacos client
+	discoveryURL *common.URL
 
 	instanceListenerMap map[string]*gxset.HashSet
 	listenerLock        sync.Mutex
@@ -79,7 +86,10 @@ type nacosServiceDiscovery struct {
 // Destroy will close the service discovery.
 // Actually, it only marks the naming namingClient as null and then return
 func (n *nacosServiceDiscovery) Destroy() error {
-	for _, inst := range n.registryInstances {
+	n.registryLock.Lock()
+	registryInstances := n.registryInstances
+	n.registryLock.Unlock()
+	for _, inst := range registryInstances {
 		err := n.Unregister(inst)
 		logger.Infof("Unregister nacos instance:%+v", inst)
 		if err != nil {
@@ -90,6 +100,8 @@ func (n *nacosServiceDiscovery) Destroy() error {
 	// Clean up listeners to prevent potential leaks
 	n.listenerLock.Lock()
 	defer n.listenerLock.Unlock()
+	n.clientLock.Lock()
+	defer n.clientLock.Unlock()
 	// Unsubscribe from all services to stop callbacks
 	for serviceName := range n.instanceListenerMap {
 		err := n.namingClient.Client().Unsubscribe(&vo.SubscribeParam{
@@ -109,13 +121,17 @@ func (n *nacosServiceDiscovery) Destroy() error {
 
 // Register will register the service to nacos
 func (n *nacosServiceDiscovery) Register(instance registry.ServiceInstance) error {
+	n.registryLock.Lock()
+	defer n.registryLock.Unlock()
 	instSrvName := instance.GetServiceName()
 	if n.serviceNameInstancesMap == nil {
 		n.serviceNameInstancesMap = make(map[string][]registry.ServiceInstance)
 	}
 	n.serviceNameInstancesMap[instSrvName] = append(n.serviceNameInstancesMap[instSrvName], instance)
 	brins := n.toBatchRegisterInstances(n.serviceNameInstancesMap[instSrvName])
+	n.clientLock.RLock()
 	ok, err := n.namingClient.Client().BatchRegisterInstance(brins)
+	n.clientLock.RUnlock()
 	if err != nil || !ok {
 		return perrors.Errorf("register nacos instances failed, err:%+v", err)
 	}
@@ -138,7 +154,9 @@ func (n *nacosServiceDiscovery) Update(instance registry.ServiceInstance) error
 
 // Unregister will unregister the instance
 func (n *nacosServiceDiscovery) Unregister(instance registry.ServiceInstance) error {
+	n.clientLock.RLock()
 	ok, err := n.namingClient.Client().DeregisterInstance(n.toDeregisterInstance(instance))
+	n.clientLock.RUnlock()
 	if err != nil || !ok {
 		return perrors.WithMessage(err, "Could not unregister the instance. "+instance.GetServiceName())
 	}
@@ -158,11 +176,13 @@ func (n *nacosServiceDiscovery) GetServices() *gxset.HashSet {
 	const pattern = `^providers:[\w\.]+(?::[\w\.]*:|::[\w\.]*)?$`
 	re := regexp.MustCompile(pattern)
 	for pageNo := uint32(1); ; pageNo++ {
+		n.clientLock.RLock()
 		services, err := n.namingClient.Client().GetAllServicesInfo(vo.GetAllServiceInfoParam{
 			PageSize:  uint32(n.GetDefaultPageSize()),
 			PageNo:    pageNo,
 			GroupName: n.group,
 		})
+		n.clientLock.RUnlock()
 
 		if err != nil {
 			logger.Errorf("Could not query the services: %v", err)
@@ -182,10 +202,12 @@ func (n *nacosServiceDiscovery) GetServices() *gxset.HashSet {
 
 // GetInstances will return the instances of serviceName and the group
 func (n *nacosServiceDiscovery) GetInstances(serviceName string) []registry.ServiceInstance {
+	n.clientLock.RLock()
 	instances, err := n.namingClient.Client().SelectAllInstances(vo.SelectAllInstancesParam{
 		ServiceName: serviceName,
 		GroupName:   n.group,
 	})
+	n.clientLock.RUnlock()
 	if err != nil {
 		logger.Errorf("Could not query the instances for service: %+v, group: %+v . It happened err %+v",
 			serviceName, n.group, err)
@@ -286,46 +308,9 @@ func (n *nacosServiceDiscovery) AddListener(listener registry.ServiceInstancesCh
 	n.registerInstanceListener(listener)
 	for _, t := range listener.GetServiceNames().Values() {
 		serviceName := t.(string)
-		err := n.namingClient.Client().Subscribe(&vo.SubscribeParam{
-			ServiceName: serviceName,
-			GroupName:   n.group,
-			SubscribeCallback: func(services []model.Instance, err error) {
-				if err != nil {
-					logger.Errorf("Could not handle the subscribe notification because the err is not nil."+
-						" service name: %s, err: %v", serviceName, err)
-				}
-				instances := make([]registry.ServiceInstance, 0, len(services))
-				for _, service := range services {
-					// we won't use the nacos instance id here but use our instance id
-					metadata := service.Metadata
-					id := metadata[idKey]
-
-					delete(metadata, idKey)
-
-					instances = append(instances, &registry.DefaultServiceInstance{
-						ID:          id,
-						ServiceName: serviceName,
-						Host:        service.Ip,
-						Port:        int(service.Port),
-						Weight:      int64(math.Round(service.Weight)),
-						Enable:      service.Enable,
-						Healthy:     true,
-						Metadata:    metadata,
-						GroupName:   n.group,
-					})
-				}
-
-				var e error
-				for _, lis := range n.instanceListenerMap[serviceName].Values() {
-					instanceListener := lis.(registry.ServiceInstancesChangedListener)
-					e = instanceListener.OnEvent(registry.NewServiceInstancesChangedEvent(serviceName, instances))
-				}
-
-				if e != nil {
-					logger.Errorf("Dispatching event got exception, service name: %s, err: %v", serviceName, err)
-				}
-			},
-		})
+		n.clientLock.RLock()
+		err := n.namingClient.Client().Subscribe(n.subscribeParam(serviceName))
+		n.clientLock.RUnlock()
 		if err != nil {
 			return err
 		}
@@ -333,6 +318,98 @@ func (n *nacosServiceDiscovery) AddListener(listener registry.ServiceInstancesCh
 	return nil
 }
 
+// subscribeParam returns the param subscribing the instances of the service, which are dispatched to the listeners
+func (n *nacosServiceDiscovery) subscribeParam(serviceName string) *vo.SubscribeParam {
+	return &vo.SubscribeParam{
+		ServiceName: serviceName,
+		GroupName:   n.group,
+		SubscribeCallback: func(services []model.Instance, err error) {
+			if err != nil {
+				logger.Errorf("Could not handle the subscribe notification because the err is not nil."+
+					" service name: %s, err: %v", serviceName, err)
+			}
+			instances := make([]registry.ServiceInstance, 0, len(services))
+			for _, service := range services {
+				// we won't use the nacos instance id here but use our instance id
+				metadata := service.Metadata
+				id := metadata[idKey]
+
+				delete(metadata, idKey)
+
+				instances = append(instances, &registry.DefaultServiceInstance{
+					ID:          id,
+					ServiceName: serviceName,
+					Host:        service.Ip,
+					Port:        int(service.Port),
+					Weight:      int64(math.Round(service.Weight)),
+					Enable:      service.Enable,
+					Healthy:     true,
+					Metadata:    metadata,
+					GroupName:   n.group,
+				})
+			}
+
+			var e error
+			for _, lis := range n.instanceListenerMap[serviceName].Values() {
+				instanceListener := lis.(registry.ServiceInstancesChangedListener)
+				e = instanceListener.OnEvent(registry.NewServiceInstancesChangedEvent(serviceName, instances))
+			}
+
+			if e != nil {
+				logger.Errorf("Dispatching event got exception, service name: %s, err: %v", serviceName, err)
+			}
+		},
+	}
+}
+
+// UpdateCredentials re-creates the nacos client with the rotated credentials, then registers the instances and
+// subscribes the services again by the new client
+func (n *nacosServiceDiscovery) UpdateCredentials(username, password string) error {
+	url := n.discoveryURL.Clone()
+	url.SetParam(constant.NacosUsername, username)
+	url.SetParam(constant.NacosPassword, password)
+	namingClient, err := newNamingClient(url)
+	if err != nil {
+		return perrors.WithMessage(err, "re-create the nacos client failed")
+	}
+
+	// the registrations in progress are done by the old client, so they are copied after the client is replaced
+	n.registryLock.Lock()
+	n.clientLock.Lock()
+	oldClient := n.namingClient
+	n.namingClient = namingClient
+	n.discoveryURL.SetParam(constant.NacosUsername, username)
+	n.discoveryURL.SetParam(constant.NacosPassword, password)
+	n.clientLock.Unlock()
+	serviceNameInstances := make([][]registry.ServiceInstance, 0, len(n.serviceNameInstancesMap))
+	for _, instances := range n.serviceNameInstancesMap {
+		serviceNameInstances = append(serviceNameInstances, append([]registry.ServiceInstance(nil), instances...))
+	}
+	n.registryLock.Unlock()
+
+	for _, instances := range serviceNameInstances {
+		if _, err = namingClient.Client().BatchRegisterInstance(n.toBatchRegisterInstances(instances)); err != nil {
+			logger.Errorf("Register the nacos instances %+v again failed, err: %v", instances, err)
+		}
+	}
+	// the listeners added from now on subscribe by the new client
+	n.listenerLock.Lock()
+	serviceNames := make([]string, 0, len(n.instanceListenerMap))
+	for serviceName := range n.instanceListenerMap {
+		serviceNames = append(serviceNames, serviceName)
+	}
+	n.listenerLock.Unlock()
+	for _, serviceName := range serviceNames {
+		if subErr := namingClient.Client().Subscribe(n.subscribeParam(serviceName)); subErr != nil {
+			logger.Errorf("Subscribe the nacos service %s again failed, err: %v", serviceName, subErr)
+		}
+	}
+	if oldClient != nil {
+		closeNamingClient(oldClient)
+	}
+	return err
+}
+
 // toRegisterInstance convert the ServiceInstance to RegisterInstanceParam
 // the Ephemeral will be true
 func (n *nacosServiceDiscovery) toRegisterInstance(instance registry.ServiceInstance) vo.RegisterInstanceParam {
@@ -435,6 +512,7 @@ func newNacosServiceDiscovery(url *common.URL) (registry.ServiceDiscovery, error
 		registryInstances:       []registry.ServiceInstance{},
 		serviceNameInstancesMap: make(map[string][]registry.ServiceInstance),
 		registryURL:             url,
+		discoveryURL:            discoveryURL,
 		instanceListenerMap:     make(map[string]*gxset.HashSet),
 	}
 	return newInstance, nil
@@ -29,6 +29,7 @@ import (
 
 import (
 	gxset "github.com/dubbogo/gost/container/set"
+	nacosClient "github.com/dubbogo/gost/database/kv/nacos"
 
 	"github.com/nacos-group/nacos-sdk-go/v2/model"
 	"github.com/nacos-group/nacos-sdk-go/v2/vo"
@@ -334,6 +335,119 @@ func (c mockClient) GetAllServicesInfo(param vo.GetAllServiceInfoParam) (model.S
 func (c mockClient) CloseClient() {
 }
 
+// credentialClient records the services registered and subscribed by it, and the calls after it's closed
+type credentialClient struct {
+	mockClient
+	mu         sync.Mutex
+	closed     bool
+	lateCalls  int
+	registered map[string]bool
+	subscribed map[string]bool
+}
+
+func newCredentialClient() *credentialClient {
+	return &credentialClient{registered: map[string]bool{}, subscribed: map[string]bool{}}
+}
+
+func (c *credentialClient) record(services map[string]bool, serviceName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		c.lateCalls++
+	}
+	if services != nil {
+		services[serviceName] = true
+	}
+}
+
+func (c *credentialClient) RegisterInstance(param vo.RegisterInstanceParam) (bool, error) {
+	c.record(c.registered, param.ServiceName)
+	return true, nil
+}
+
+func (c *credentialClient) BatchRegisterInstance(param vo.BatchRegisterInstanceParam) (bool, error) {
+	c.record(c.registered, param.ServiceName)
+	return true, nil
+}
+
+func (c *credentialClient) Subscribe(param *vo.SubscribeParam) error {
+	c.record(c.subscribed, param.ServiceName)
+	return nil
+}
+
+func (c *credentialClient) Unsubscribe(param *vo.SubscribeParam) error {
+	c.record(nil, param.ServiceName)
+	return nil
+}
+
+func (c *credentialClient) GetAllServicesInfo(param vo.GetAllServiceInfoParam) (model.ServiceList, error) {
+	c.record(nil, "")
+	return model.ServiceList{}, nil
+}
+
+func (c *credentialClient) CloseClient() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+}
+
+// replaceNamingClient makes UpdateCredentials create the naming client of client
+func replaceNamingClient(t *testing.T, client *credentialClient) {
+	origin := newNamingClient
+	newNamingClient = func(*common.URL) (*nacosClient.NacosNamingClient, error) {
+		nc := &nacosClient.NacosNamingClient{}
+		nc.SetClient(client)
+		return nc, nil
+	}
+	t.Cleanup(func() { newNamingClient = origin })
+}
+
+func TestNacosServiceDiscoveryUpdateCredentials(t *testing.T) {
+	oldClient, newClient := newCredentialClient(), newCredentialClient()
+	replaceNamingClient(t, newClient)
+	nc := &nacosClient.NacosNamingClient{}
+	nc.SetClient(oldClient)
+	url, _ := common.NewURL("registry://127.0.0.1:8848")
+	n := &nacosServiceDiscovery{
+		group:                   defaultGroup,
+		namingClient:            nc,
+		registryURL:             url,
+		discoveryURL:            url.Clone(),
+		serviceNameInstancesMap: make(map[string][]registry.ServiceInstance),
+		instanceListenerMap:     make(map[string]*gxset.HashSet),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		serviceName := "app-" + strconv.Itoa(i)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, n.Register(&registry.DefaultServiceInstance{ID: serviceName, ServiceName: serviceName,
+				Host: "127.0.0.1", Port: 20000, Enable: true, Healthy: true, Metadata: map[string]string{}}))
+		}()
+		go func() {
+			defer wg.Done()
+			listener := servicediscovery.NewServiceInstancesChangedListener("app", gxset.NewSet(serviceName))
+			assert.NoError(t, n.AddListener(listener))
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, n.UpdateCredentials("user", "rotated"))
+	}()
+	wg.Wait()
+
+	assert.Equal(t, "rotated", n.discoveryURL.GetParam(constant.NacosPassword, ""))
+	assert.Zero(t, oldClient.lateCalls)
+	for i := 0; i < 10; i++ {
+		serviceName := "app-" + strconv.Itoa(i)
+		assert.True(t, newClient.registered[serviceName], serviceName)
+		assert.True(t, newClient.subscribed[serviceName], serviceName)
+	}
+}
+
 type mockProtocol struct{}
 
 func (m mockProtocol) Export(base.Invoker) base.Exporter {
@@ -32,6 +32,7 @@ import (
 )
 
 import (
+	"dubbo.apache.org/dubbo-go/v3/cluster/directory"
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
@@ -42,6 +43,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/protocol/protocolwrapper"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 	"dubbo.apache.org/dubbo-go/v3/registry"
+	"dubbo.apache.org/dubbo-go/v3/registry/migration"
 	"dubbo.apache.org/dubbo-go/v3/remoting"
 )
 
@@ -126,6 +128,18 @@ func (proto *registryProtocol) initConfigurationListeners() {
 	proto.overrideListeners = &sync.Map{}
 	proto.serviceConfigurationListeners = &sync.Map{}
 	proto.providerConfigurationListener = newProviderConfigurationListener(proto.overrideListeners)
+	config_center.AddAppConfiguratorListener(common.DubboRole[common.PROVIDER], proto.processAppConfigurator)
+}
+
+// processAppConfigurator re-exports the service once its application configuration is reloaded from the config center
+func (proto *registryProtocol) processAppConfigurator(serviceKey string) {
+	proto.overrideListeners.Range(func(_, value any) bool {
+		listener := value.(*overrideSubscribeListener)
+		if getProviderUrl(listener.originInvoker).ServiceKey() == serviceKey {
+			listener.doOverrideIfNecessary()
+		}
+		return true
+	})
 }
 
 // GetRegistries returns all underlying registry instances.
@@ -142,6 +156,45 @@ func (proto *registryProtocol) GetRegistries() []registry.Registry {
 
 // Refer provider service from registry center
 func (proto *registryProtocol) Refer(url *common.URL) base.Invoker {
+	if url.Protocol == constant.ServiceRegistryProtocol &&
+		url.GetParam(constant.RegistryTypeKey, "") == constant.RegistryTypeAll {
+		return proto.referWithMigration(url)
+	}
+	_, invoker := proto.refer(url)
+	return invoker
+}
+
+// referWithMigration subscribes to both interface-level and application-level discovery,
+// the invocations are routed to one of them by the migration invoker.
+func (proto *registryProtocol) referWithMigration(url *common.URL) base.Invoker {
+	interfaceURL, err := common.NewURL(constant.RegistryProtocol+strings.TrimPrefix(url.PrimitiveURL, url.Protocol),
+		common.WithParams(url.GetParams()),
+		common.WithUsername(url.Username),
+		common.WithPassword(url.Password),
+		common.WithLocation(url.Location),
+	)
+	if err != nil {
+		logger.Errorf("consumer service %v create interface registry url error, error message is %s, will return nil invoker!",
+			url.SubURL.String(), err.Error())
+		return nil
+	}
+	interfaceURL.SubURL = url.SubURL
+
+	appDir, appInvoker := proto.refer(url)
+	interfaceDir, interfaceInvoker := proto.refer(interfaceURL)
+	if appInvoker == nil || interfaceInvoker == nil {
+		if appInvoker != nil {
+			return appInvoker
+		}
+		return interfaceInvoker
+	}
+	return migration.NewInvoker(url,
+		migration.Target{Directory: interfaceDir, Invoker: interfaceInvoker},
+		migration.Target{Directory: appDir, Invoker: appInvoker})
+}
+
+// refer creates the directory of the registry url and joins it into the cluster invoker
+func (proto *registryProtocol) refer(url *common.URL) (directory.Directory, base.Invoker) {
 	registryUrl := url
 	serviceUrl := registryUrl.SubURL
 	if registryUrl.Protocol == constant.RegistryProtocol {
@@ -155,7 +208,7 @@ func (proto *registryProtocol) Refer(url *common.URL) base.Invoker {
 	if err != nil {
 		logger.Errorf("consumer service %v create registry directory error, error message is %s, and will return nil invoker!",
 			serviceUrl.String(), err.Error())
-		return nil
+		return nil, nil
 	}
 
 	// This will start a new routine and listen to instance changes.
@@ -172,15 +225,15 @@ func (proto *registryProtocol) Refer(url *common.URL) base.Invoker {
 	if err != nil {
 		logger.Errorf("consumer service %v get cluster %s error, error message is %s, will return nil invoker!",
 			serviceUrl.String(), clusterKey, err.Error())
-		return nil
+		return nil, nil
 	}
 	if cluster == nil {
 		logger.Errorf("consumer service %v cluster is nil for key %s, will return nil invoker!",
 			serviceUrl.String(), clusterKey)
-		return nil
+		return nil, nil
 	}
 	invoker := cluster.Join(dic)
-	return invoker
+	return dic, invoker
 }
 
 // Export provider service to registry center
@@ -340,6 +393,10 @@ func (nl *overrideSubscribeListener) doOverrideIfNecessary() {
 				v.Configure(providerUrl)
 			}
 		}
+		// application configuration reloaded from the config center
+		for _, v := range config_center.GetAppConfigurators(common.DubboRole[common.PROVIDER], providerUrl.ServiceKey()) {
+			v.Configure(providerUrl)
+		}
 
 		if currentUrl.String() != providerUrl.String() {
 			newRegUrl := nl.originInvoker.GetURL().Clone()
@@ -437,6 +494,29 @@ func (proto *registryProtocol) Destroy() {
 	})
 }
 
+// UpdateRegistryCredentials re-creates the clients of the live registries configured by the registry id with the
+// rotated credentials, the registries not supporting it keep the former credentials until restarted
+func (proto *registryProtocol) UpdateRegistryCredentials(registryId, username, password string) {
+	proto.registries.Range(func(_, value any) bool {
+		reg := value.(registry.Registry)
+		if url := reg.GetURL(); url == nil || url.GetParam(constant.RegistryIdKey, "") != registryId {
+			return true
+		}
+		updater, ok := reg.(registry.CredentialsUpdater)
+		if !ok {
+			logger.Warnf("The registry %s doesn't support updating the credentials, restart to apply the rotated ones",
+				reg.GetURL().Location)
+			return true
+		}
+		if err := updater.UpdateCredentials(username, password); err != nil {
+			logger.Errorf("The registry %s failed to update the credentials, err: %v", reg.GetURL().Location, err)
+			return true
+		}
+		logger.Infof("The registry %s updated the credentials", reg.GetURL().Location)
+		return true
+	})
+}
+
 func getRegistryUrl(invoker base.Invoker) *common.URL {
 	// here add * for return a new url
 	url := invoker.GetURL()
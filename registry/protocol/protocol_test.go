@@ -298,3 +298,38 @@ func TestGetProviderUrlWithHideKey(t *testing.T) {
 	assert.NotContains(t, providerUrl.GetParams(), ".d")
 	assert.Contains(t, providerUrl.GetParams(), "a")
 }
+
+// credentialsRegistry records the credentials updated
+type credentialsRegistry struct {
+	registry.Registry
+	url      *common.URL
+	username string
+	password string
+}
+
+func (r *credentialsRegistry) GetURL() *common.URL {
+	return r.url
+}
+
+func (r *credentialsRegistry) UpdateCredentials(username, password string) error {
+	r.username, r.password = username, password
+	return nil
+}
+
+func TestUpdateRegistryCredentials(t *testing.T) {
+	regProtocol := newRegistryProtocol()
+	nacosURL, _ := common.NewURL("nacos://127.0.0.1:8848", common.WithParamsValue(constant.RegistryIdKey, "nacos"))
+	zkURL, _ := common.NewURL("zookeeper://127.0.0.1:2181", common.WithParamsValue(constant.RegistryIdKey, "zk"))
+	nacosReg := &credentialsRegistry{url: nacosURL}
+	zkReg := &credentialsRegistry{url: zkURL}
+	regProtocol.registries.Store(nacosURL.PrimitiveURL, nacosReg)
+	regProtocol.registries.Store(zkURL.PrimitiveURL, zkReg)
+	mockReg, _ := registry.NewMockRegistry(nacosURL)
+	regProtocol.registries.Store("mock", mockReg)
+
+	// the registries not supporting it are skipped
+	regProtocol.UpdateRegistryCredentials("nacos", "nacos", "rotated")
+	assert.Equal(t, "nacos", nacosReg.username)
+	assert.Equal(t, "rotated", nacosReg.password)
+	assert.Empty(t, zkReg.password)
+}
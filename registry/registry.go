@@ -67,6 +67,18 @@ type Registry interface {
 	LoadSubscribeInstances(*common.URL, NotifyListener) error
 }
 
+// CredentialsUpdater is implemented by the registries whose clients authenticate with the username and the password
+// of the registry url, they re-create the clients with the rotated credentials, then register and subscribe again.
+type CredentialsUpdater interface {
+	UpdateCredentials(username, password string) error
+}
+
+// RegistryCredentialsUpdater is implemented by the registry protocol, which updates the credentials of the live
+// registries configured by the registry id.
+type RegistryCredentialsUpdater interface {
+	UpdateRegistryCredentials(registryId, username, password string)
+}
+
 // NotifyListener handles service change notifications from Registry implementations.
 type NotifyListener interface {
 	// Notify supports notifications on the service interface and the dimension of the data type. When a list of
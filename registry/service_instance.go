@@ -180,6 +180,7 @@ func (d *DefaultServiceInstance) ToURLs(service *info.ServiceInfo) []*common.URL
 					common.WithPath(service.Name), common.WithInterface(service.Name),
 					common.WithMethods(service.GetMethods()), common.WithParams(service.GetParams()),
 					common.WithParams(url2.Values{constant.Tagkey: {d.Tag}}),
+					common.WithParams(d.localityParams()),
 					common.WithWeight(d.GetWeight()))
 				urls = append(urls, url)
 			}
@@ -190,12 +191,24 @@ func (d *DefaultServiceInstance) ToURLs(service *info.ServiceInfo) []*common.URL
 			common.WithPath(service.Name), common.WithInterface(service.Name),
 			common.WithMethods(service.GetMethods()), common.WithParams(service.GetParams()),
 			common.WithParams(url2.Values{constant.Tagkey: {d.Tag}}),
+			common.WithParams(d.localityParams()),
 			common.WithWeight(d.GetWeight()))
 		urls = append(urls, url)
 	}
 	return urls
 }
 
+// localityParams returns the zone, the region and the identity of the host in the metadata as the url params
+func (d *DefaultServiceInstance) localityParams() url2.Values {
+	params := url2.Values{}
+	for _, key := range []string{constant.ZoneKey, constant.RegionKey, constant.HostIdKey} {
+		if value := d.Metadata[key]; value != "" {
+			params.Set(key, value)
+		}
+	}
+	return params
+}
+
 // GetEndPoints get end points from metadata
 func (d *DefaultServiceInstance) GetEndPoints() []*Endpoint {
 	rawEndpoints := d.Metadata[constant.ServiceInstanceEndpoints]
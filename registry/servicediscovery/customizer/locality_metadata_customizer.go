@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package customizer
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+)
+
+func init() {
+	extension.AddCustomizers(&localityMetadataCustomizer{})
+}
+
+// localityMetadataCustomizer puts the zone and the region of the provider into the instance metadata,
+// which are copied to the urls of the instance by the consumers for the locality load balance. The identity
+// of the host is put as well, by which the consumers on the same host find the unix sockets of the instance.
+type localityMetadataCustomizer struct{}
+
+// GetPriority will return 0, which means it will be invoked at the beginning
+func (c *localityMetadataCustomizer) GetPriority() int {
+	return 0
+}
+
+// Customize puts the zone and the region of the exported urls, or the ones of the environment variables
+func (c *localityMetadataCustomizer) Customize(instance registry.ServiceInstance) {
+	zone, region, hostId := common.GetLocalZone(), common.GetLocalRegion(), ""
+	if meta := instance.GetServiceMetadata(); meta != nil {
+		located := false
+		for _, url := range meta.GetExportedServiceURLs() {
+			if z := url.GetParam(constant.ZoneKey, ""); z != "" && !located {
+				zone, region, located = z, url.GetParam(constant.RegionKey, region), true
+			}
+			if id := url.GetParam(constant.HostIdKey, ""); id != "" {
+				hostId = id
+			}
+		}
+	}
+	if hostId != "" {
+		instance.GetMetadata()[constant.HostIdKey] = hostId
+	}
+	if zone != "" {
+		instance.GetMetadata()[constant.ZoneKey] = zone
+	}
+	if region != "" {
+		instance.GetMetadata()[constant.RegionKey] = region
+	}
+}
@@ -0,0 +1,186 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package servicediscovery
+
+import (
+	"encoding/gob"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+
+	"golang.org/x/sync/singleflight"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/metadata"
+	"dubbo.apache.org/dubbo-go/v3/metadata/info"
+	"dubbo.apache.org/dubbo-go/v3/metrics"
+	metricsMetadata "dubbo.apache.org/dubbo-go/v3/metrics/metadata"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+	"dubbo.apache.org/dubbo-go/v3/registry/servicediscovery/store"
+)
+
+// the modes fetching the metadata, which are the tags of the metrics
+const (
+	fetchModeRpc    = "rpc"
+	fetchModeReport = "report"
+)
+
+var (
+	// metaCache caches the metadata by the revision, which is shared by the listeners of all the references
+	metaCache *store.CacheManager
+	cacheOnce sync.Once
+	// metaFlight merges the concurrent fetches of the same revision into one
+	metaFlight singleflight.Group
+)
+
+func initCache(app string) {
+	gob.Register(&info.MetadataInfo{})
+	fileName := constant.DefaultMetaFileName + app
+	cache, err := store.NewCacheManager(constant.DefaultMetaCacheName, fileName, time.Minute*10, constant.DefaultEntrySize, true)
+	if err != nil {
+		logger.Fatal("Failed to create cache [%s],the err is %v", constant.DefaultMetaCacheName, err)
+	}
+	ttl, _ := time.ParseDuration(constant.DefaultMetaCacheTTL)
+	cache.SetTTL(ttl)
+	cache.SetEvictedListener(func(revision string, reason store.EvictionReason) {
+		logger.Debugf("The metadata of revision %s is evicted from the cache, reason: %s", revision, reason)
+		metrics.Publish(metricsMetadata.NewMetadataCacheEvictedEvent(string(reason)))
+	})
+	metaCache = cache
+}
+
+// GetMetadataInfo get metadata info when MetadataStorageTypePropertyName is null.
+// The metadata is cached by the revision, the concurrent fetches of the same revision are merged into one.
+func GetMetadataInfo(app string, instance registry.ServiceInstance, revision string) (*info.MetadataInfo, error) {
+	cacheOnce.Do(func() {
+		initCache(app)
+	})
+	if metadataInfo, ok := metaCache.Get(revision); ok {
+		metrics.Publish(metricsMetadata.NewMetadataCacheEvent(true))
+		return metadataInfo.(*info.MetadataInfo), nil
+	}
+	metrics.Publish(metricsMetadata.NewMetadataCacheEvent(false))
+
+	v, err, _ := metaFlight.Do(revision, func() (any, error) {
+		// the revision may be cached by the former flight just now
+		if metadataInfo, ok := metaCache.Get(revision); ok {
+			return metadataInfo, nil
+		}
+		metadataInfo, err := fetchMetadataInfo(instance, revision)
+		if err != nil {
+			return nil, err
+		}
+		metaCache.Set(revision, metadataInfo)
+		return metadataInfo, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*info.MetadataInfo), nil
+}
+
+// fetchMetadataInfo fetches the metadata from the metadata report if the instance stores its metadata remotely,
+// otherwise from the metadata service of the instance, falling back to the metadata report if it fails
+func fetchMetadataInfo(instance registry.ServiceInstance, revision string) (*info.MetadataInfo, error) {
+	if metadataStorageType(instance) == constant.RemoteMetadataStorageType {
+		return fetchMetadataInfoFromReport(instance, revision)
+	}
+	metadataInfo, err := metadata.GetMetadataFromRpc(revision, instance)
+	metrics.Publish(metricsMetadata.NewMetadataFetchEvent(fetchModeRpc, err == nil))
+	if err == nil {
+		return metadataInfo, nil
+	}
+	if metadata.GetMetadataReport() == nil {
+		return nil, err
+	}
+	logger.Warnf("Failed to get the metadata of revision %s from the instance %s, fall back to the metadata report, err: %v",
+		revision, instance.GetHost(), err)
+	return fetchMetadataInfoFromReport(instance, revision)
+}
+
+func fetchMetadataInfoFromReport(instance registry.ServiceInstance, revision string) (*info.MetadataInfo, error) {
+	metadataInfo, err := metadata.GetMetadataFromMetadataReport(revision, instance)
+	metrics.Publish(metricsMetadata.NewMetadataFetchEvent(fetchModeReport, err == nil))
+	return metadataInfo, err
+}
+
+func metadataStorageType(instance registry.ServiceInstance) string {
+	if instance.GetMetadata() == nil {
+		return constant.DefaultMetadataStorageType
+	}
+	return instance.GetMetadata()[constant.MetadataStorageTypePropertyName]
+}
+
+// prefetchConcurrency bounds the concurrent fetches of the metadata in one notification
+const prefetchConcurrency = 8
+
+// prefetchMetadataInfos fetches the metadata of the uncached revisions in the notification before the instances are
+// handled one by one. Each revision is fetched once from one of its instances chosen at random, which spreads the
+// fetches of the consumers across the instances, and the revisions are fetched concurrently up to
+// prefetchConcurrency. The revisions failing to be fetched are left to GetMetadataInfo.
+func prefetchMetadataInfos(app string, allInstances map[string][]registry.ServiceInstance, known map[string]*info.MetadataInfo) {
+	cacheOnce.Do(func() {
+		initCache(app)
+	})
+	chosen := make(map[string]registry.ServiceInstance)
+	counts := make(map[string]int)
+	for _, instances := range allInstances {
+		for _, instance := range instances {
+			meta := instance.GetMetadata()
+			if meta == nil {
+				continue
+			}
+			revision := meta[constant.ExportedServicesRevisionPropertyName]
+			if revision == "" || revision == "0" || known[revision] != nil {
+				continue
+			}
+			// reservoir sampling, every instance of the revision is chosen with the same probability
+			counts[revision]++
+			if rand.Intn(counts[revision]) == 0 {
+				chosen[revision] = instance
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	tokens := make(chan struct{}, prefetchConcurrency)
+	for revision, instance := range chosen {
+		if _, ok := metaCache.Get(revision); ok {
+			continue
+		}
+		wg.Add(1)
+		tokens <- struct{}{}
+		go func(revision string, instance registry.ServiceInstance) {
+			defer func() {
+				<-tokens
+				wg.Done()
+			}()
+			if _, err := GetMetadataInfo(app, instance, revision); err != nil {
+				logger.Warnf("Failed to prefetch the metadata of revision %s from the instance %s, err: %v",
+					revision, instance.GetHost(), err)
+			}
+		}(revision, instance)
+	}
+	wg.Wait()
+}
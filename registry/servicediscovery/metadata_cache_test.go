@@ -0,0 +1,247 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package servicediscovery
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+import (
+	gxset "github.com/dubbogo/gost/container/set"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/metadata/info"
+	tripleapi "dubbo.apache.org/dubbo-go/v3/metadata/triple_api/proto"
+	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+	"dubbo.apache.org/dubbo-go/v3/registry"
+)
+
+// metadataProtocol refers the invokers of the metadata services, which count the calls and the concurrent ones
+type metadataProtocol struct {
+	calls         atomic.Int32
+	inflight      atomic.Int32
+	maxInflight   atomic.Int32
+	delay         time.Duration
+	lock          sync.Mutex
+	revisionHosts map[string][]string
+}
+
+func (p *metadataProtocol) Export(base.Invoker) base.Exporter {
+	return nil
+}
+
+func (p *metadataProtocol) Refer(url *common.URL) base.Invoker {
+	return &metadataInvoker{BaseInvoker: base.NewBaseInvoker(url), protocol: p}
+}
+
+func (p *metadataProtocol) Destroy() {}
+
+type metadataInvoker struct {
+	*base.BaseInvoker
+	protocol *metadataProtocol
+}
+
+func (m *metadataInvoker) Invoke(_ context.Context, inv base.Invocation) result.Result {
+	m.protocol.calls.Add(1)
+	inflight := m.protocol.inflight.Add(1)
+	defer m.protocol.inflight.Add(-1)
+	for max := m.protocol.maxInflight.Load(); inflight > max; max = m.protocol.maxInflight.Load() {
+		if m.protocol.maxInflight.CompareAndSwap(max, inflight) {
+			break
+		}
+	}
+	time.Sleep(m.protocol.delay)
+
+	var revision string
+	switch reply := inv.Reply().(type) {
+	case *info.MetadataInfo:
+		revision = inv.Arguments()[0].(string)
+		reply.App = "dubbo-app"
+		reply.Revision = revision
+	case *tripleapi.MetadataInfoV2:
+		revision = inv.Arguments()[0].(*tripleapi.MetadataRequest).Revision
+		reply.App = "dubbo-app"
+		reply.Version = revision
+		reply.Services = map[string]*tripleapi.ServiceInfoV2{
+			"com.example.Greeter:tri": {Name: "com.example.Greeter", Protocol: constant.TriProtocol, Path: "com.example.Greeter"},
+		}
+	}
+	m.protocol.lock.Lock()
+	if m.protocol.revisionHosts == nil {
+		m.protocol.revisionHosts = make(map[string][]string)
+	}
+	m.protocol.revisionHosts[revision] = append(m.protocol.revisionHosts[revision], m.GetURL().Ip)
+	m.protocol.lock.Unlock()
+	return &result.RPCResult{Rest: inv.Reply()}
+}
+
+func newMetadataInstance(host, protocol, version, revision string) registry.ServiceInstance {
+	return &registry.DefaultServiceInstance{
+		ServiceName: "dubbo-app",
+		Host:        host,
+		Metadata: map[string]string{
+			constant.ExportedServicesRevisionPropertyName: revision,
+			constant.MetadataVersion:                      version,
+			constant.MetadataServiceURLParamsPropertyName: `{"protocol": "` + protocol + `", "port": "20880"}`,
+		},
+	}
+}
+
+func TestGetMetadataInfo(t *testing.T) {
+	protocol := &metadataProtocol{delay: 50 * time.Millisecond}
+	extension.SetProtocol("mock-metadata", func() base.Protocol {
+		return protocol
+	})
+	instance := newMetadataInstance("127.0.0.1", "mock-metadata", constant.MetadataServiceV1Version, "flight")
+
+	// the concurrent fetches of the same revision are merged into one
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			metadataInfo, err := GetMetadataInfo("dubbo-app", instance, "flight")
+			if assert.NoError(t, err) {
+				assert.Equal(t, "flight", metadataInfo.Revision)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), protocol.calls.Load())
+
+	// the cached revision is shared
+	_, err := GetMetadataInfo("another-app", instance, "flight")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), protocol.calls.Load())
+}
+
+func TestPrefetchMetadataInfos(t *testing.T) {
+	protocol := &metadataProtocol{}
+	extension.SetProtocol(constant.TriProtocol, func() base.Protocol {
+		return protocol
+	})
+	v2 := constant.MetadataServiceV2Version
+	allInstances := map[string][]registry.ServiceInstance{
+		"dubbo-app": {
+			newMetadataInstance("127.0.0.1", constant.TriProtocol, v2, "prefetch-1"),
+			newMetadataInstance("127.0.0.2", constant.TriProtocol, v2, "prefetch-1"),
+			newMetadataInstance("127.0.0.3", constant.TriProtocol, v2, "prefetch-2"),
+			// the known revision isn't fetched
+			newMetadataInstance("127.0.0.4", constant.TriProtocol, v2, "known"),
+			newMetadataInstance("127.0.0.5", constant.TriProtocol, v2, "0"),
+		},
+	}
+	known := map[string]*info.MetadataInfo{"known": {}}
+	prefetchMetadataInfos("dubbo-app", allInstances, known)
+
+	// each revision is fetched once
+	assert.Equal(t, int32(2), protocol.calls.Load())
+	for _, revision := range []string{"prefetch-1", "prefetch-2"} {
+		_, ok := metaCache.Get(revision)
+		assert.True(t, ok, revision)
+		assert.Len(t, protocol.revisionHosts[revision], 1)
+	}
+	_, ok := metaCache.Get("known")
+	assert.False(t, ok)
+
+	// the cached revisions aren't fetched again
+	prefetchMetadataInfos("dubbo-app", allInstances, known)
+	assert.Equal(t, int32(2), protocol.calls.Load())
+}
+
+// notifyListener records the urls notified
+type notifyListener struct {
+	urls []*common.URL
+}
+
+func (l *notifyListener) Notify(event *registry.ServiceEvent) {
+	l.urls = append(l.urls, event.Service)
+}
+
+func (l *notifyListener) NotifyAll(events []*registry.ServiceEvent, _ func()) {
+	l.urls = l.urls[:0]
+	for _, event := range events {
+		l.urls = append(l.urls, event.Service)
+	}
+}
+
+func TestServiceInstancesChangedListenerPrefetch(t *testing.T) {
+	protocol := &metadataProtocol{delay: 50 * time.Millisecond}
+	extension.SetProtocol(constant.TriProtocol, func() base.Protocol {
+		return protocol
+	})
+	// a fleet in the middle of the rolling upgrade, the instances of the old and the new releases, and the canary
+	// ones, are spread on the hosts
+	releases := map[string]int{"listener-old": 40, "listener-new": 20, "listener-canary": 4}
+	instances := make([]registry.ServiceInstance, 0, 64)
+	hosts := 0
+	for revision, num := range releases {
+		for i := 0; i < num; i++ {
+			hosts++
+			instances = append(instances, newFleetInstance(hosts, revision))
+		}
+	}
+
+	lstn := NewServiceInstancesChangedListener("dubbo-app", gxset.NewSet("dubbo-app")).(*ServiceInstancesChangedListenerImpl)
+	listener := &notifyListener{}
+	matchKey := (&info.ServiceInfo{Name: "com.example.Greeter", Protocol: constant.TriProtocol}).GetMatchKey()
+	lstn.AddListenerAndNotify(matchKey, listener)
+	start := time.Now()
+	require.NoError(t, lstn.OnEvent(registry.NewServiceInstancesChangedEvent("dubbo-app", instances)))
+
+	// the 64 instances cost a call for each of the 3 revisions, and the calls are made concurrently
+	assert.Equal(t, int32(len(releases)), protocol.calls.Load())
+	assert.Equal(t, int32(len(releases)), protocol.maxInflight.Load())
+	assert.Less(t, time.Since(start), time.Duration(len(releases))*protocol.delay)
+	for revision := range releases {
+		assert.Len(t, protocol.revisionHosts[revision], 1, revision)
+	}
+	for _, instance := range instances {
+		revision := instance.GetMetadata()[constant.ExportedServicesRevisionPropertyName]
+		assert.Equal(t, revision, instance.GetServiceMetadata().Revision)
+	}
+	assert.NotEmpty(t, listener.urls)
+
+	// the instances of the new release are scaled out without new calls
+	instance := newFleetInstance(hosts+1, "listener-new")
+	require.NoError(t, lstn.OnEvent(registry.NewServiceInstancesChangedEvent("dubbo-app", append(instances, instance))))
+	assert.Equal(t, int32(len(releases)), protocol.calls.Load())
+	assert.Equal(t, "listener-new", instance.GetServiceMetadata().Revision)
+}
+
+// newFleetInstance returns the instance of the fleet serving the triple protocol on the host
+func newFleetInstance(host int, revision string) registry.ServiceInstance {
+	instance := newMetadataInstance("10.0.0."+strconv.Itoa(host), constant.TriProtocol,
+		constant.MetadataServiceV2Version, revision).(*registry.DefaultServiceInstance)
+	instance.Port = 20000
+	instance.Metadata[constant.ServiceInstanceEndpoints] = `[{"port": 20000, "protocol": "tri"}]`
+	return instance
+}
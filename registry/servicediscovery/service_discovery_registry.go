@@ -185,6 +185,15 @@ func (s *serviceDiscoveryRegistry) GetURL() *common.URL {
 	return s.url
 }
 
+// UpdateCredentials updates the credentials of the service discovery, if it supports re-creating its client
+func (s *serviceDiscoveryRegistry) UpdateCredentials(username, password string) error {
+	updater, ok := s.serviceDiscovery.(registry.CredentialsUpdater)
+	if !ok {
+		return perrors.Errorf("the service discovery %s doesn't support updating the credentials", s.serviceDiscovery)
+	}
+	return updater.UpdateCredentials(username, password)
+}
+
 func (s *serviceDiscoveryRegistry) IsAvailable() bool {
 	if s.serviceDiscovery.GetServices() == nil {
 		return false
@@ -18,10 +18,8 @@
 package servicediscovery
 
 import (
-	"encoding/gob"
 	"reflect"
 	"sync"
-	"time"
 )
 
 import (
@@ -33,28 +31,11 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
-	"dubbo.apache.org/dubbo-go/v3/metadata"
 	"dubbo.apache.org/dubbo-go/v3/metadata/info"
 	"dubbo.apache.org/dubbo-go/v3/registry"
-	"dubbo.apache.org/dubbo-go/v3/registry/servicediscovery/store"
 	"dubbo.apache.org/dubbo-go/v3/remoting"
 )
 
-var (
-	metaCache *store.CacheManager
-	cacheOnce sync.Once
-)
-
-func initCache(app string) {
-	gob.Register(&info.MetadataInfo{})
-	fileName := constant.DefaultMetaFileName + app
-	cache, err := store.NewCacheManager(constant.DefaultMetaCacheName, fileName, time.Minute*10, constant.DefaultEntrySize, true)
-	if err != nil {
-		logger.Fatal("Failed to create cache [%s],the err is %v", constant.DefaultMetaCacheName, err)
-	}
-	metaCache = cache
-}
-
 // ServiceInstancesChangedListenerImpl The Service Discovery Changed  Event Listener
 type ServiceInstancesChangedListenerImpl struct {
 	app                string
@@ -99,6 +80,9 @@ func (lstn *ServiceInstancesChangedListenerImpl) OnEvent(e observer.Event) error
 
 	logger.Infof("Received instance notification event of service %s, instance list size %d", ce.ServiceName, len(ce.Instances))
 
+	// fetch the metadata of the new revisions concurrently, each one from one of its instances
+	prefetchMetadataInfos(lstn.app, lstn.allInstances, lstn.revisionToMetadata)
+
 	for _, instances := range lstn.allInstances {
 		for _, instance := range instances {
 			if instance.GetMetadata() == nil {
@@ -218,35 +202,3 @@ func (lstn *ServiceInstancesChangedListenerImpl) GetPriority() int {
 func (lstn *ServiceInstancesChangedListenerImpl) GetEventType() reflect.Type {
 	return reflect.TypeOf(&registry.ServiceInstancesChangedEvent{})
 }
-
-// GetMetadataInfo get metadata info when MetadataStorageTypePropertyName is null
-func GetMetadataInfo(app string, instance registry.ServiceInstance, revision string) (*info.MetadataInfo, error) {
-	cacheOnce.Do(func() {
-		initCache(app)
-	})
-	if metadataInfo, ok := metaCache.Get(revision); ok {
-		return metadataInfo.(*info.MetadataInfo), nil
-	}
-
-	var metadataStorageType string
-	var metadataInfo *info.MetadataInfo
-	var err error
-	if instance.GetMetadata() == nil {
-		metadataStorageType = constant.DefaultMetadataStorageType
-	} else {
-		metadataStorageType = instance.GetMetadata()[constant.MetadataStorageTypePropertyName]
-	}
-	if metadataStorageType == constant.RemoteMetadataStorageType {
-		metadataInfo, err = metadata.GetMetadataFromMetadataReport(revision, instance)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		metadataInfo, err = metadata.GetMetadataFromRpc(revision, instance)
-		if err != nil {
-			return nil, err
-		}
-	}
-	metaCache.Set(revision, metadataInfo)
-	return metadataInfo, nil
-}
@@ -21,6 +21,7 @@ import (
 	"encoding/gob"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -38,6 +39,8 @@ type CacheManager struct {
 	cache        *lru.Cache    // The LRU cache implementation
 	lock         sync.Mutex
 	enableDump   bool
+	ttl          time.Duration                           // The time to live of the entries, zero means never expiring
+	evicted      func(key string, reason EvictionReason) // The listener of the evicted entries
 }
 
 type Item struct {
@@ -45,6 +48,21 @@ type Item struct {
 	Value any
 }
 
+// EvictionReason is the reason why the entry is evicted from the cache
+type EvictionReason string
+
+const (
+	EvictionExpired  EvictionReason = "expired"  // the entry outlives the ttl
+	EvictionCapacity EvictionReason = "capacity" // the entry is the least recently used one when the cache is full
+)
+
+// entry is the value in the LRU cache with the time it's set
+type entry struct {
+	value   any
+	setAt   time.Time
+	deleted atomic.Bool // deleted by the users, which isn't an eviction
+}
+
 // NewCacheManager creates a new CacheManager instance.
 // It initializes the cache manager with the provided parameters and starts a routine for cache dumping.
 func NewCacheManager(name, cacheFile string, dumpInterval time.Duration, maxCacheSize int, enableDump bool) (*CacheManager, error) {
@@ -55,7 +73,7 @@ func NewCacheManager(name, cacheFile string, dumpInterval time.Duration, maxCach
 		stop:         make(chan struct{}),
 		enableDump:   enableDump,
 	}
-	cache, err := lru.New(maxCacheSize)
+	cache, err := lru.NewWithEvict(maxCacheSize, cm.onEvicted)
 	if err != nil {
 		return nil, err
 	}
@@ -75,18 +93,42 @@ func NewCacheManager(name, cacheFile string, dumpInterval time.Duration, maxCach
 	return cm, nil
 }
 
+// SetTTL sets the time to live of the entries, the expired entries are evicted when they are accessed or the cache
+// is dumped. It should be called before the cache manager is shared.
+func (cm *CacheManager) SetTTL(ttl time.Duration) {
+	cm.ttl = ttl
+}
+
+// SetEvictedListener sets the listener notified when the entries are evicted as expired or by the capacity.
+// It should be called before the cache manager is shared.
+func (cm *CacheManager) SetEvictedListener(listener func(key string, reason EvictionReason)) {
+	cm.evicted = listener
+}
+
 // Get retrieves the value associated with the given key from the cache.
 func (cm *CacheManager) Get(key string) (any, bool) {
-	return cm.cache.Get(key)
+	v, ok := cm.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	e := v.(*entry)
+	if cm.expired(e) {
+		cm.cache.Remove(key)
+		return nil, false
+	}
+	return e.value, true
 }
 
-// Set sets the value associated with the given key in the cache.
+// Set sets the value associated with the given key in the cache, the time to live of the entry is renewed.
 func (cm *CacheManager) Set(key string, value any) {
-	cm.cache.Add(key, value)
+	cm.cache.Add(key, &entry{value: value, setAt: time.Now()})
 }
 
 // Delete removes the value associated with the given key from the cache.
 func (cm *CacheManager) Delete(key string) {
+	if v, ok := cm.cache.Peek(key); ok {
+		v.(*entry).deleted.Store(true)
+	}
 	cm.cache.Remove(key)
 }
 
@@ -96,12 +138,32 @@ func (cm *CacheManager) GetAll() map[string]any {
 
 	result := make(map[string]any)
 	for _, k := range keys {
-		result[k.(string)], _ = cm.cache.Get(k)
+		if v, ok := cm.Get(k.(string)); ok {
+			result[k.(string)] = v
+		}
 	}
 
 	return result
 }
 
+// expired reports whether the entry outlives the ttl
+func (cm *CacheManager) expired(e *entry) bool {
+	return cm.ttl > 0 && time.Since(e.setAt) > cm.ttl
+}
+
+// onEvicted notifies the listener of the entries evicted by the LRU cache, the ones deleted by the users are skipped
+func (cm *CacheManager) onEvicted(key, value any) {
+	e := value.(*entry)
+	if cm.evicted == nil || e.deleted.Load() {
+		return
+	}
+	reason := EvictionCapacity
+	if cm.expired(e) {
+		reason = EvictionExpired
+	}
+	cm.evicted(key.(string), reason)
+}
+
 // loadCache loads the cache from the cache file.
 func (cm *CacheManager) loadCache() error {
 	cf, err := os.Open(cm.cacheFile)
@@ -120,7 +182,7 @@ func (cm *CacheManager) loadCache() error {
 			return err
 		}
 		// Add the loaded keys to the front of the LRU list
-		cm.cache.Add(it.Key, it.Value)
+		cm.Set(it.Key, it.Value)
 	}
 
 	return cf.Close()
@@ -186,8 +248,11 @@ func (cm *CacheManager) StopDump() {
 
 // destroy stops the cache dump routine, clears the cache and removes the cache file.
 func (cm *CacheManager) destroy() {
-	cm.StopDump()    // Stop the cache dump routine
-	cm.cache.Purge() // Clear the cache
+	cm.StopDump() // Stop the cache dump routine
+	// Clear the cache
+	for _, k := range cm.cache.Keys() {
+		cm.Delete(k.(string))
+	}
 
 	// Delete the cache file if it exists
 	if _, err := os.Stat(cm.cacheFile); err == nil {
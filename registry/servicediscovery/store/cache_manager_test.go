@@ -150,3 +150,44 @@ func TestMetaInfoCacheManager(t *testing.T) {
 	cm2.destroy()
 	cm.destroy() // clear cache file
 }
+
+func TestCacheManagerEviction(t *testing.T) {
+	cm, err := NewCacheManager("evictTest", "test_evict_cache", defaultTime, 2, false)
+	if err != nil {
+		t.Fatalf("failed to create cache manager: %v", err)
+	}
+	defer cm.destroy()
+	evicted := make(map[string]EvictionReason)
+	cm.SetTTL(50 * time.Millisecond)
+	cm.SetEvictedListener(func(key string, reason EvictionReason) {
+		evicted[key] = reason
+	})
+
+	// Test the deleted entries aren't evicted
+	cm.Set("key1", "value1")
+	cm.Delete("key1")
+	if len(evicted) != 0 {
+		t.Errorf("unexpected evicted entries: %v", evicted)
+	}
+
+	// Test the least recently used entry is evicted when the cache is full
+	cm.Set("key1", "value1")
+	cm.Set("key2", "value2")
+	cm.Set("key3", "value3")
+	if evicted["key1"] != EvictionCapacity {
+		t.Errorf("unexpected eviction of key1: got %q, want %q", evicted["key1"], EvictionCapacity)
+	}
+
+	// Test the expired entries are evicted on access, and the renewed ones are kept
+	time.Sleep(60 * time.Millisecond)
+	cm.Set("key3", "value3")
+	if _, ok := cm.Get("key2"); ok {
+		t.Errorf("key2 was not expired")
+	}
+	if evicted["key2"] != EvictionExpired {
+		t.Errorf("unexpected eviction of key2: got %q, want %q", evicted["key2"], EvictionExpired)
+	}
+	if _, ok := cm.Get("key3"); !ok {
+		t.Errorf("key3 was expired after renewed")
+	}
+}
@@ -93,6 +93,15 @@ func (response *Response) IsHeartbeat() bool {
 }
 
 func (response *Response) Handle() {
+	// the frames of a stream share the id of the request opening the stream
+	if pendingResponse := GetPendingResponse(SequenceType(response.ID)); pendingResponse != nil && pendingResponse.Stream != nil {
+		pendingResponse.Stream.OnFrame(NewStreamFrameFromResponse(response))
+		if pendingResponse.Stream.remoteEnded() {
+			removePendingResponse(SequenceType(response.ID))
+		}
+		return
+	}
+
 	pendingResponse := removePendingResponse(SequenceType(response.ID))
 	if pendingResponse == nil {
 		logger.Errorf("failed to get pending response context for response package %s", *response)
@@ -139,6 +148,8 @@ type PendingResponse struct {
 	response  *Response
 	Reply     any
 	Done      chan struct{}
+	// Stream receives the frames of server if the request opens a stream
+	Stream *Stream
 }
 
 // NewPendingResponse aims to create PendingResponse.
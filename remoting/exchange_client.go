@@ -18,7 +18,9 @@
 package remoting
 
 import (
+	"context"
 	"errors"
+	"strconv"
 	"time"
 )
 
@@ -30,7 +32,9 @@ import (
 
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/protocol/base"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
 	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 )
 
@@ -192,6 +196,73 @@ func (client *ExchangeClient) Send(invocation *base.Invocation, url *common.URL,
 	return nil
 }
 
+// NewStream opens a stream by sending the invocation as a two way request, the responses of the request
+// are delivered to the returned stream. The stream is canceled if ctx is done.
+func (client *ExchangeClient) NewStream(ctx context.Context, inv *invocation.RPCInvocation, url *common.URL,
+	timeout time.Duration, window int) (*Stream, error) {
+	if er := client.doInit(url); er != nil {
+		return nil, er
+	}
+	request := NewRequest("2.0.2")
+	var ivc base.Invocation = inv
+	request.Data = &ivc
+	request.Event = false
+	request.TwoWay = true
+
+	stream := NewStream(ctx, window, func(frame *StreamFrame) error {
+		return client.sendStreamFrame(inv, request.ID, frame, timeout)
+	})
+	stream.endTerminates = true
+
+	rsp := NewPendingResponse(request.ID)
+	rsp.response = NewResponse(request.ID, "2.0.2")
+	rsp.Stream = stream
+	AddPendingResponse(rsp)
+
+	if err := client.client.Request(request, timeout, rsp); err != nil {
+		removePendingResponse(SequenceType(request.ID))
+		stream.Close()
+		return nil, err
+	}
+
+	go func() {
+		<-stream.Context().Done()
+		if removePendingResponse(SequenceType(request.ID)) != nil && !stream.remoteEnded() {
+			// the stream is abandoned by the client, let the server stop as well
+			if err := client.sendStreamFrame(inv, request.ID, &StreamFrame{Type: StreamFrameCancel, Seq: -1}, timeout); err != nil {
+				logger.Warnf("[ExchangeClient.NewStream] failed to cancel stream %d: %v", request.ID, err)
+			}
+		}
+	}()
+	return stream, nil
+}
+
+// sendStreamFrame sends a frame of the stream opened by the request with streamID as an oneway request.
+func (client *ExchangeClient) sendStreamFrame(opening *invocation.RPCInvocation, streamID int64, frame *StreamFrame,
+	timeout time.Duration) error {
+	attachments := frame.ToAttachments()
+	for k, v := range opening.Attachments() {
+		if _, ok := attachments[k]; !ok {
+			attachments[k] = v
+		}
+	}
+	delete(attachments, constant.StreamTypeKey)
+	attachments[constant.StreamIDKey] = strconv.FormatInt(streamID, 10)
+
+	args := []any{}
+	if frame.Type == StreamFrameData {
+		args = append(args, frame.Msg)
+	}
+	var ivc base.Invocation = invocation.NewRPCInvocationWithOptions(invocation.WithMethodName(opening.MethodName()),
+		invocation.WithArguments(args), invocation.WithAttachments(attachments))
+
+	request := NewRequest("2.0.2")
+	request.Data = &ivc
+	request.Event = false
+	request.TwoWay = false
+	return client.client.Request(request, timeout, NewPendingResponse(request.ID))
+}
+
 // Close close the client.
 func (client *ExchangeClient) Close() {
 	client.client.Close()
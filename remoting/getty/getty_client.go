@@ -159,6 +159,8 @@ type Client struct {
 	gettyClientMux     sync.RWMutex
 	gettyClientCreated atomic.Bool
 	codec              remoting.Codec
+	// unixSocket is the unix domain socket of the provider on the same host, which is preferred over addr
+	unixSocket string
 }
 
 // NewClient create client
@@ -190,6 +192,10 @@ func (c *Client) Connect(url *common.URL) error {
 	// codec
 	c.codec = remoting.GetCodec(url.Protocol)
 	c.addr = url.Location
+	// the unix domain socket isn't encrypted, it's only dialed without TLS
+	if !c.sslEnabled {
+		c.unixSocket = common.UnixSocketOf(url)
+	}
 	_, _, err := c.selectSession(c.addr)
 	if err != nil {
 		logger.Errorf("try to connect server %v failed for : %v", url.Location, err)
@@ -230,7 +236,7 @@ func (c *Client) Request(request *remoting.Request, timeout time.Duration, respo
 		return perrors.WithStack(err)
 	}
 
-	if !request.TwoWay || response.Callback != nil {
+	if !request.TwoWay || response.Callback != nil || response.Stream != nil {
 		return nil
 	}
 
@@ -29,8 +29,6 @@ import (
 	"github.com/dubbogo/gost/log/logger"
 	gxsync "github.com/dubbogo/gost/sync"
 
-	perrors "github.com/pkg/errors"
-
 	"gopkg.in/yaml.v2"
 )
 
@@ -140,6 +138,9 @@ type Server struct {
 	tcpServer      getty.Server
 	rpcHandler     *RpcServerHandler
 	requestHandler func(*invocation.RPCInvocation) result.RPCResult
+	// unixSocket is the unix domain socket served by unixServer, which is empty if it isn't served
+	unixSocket string
+	unixServer *unixServer
 }
 
 // NewServer create a new Server
@@ -151,6 +152,7 @@ func NewServer(url *common.URL, handlers func(*invocation.RPCInvocation) result.
 		addr:           url.Location,
 		codec:          remoting.GetCodec(url.Protocol),
 		requestHandler: handlers,
+		unixSocket:     url.GetParam(constant.UnixSocketKey, ""),
 	}
 
 	s.rpcHandler = NewRpcServerHandler(s.conf.SessionNumber, s.conf.sessionTimeout, s)
@@ -181,13 +183,10 @@ func (s *Server) newSession(session getty.Session) error {
 		logger.Debugf("server accepts new session:%s\n", session.Stat())
 		return nil
 	}
-	if _, ok = session.Conn().(*net.TCPConn); !ok {
-		panic(fmt.Sprintf("%s, session.conn{%#v} is not tcp connection\n", session.Stat(), session.Conn()))
-	}
-
-	if _, ok = session.Conn().(*tls.Conn); !ok {
+	// the unix domain socket has no tcp options
+	if _, ok = session.Conn().(*net.UnixConn); !ok {
 		if tcpConn, ok = session.Conn().(*net.TCPConn); !ok {
-			return perrors.New(fmt.Sprintf("%s, session.conn{%#v} is not tcp connection", session.Stat(), session.Conn()))
+			panic(fmt.Sprintf("%s, session.conn{%#v} is not tcp connection\n", session.Stat(), session.Conn()))
 		}
 
 		if err = tcpConn.SetNoDelay(conf.GettySessionParam.TcpNoDelay); err != nil {
@@ -226,6 +225,7 @@ func (s *Server) Start() {
 	var (
 		addr      string
 		tcpServer getty.Server
+		taskPool  gxsync.GenericTaskPool
 	)
 
 	addr = s.addr
@@ -236,15 +236,54 @@ func (s *Server) Start() {
 		logger.Infof("Getty Server initialized the TLSConfig configuration")
 	}
 
-	serverOpts = append(serverOpts, getty.WithServerTaskPool(gxsync.NewTaskPoolSimple(s.conf.GrPoolSize)))
+	taskPool = gxsync.NewTaskPoolSimple(s.conf.GrPoolSize)
+	serverOpts = append(serverOpts, getty.WithServerTaskPool(taskPool))
 
 	tcpServer = getty.NewTCPServer(serverOpts...)
 	tcpServer.RunEventLoop(s.newSession)
 	logger.Debugf("s bind addr{%s} ok!", s.addr)
 	s.tcpServer = tcpServer
+	s.listenUnix(taskPool)
+}
+
+// listenUnix serves the unix domain socket of the server besides the tcp address, the socket is left unserved if
+// the server fails to listen on it
+func (s *Server) listenUnix(taskPool gxsync.GenericTaskPool) {
+	socket := s.unixSocket
+	s.unixSocket = ""
+	path, ok := common.ParseUnixSocket(socket)
+	if !ok {
+		return
+	}
+	if s.conf.SSLEnabled {
+		logger.Warnf("Getty Server with TLS doesn't serve the unix socket %s", socket)
+		return
+	}
+	unixServer, err := newUnixServer(path, taskPool)
+	if err != nil {
+		logger.Warnf("Getty Server doesn't serve the unix socket %s, err: %v", socket, err)
+		return
+	}
+	unixServer.RunEventLoop(s.newSession)
+	logger.Infof("Getty Server serves the unix socket %s", socket)
+	s.unixServer = unixServer
+	s.unixSocket = socket
+}
+
+// SyncUnixSocketParams keeps the params of the unix domain socket on the url only if the socket is served by the
+// server, the consumers dial the tcp address otherwise
+func (s *Server) SyncUnixSocketParams(url *common.URL) {
+	if s.unixSocket != "" && url.GetParam(constant.UnixSocketKey, "") == s.unixSocket {
+		return
+	}
+	url.DelParam(constant.UnixSocketKey)
+	url.DelParam(constant.HostIdKey)
 }
 
 // Stop dubbo server
 func (s *Server) Stop() {
 	s.tcpServer.Close()
+	if s.unixServer != nil {
+		s.unixServer.Close()
+	}
 }
@@ -18,6 +18,8 @@
 package getty
 
 import (
+	"context"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -37,6 +39,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
 	"dubbo.apache.org/dubbo-go/v3/remoting"
 )
 
@@ -175,6 +178,32 @@ type RpcServerHandler struct {
 	rwlock         sync.RWMutex
 	server         *Server
 	timeoutTimes   int
+	streamMu       sync.Mutex
+	// streams opened by clients
+	streams map[streamKey]*remoting.Stream
+	// frames arrived before the request opening their stream, since messages may be handled concurrently
+	earlyFrames map[streamKey]*earlyStreamFrames
+}
+
+type streamKey struct {
+	session getty.Session
+	id      int64
+}
+
+// The frames arrived before their stream is opened are bounded, since the stream may never be opened.
+// They are a few in practice, the opening request is handled concurrently with them just now.
+const (
+	// maxEarlyStreamFrames is the max frames kept for a stream, the stream is reset once it's opened if exceeded
+	maxEarlyStreamFrames = 4 * constant.DefaultStreamWindow
+	// maxEarlyStreams is the max streams whose frames are kept, the frames of more streams are discarded
+	maxEarlyStreams = 1024
+)
+
+type earlyStreamFrames struct {
+	frames []*remoting.StreamFrame
+	since  time.Time
+	// overflowed means the frames are discarded since there are too many of them
+	overflowed bool
 }
 
 // NewRpcServerHandler constructs a RpcServerHandler.
@@ -184,6 +213,8 @@ func NewRpcServerHandler(maxSessionNum int, sessionTimeout time.Duration, server
 		sessionTimeout: sessionTimeout,
 		sessionMap:     make(map[getty.Session]*rpcSession),
 		server:         serverP,
+		streams:        make(map[streamKey]*remoting.Stream),
+		earlyFrames:    make(map[streamKey]*earlyStreamFrames),
 	}
 }
 
@@ -213,6 +244,7 @@ func (h *RpcServerHandler) OnError(session getty.Session, err error) {
 	h.rwlock.Lock()
 	delete(h.sessionMap, session)
 	h.rwlock.Unlock()
+	h.cancelStreams(session)
 }
 
 // OnClose close the session, remove it from the getty server list
@@ -221,6 +253,7 @@ func (h *RpcServerHandler) OnClose(session getty.Session) {
 	h.rwlock.Lock()
 	delete(h.sessionMap, session)
 	h.rwlock.Unlock()
+	h.cancelStreams(session)
 }
 
 // OnMessage get request from getty client, update the session reqNum and reply response to client
@@ -272,6 +305,16 @@ func (h *RpcServerHandler) OnMessage(session getty.Session, pkg any) {
 	attachments[constant.LocalAddr] = session.LocalAddr()
 	attachments[constant.RemoteAddr] = session.RemoteAddr()
 
+	// frame of an opened stream
+	if streamID, ok := attachments[constant.StreamIDKey].(string); ok {
+		h.dispatchStreamFrame(session, streamID, invoc)
+		return
+	}
+	if streamType, ok := attachments[constant.StreamTypeKey].(string); ok && streamType != "" && req.TwoWay {
+		h.serveStream(session, req, resp, invoc)
+		return
+	}
+
 	result := h.server.requestHandler(invoc)
 	if !req.TwoWay {
 		return
@@ -281,6 +324,161 @@ func (h *RpcServerHandler) OnMessage(session getty.Session, pkg any) {
 	reply(session, resp)
 }
 
+// serveStream handles the request opening a stream. The request handler runs in a new goroutine
+// so that the following frames of the stream can be read from the session, and its result is sent
+// as the end frame of the stream.
+func (h *RpcServerHandler) serveStream(session getty.Session, req *remoting.Request, resp *remoting.Response,
+	invoc *invocation.RPCInvocation) {
+	if version := invoc.GetAttachmentWithDefaultValue(constant.StreamVersionKey, ""); version != constant.StreamVersion {
+		// the consumer speaks another version of the stream extension, fail the stream rather than guessing
+		resp.Result = result.RPCResult{Err: perrors.Errorf("unsupported dubbo-go stream version %q, want %s",
+			version, constant.StreamVersion)}
+		reply(session, resp)
+		return
+	}
+	window, err := strconv.Atoi(invoc.GetAttachmentWithDefaultValue(constant.StreamWindowKey, ""))
+	if err != nil {
+		window = constant.DefaultStreamWindow
+	}
+	key := streamKey{session: session, id: req.ID}
+	stream := remoting.NewStream(context.Background(), window, func(frame *remoting.StreamFrame) error {
+		frameResp := remoting.NewResponse(req.ID, "2.0.2")
+		frameResp.Status = hessian.Response_OK
+		frameResp.SerialID = req.SerialID
+		frameResp.Result = result.RPCResult{Rest: frame.Msg, Attrs: streamFrameAttachments(frame.ToAttachments())}
+		if _, _, err := session.WritePkg(frameResp, WritePkg_Timeout); err != nil {
+			return perrors.WithStack(err)
+		}
+		return nil
+	})
+	invoc.SetAttribute(constant.StreamKey, stream)
+
+	h.streamMu.Lock()
+	h.streams[key] = stream
+	early := h.earlyFrames[key]
+	delete(h.earlyFrames, key)
+	h.streamMu.Unlock()
+	if early != nil {
+		if early.overflowed {
+			logger.Warnf("Too many frames of stream %d arrived before it's opened, reset the stream", req.ID)
+			stream.Cancel()
+		}
+		for _, frame := range early.frames {
+			stream.OnFrame(frame)
+		}
+	}
+
+	go func() {
+		defer func() {
+			h.streamMu.Lock()
+			delete(h.streams, key)
+			h.streamMu.Unlock()
+			stream.Close()
+		}()
+		res := h.server.requestHandler(invoc)
+		if end := stream.EndFrame(res.Attrs); end != nil {
+			res.Attrs = end.ToAttachments()
+		}
+		res.Attrs = streamFrameAttachments(res.Attrs)
+		resp.Result = res
+		reply(session, resp)
+	}()
+}
+
+// dispatchStreamFrame delivers the frame sent by client to the stream it belongs to.
+func (h *RpcServerHandler) dispatchStreamFrame(session getty.Session, streamID string, invoc *invocation.RPCInvocation) {
+	id, err := strconv.ParseInt(streamID, 10, 64)
+	if err != nil {
+		logger.Errorf("illegal stream id %s of stream frame", streamID)
+		return
+	}
+	var msg any
+	if args := invoc.Arguments(); len(args) > 0 {
+		msg = args[0]
+	}
+	frame := remoting.NewStreamFrame(invoc.Attachments(), msg, nil)
+
+	key := streamKey{session: session, id: id}
+	h.streamMu.Lock()
+	stream, ok := h.streams[key]
+	if !ok {
+		// the stream may be opened later, the frames are discarded by OnCron if it is not
+		h.keepEarlyFrameLocked(key, frame)
+	}
+	h.streamMu.Unlock()
+	if ok {
+		stream.OnFrame(frame)
+	}
+}
+
+// keepEarlyFrameLocked keeps the frame until its stream is opened, it must be called with h.streamMu held.
+func (h *RpcServerHandler) keepEarlyFrameLocked(key streamKey, frame *remoting.StreamFrame) {
+	early, exist := h.earlyFrames[key]
+	if !exist {
+		if len(h.earlyFrames) >= maxEarlyStreams {
+			logger.Warnf("Too many streams are not opened yet, discard the frame of stream %d", key.id)
+			return
+		}
+		early = &earlyStreamFrames{since: time.Now()}
+		h.earlyFrames[key] = early
+	}
+	if early.overflowed {
+		return
+	}
+	if len(early.frames) >= maxEarlyStreamFrames {
+		early.frames = nil
+		early.overflowed = true
+		return
+	}
+	early.frames = append(early.frames, frame)
+}
+
+// cancelStreams cancels the streams opened on the session.
+func (h *RpcServerHandler) cancelStreams(session getty.Session) {
+	var canceled []*remoting.Stream
+	h.streamMu.Lock()
+	for key, stream := range h.streams {
+		if key.session == session {
+			canceled = append(canceled, stream)
+			delete(h.streams, key)
+		}
+	}
+	for key := range h.earlyFrames {
+		if key.session == session {
+			delete(h.earlyFrames, key)
+		}
+	}
+	h.streamMu.Unlock()
+	for _, stream := range canceled {
+		stream.OnFrame(&remoting.StreamFrame{Type: remoting.StreamFrameCancel, Seq: -1})
+	}
+}
+
+// expireEarlyFrames discards the frames of the session whose stream is not opened in time,
+// which happens when the frames arrive after the stream is finished.
+func (h *RpcServerHandler) expireEarlyFrames(session getty.Session) {
+	h.streamMu.Lock()
+	defer h.streamMu.Unlock()
+	for key, early := range h.earlyFrames {
+		if key.session == session && time.Since(early.since) > h.sessionTimeout {
+			delete(h.earlyFrames, key)
+		}
+	}
+}
+
+// streamFrameAttachments makes sure the attachments of response are encoded and tell the consumer
+// that the stream is served.
+func streamFrameAttachments(attachments map[string]any) map[string]any {
+	if attachments == nil {
+		attachments = make(map[string]any, 2)
+	}
+	attachments[constant.StreamVersionKey] = constant.StreamVersion
+	if _, ok := attachments[constant.Dubbo]; !ok {
+		attachments[constant.Dubbo] = "2.0.2"
+	}
+	return attachments
+}
+
 // OnCron check the session health periodic. if the session's sessionTimeout has reached, just close the session
 func (h *RpcServerHandler) OnCron(session getty.Session) {
 	var (
@@ -298,6 +496,7 @@ func (h *RpcServerHandler) OnCron(session getty.Session) {
 		}
 	}
 	h.rwlock.RUnlock()
+	h.expireEarlyFrames(session)
 
 	if flag {
 		h.rwlock.Lock()
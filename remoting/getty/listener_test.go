@@ -32,6 +32,7 @@ import (
 import (
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
 )
 
 // test rebuild the ctx
@@ -74,3 +75,27 @@ func rebuildCtx(inv *invocation.RPCInvocation) context.Context {
 	}
 	return ctx
 }
+
+func TestKeepEarlyFrame(t *testing.T) {
+	h := NewRpcServerHandler(1, 0, nil)
+	frame := &remoting.StreamFrame{Type: remoting.StreamFrameData, Seq: 0}
+
+	key := streamKey{id: 1}
+	for i := 0; i < maxEarlyStreamFrames; i++ {
+		h.keepEarlyFrameLocked(key, frame)
+	}
+	assert.Len(t, h.earlyFrames[key].frames, maxEarlyStreamFrames)
+	// the frames are discarded once they are too many
+	h.keepEarlyFrameLocked(key, frame)
+	assert.True(t, h.earlyFrames[key].overflowed)
+	assert.Empty(t, h.earlyFrames[key].frames)
+	h.keepEarlyFrameLocked(key, frame)
+	assert.Empty(t, h.earlyFrames[key].frames)
+
+	// the frames of too many streams are discarded
+	for i := 2; i <= maxEarlyStreams+1; i++ {
+		h.keepEarlyFrameLocked(streamKey{id: int64(i)}, frame)
+	}
+	assert.Len(t, h.earlyFrames, maxEarlyStreams)
+	assert.NotContains(t, h.earlyFrames, streamKey{id: maxEarlyStreams + 1})
+}
@@ -67,12 +67,28 @@ func newGettyRPCClientConn(rpcClient *Client, addr string) (*gettyRPCClient, err
 		clientOpts = append(clientOpts, getty.WithClientTaskPool(clientGrPool))
 	}
 
-	gettyClient = getty.NewTCPClient(clientOpts...)
 	c := &gettyRPCClient{
-		addr:        addr,
-		rpcClient:   rpcClient,
-		gettyClient: gettyClient,
+		addr:      addr,
+		rpcClient: rpcClient,
 	}
+	// the unix domain socket of the provider on the same host is preferred, the tcp address is dialed if nobody
+	// listens on the socket, e.g. the socket is left by the provider exited abnormally
+	if path := rpcClient.unixSocket; path != "" {
+		unixClient := newUnixClient(path, (int)(rpcClient.conf.ConnectionNum), rpcClient.conf.ReconnectInterval, clientGrPool)
+		if err := unixClient.connect(c.newSession); err != nil {
+			logger.Warnf("Getty client dials the unix socket %s failed, fall back to %s, err: %v", path, addr, err)
+			unixClient.Close()
+		} else {
+			logger.Infof("Getty client dials the provider %s over the unix socket %s", addr, path)
+			gettyClient = unixClient
+		}
+	}
+	if gettyClient == nil {
+		gettyClient = getty.NewTCPClient(clientOpts...)
+	}
+	c.lock.Lock()
+	c.gettyClient = gettyClient
+	c.lock.Unlock()
 	go c.gettyClient.RunEventLoop(c.newSession)
 
 	idx := 1
@@ -132,26 +148,29 @@ func (c *gettyRPCClient) newSession(session getty.Session) error {
 		logger.Debugf("client new session:%s\n", session.Stat())
 		return nil
 	}
-	if tcpConn, ok = session.Conn().(*net.TCPConn); !ok {
-		panic(fmt.Sprintf("%s, session.conn{%#v} is not tcp connection\n", session.Stat(), session.Conn()))
-	}
+	// the unix domain socket has no tcp options
+	if _, ok = session.Conn().(*net.UnixConn); !ok {
+		if tcpConn, ok = session.Conn().(*net.TCPConn); !ok {
+			panic(fmt.Sprintf("%s, session.conn{%#v} is not tcp connection\n", session.Stat(), session.Conn()))
+		}
 
-	if err := tcpConn.SetNoDelay(conf.GettySessionParam.TcpNoDelay); err != nil {
-		logger.Error("tcpConn.SetNoDelay() = error:%v", err)
-	}
-	if err := tcpConn.SetKeepAlive(conf.GettySessionParam.TcpKeepAlive); err != nil {
-		logger.Error("tcpConn.SetKeepAlive() = error:%v", err)
-	}
-	if conf.GettySessionParam.TcpKeepAlive {
-		if err := tcpConn.SetKeepAlivePeriod(conf.GettySessionParam.keepAlivePeriod); err != nil {
-			logger.Error("tcpConn.SetKeepAlivePeriod() = error:%v", err)
+		if err := tcpConn.SetNoDelay(conf.GettySessionParam.TcpNoDelay); err != nil {
+			logger.Error("tcpConn.SetNoDelay() = error:%v", err)
+		}
+		if err := tcpConn.SetKeepAlive(conf.GettySessionParam.TcpKeepAlive); err != nil {
+			logger.Error("tcpConn.SetKeepAlive() = error:%v", err)
+		}
+		if conf.GettySessionParam.TcpKeepAlive {
+			if err := tcpConn.SetKeepAlivePeriod(conf.GettySessionParam.keepAlivePeriod); err != nil {
+				logger.Error("tcpConn.SetKeepAlivePeriod() = error:%v", err)
+			}
+		}
+		if err := tcpConn.SetReadBuffer(conf.GettySessionParam.TcpRBufSize); err != nil {
+			logger.Error("tcpConn.SetReadBuffer() = error:%v", err)
+		}
+		if err := tcpConn.SetWriteBuffer(conf.GettySessionParam.TcpWBufSize); err != nil {
+			logger.Error("tcpConn.SetWriteBuffer() = error:%v", err)
 		}
-	}
-	if err := tcpConn.SetReadBuffer(conf.GettySessionParam.TcpRBufSize); err != nil {
-		logger.Error("tcpConn.SetReadBuffer() = error:%v", err)
-	}
-	if err := tcpConn.SetWriteBuffer(conf.GettySessionParam.TcpWBufSize); err != nil {
-		logger.Error("tcpConn.SetWriteBuffer() = error:%v", err)
 	}
 
 	session.SetName(conf.GettySessionParam.SessionName)
@@ -0,0 +1,695 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package getty
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+import (
+	getty "github.com/apache/dubbo-getty"
+
+	"github.com/dubbogo/gost/log/logger"
+	gxsync "github.com/dubbogo/gost/sync"
+
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+)
+
+// The listeners, the dialers and the sessions of getty v1.4.x are bound to tcp, so the unix domain sockets are
+// served and dialed by the session and the endpoints below, which follow the behaviors of the getty tcp ones except
+// that the data isn't compressed and the socket isn't encrypted, since both peers are on the same host.
+
+const (
+	unixReadBufLen        = 4 * 1024
+	unixCronPeriod        = time.Minute
+	unixConnectTimeout    = 3 * time.Second
+	unixReconnectInterval = 300 * time.Millisecond
+	unixMaxReconnectTimes = 10
+)
+
+var (
+	unixSessionID  atomic.Uint32
+	unixEndPointID atomic.Int32
+
+	_ getty.Session = (*unixSession)(nil)
+	_ getty.Server  = (*unixServer)(nil)
+	_ getty.Client  = (*unixClient)(nil)
+)
+
+// unixSession is the getty session over the unix domain socket
+type unixSession struct {
+	id       uint32
+	conn     net.Conn
+	endPoint getty.EndPoint
+	base     *unixEndPoint
+
+	lock      sync.RWMutex
+	name      string
+	listener  getty.EventListener
+	reader    getty.Reader
+	writer    getty.Writer
+	maxMsgLen int
+	period    time.Duration
+	rTimeout  time.Duration
+	wTimeout  time.Duration
+	attrs     map[any]any
+
+	writeLock   sync.Mutex
+	active      atomic.Int64
+	readBytes   atomic.Uint32
+	writeBytes  atomic.Uint32
+	readPkgNum  atomic.Uint32
+	writePkgNum atomic.Uint32
+
+	once sync.Once
+	done chan struct{}
+}
+
+func newUnixSession(conn net.Conn, endPoint getty.EndPoint, base *unixEndPoint) *unixSession {
+	return &unixSession{
+		id:       unixSessionID.Add(1),
+		conn:     conn,
+		endPoint: endPoint,
+		base:     base,
+		period:   unixCronPeriod,
+		attrs:    make(map[any]any),
+		done:     make(chan struct{}),
+	}
+}
+
+func (s *unixSession) ID() uint32 {
+	return s.id
+}
+
+// SetCompressType does nothing, the data over the unix domain socket isn't compressed
+func (s *unixSession) SetCompressType(getty.CompressType) {}
+
+func (s *unixSession) LocalAddr() string {
+	return s.conn.LocalAddr().String()
+}
+
+func (s *unixSession) RemoteAddr() string {
+	return s.conn.RemoteAddr().String()
+}
+
+func (s *unixSession) IncReadPkgNum() {
+	s.readPkgNum.Add(1)
+}
+
+func (s *unixSession) IncWritePkgNum() {
+	s.writePkgNum.Add(1)
+}
+
+func (s *unixSession) UpdateActive() {
+	s.active.Store(time.Now().UnixNano())
+}
+
+func (s *unixSession) GetActive() time.Time {
+	return time.Unix(0, s.active.Load())
+}
+
+func (s *unixSession) ReadTimeout() time.Duration {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.rTimeout
+}
+
+// SetReadTimeout sets the read timeout, which is only used to tell the idle sessions like getty
+func (s *unixSession) SetReadTimeout(timeout time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.rTimeout = timeout
+}
+
+func (s *unixSession) WriteTimeout() time.Duration {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.wTimeout
+}
+
+func (s *unixSession) SetWriteTimeout(timeout time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.wTimeout = timeout
+}
+
+func (s *unixSession) Send(pkg any) (int, error) {
+	switch p := pkg.(type) {
+	case []byte:
+		return s.send(0, p)
+	case [][]byte:
+		return s.send(0, p...)
+	default:
+		return 0, perrors.Errorf("illegal @pkg{%#v} type", pkg)
+	}
+}
+
+func (s *unixSession) CloseConn(int) {
+	_ = s.conn.Close()
+}
+
+func (s *unixSession) SetSession(getty.Session) {}
+
+// Reset does nothing, the session isn't reused
+func (s *unixSession) Reset() {}
+
+func (s *unixSession) Conn() net.Conn {
+	return s.conn
+}
+
+func (s *unixSession) Stat() string {
+	return fmt.Sprintf("session %s, Read Bytes: %d, Write Bytes: %d, Read Pkgs: %d, Write Pkgs: %d",
+		s.sessionToken(), s.readBytes.Load(), s.writeBytes.Load(), s.readPkgNum.Load(), s.writePkgNum.Load())
+}
+
+func (s *unixSession) sessionToken() string {
+	if s.IsClosed() {
+		return "session-closed"
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return fmt.Sprintf("{%s:unix:%d:%s<->%s}", s.name, s.id, s.LocalAddr(), s.RemoteAddr())
+}
+
+func (s *unixSession) IsClosed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *unixSession) EndPoint() getty.EndPoint {
+	return s.endPoint
+}
+
+func (s *unixSession) SetMaxMsgLen(length int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.maxMsgLen = length
+}
+
+func (s *unixSession) SetName(name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.name = name
+}
+
+func (s *unixSession) SetEventListener(listener getty.EventListener) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.listener = listener
+}
+
+func (s *unixSession) SetPkgHandler(handler getty.ReadWriter) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.reader = handler
+	s.writer = handler
+}
+
+func (s *unixSession) SetReader(reader getty.Reader) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.reader = reader
+}
+
+func (s *unixSession) SetWriter(writer getty.Writer) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.writer = writer
+}
+
+// SetCronPeriod sets the period of (EventListener)OnCron in millisecond
+func (s *unixSession) SetCronPeriod(period int) {
+	if period < 1 {
+		panic("@period < 1")
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.period = time.Duration(period) * time.Millisecond
+}
+
+// SetWaitTime does nothing, the connection is closed as soon as the session is closed
+func (s *unixSession) SetWaitTime(time.Duration) {}
+
+func (s *unixSession) GetAttribute(key any) any {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.attrs[key]
+}
+
+func (s *unixSession) SetAttribute(key any, value any) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.attrs[key] = value
+}
+
+func (s *unixSession) RemoveAttribute(key any) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.attrs, key)
+}
+
+func (s *unixSession) WritePkg(pkg any, timeout time.Duration) (int, int, error) {
+	if pkg == nil {
+		return 0, 0, perrors.New("@pkg is nil")
+	}
+	if s.IsClosed() {
+		return 0, 0, getty.ErrSessionClosed
+	}
+	s.lock.RLock()
+	writer := s.writer
+	s.lock.RUnlock()
+
+	pkgBytes, err := writer.Write(s, pkg)
+	if err != nil {
+		logger.Warnf("%s, [unixSession.WritePkg] session.writer.Write(@pkg:%#v) = error:%+v", s.Stat(), pkg, err)
+		return len(pkgBytes), 0, perrors.WithStack(err)
+	}
+	sendLen, err := s.send(timeout, pkgBytes)
+	if err != nil {
+		logger.Warnf("%s, [unixSession.WritePkg] conn.Write(pkg len:%d) = err:%+v", s.Stat(), len(pkgBytes), err)
+		return len(pkgBytes), sendLen, perrors.WithStack(err)
+	}
+	s.IncWritePkgNum()
+	return len(pkgBytes), sendLen, nil
+}
+
+func (s *unixSession) WriteBytes(pkg []byte) (int, error) {
+	return s.WriteBytesArray(pkg)
+}
+
+func (s *unixSession) WriteBytesArray(pkgs ...[]byte) (int, error) {
+	if s.IsClosed() {
+		return 0, getty.ErrSessionClosed
+	}
+	n, err := s.send(0, pkgs...)
+	return n, perrors.WithStack(err)
+}
+
+// send writes the packages at once, the write timeout of the session is used if @timeout is not positive
+func (s *unixSession) send(timeout time.Duration, pkgs ...[]byte) (int, error) {
+	if timeout <= 0 {
+		timeout = s.WriteTimeout()
+	}
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	if timeout > 0 {
+		if err := s.conn.SetWriteDeadline(time.Now().Add(timeout)); err != nil {
+			return 0, err
+		}
+	}
+	bufs := net.Buffers(pkgs)
+	n, err := bufs.WriteTo(s.conn)
+	s.writeBytes.Add(uint32(n))
+	if err == nil {
+		s.UpdateActive()
+	}
+	return int(n), err
+}
+
+// run opens the session, and then reads the packages and invokes (EventListener)OnCron periodically until the
+// session is closed
+func (s *unixSession) run() {
+	s.UpdateActive()
+	if err := s.listener.OnOpen(s); err != nil {
+		logger.Errorf("[OnOpen] session %s, error: %#v", s.Stat(), err)
+		s.Close()
+		return
+	}
+	go s.cron()
+	go s.handlePackage()
+}
+
+func (s *unixSession) cron() {
+	s.lock.RLock()
+	period := s.period
+	s.lock.RUnlock()
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.addTask(func() {
+				s.listener.OnCron(s)
+			})
+		}
+	}
+}
+
+func (s *unixSession) handlePackage() {
+	var err error
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf("[unixSession.handlePackage] panic session %s: err=%v\n%s", s.sessionToken(), r, debug.Stack())
+		}
+		s.stop()
+		if err != nil {
+			logger.Errorf("%s, [unixSession.handlePackage] error:%+v", s.sessionToken(), err)
+			s.listener.OnError(s, err)
+		}
+		s.listener.OnClose(s)
+	}()
+	err = s.readPackages()
+}
+
+func (s *unixSession) readPackages() error {
+	s.lock.RLock()
+	reader, maxMsgLen := s.reader, s.maxMsgLen
+	s.lock.RUnlock()
+
+	var pktBuf bytes.Buffer
+	buf := make([]byte, unixReadBufLen)
+	for {
+		bufLen, err := s.conn.Read(buf)
+		if bufLen > 0 {
+			s.readBytes.Add(uint32(bufLen))
+			pktBuf.Write(buf[:bufLen])
+			for pktBuf.Len() > 0 {
+				pkg, pkgLen, readErr := reader.Read(s, pktBuf.Bytes())
+				if readErr == nil && maxMsgLen > 0 && pkgLen > maxMsgLen {
+					readErr = perrors.Errorf("pkgLen %d > session max message len %d", pkgLen, maxMsgLen)
+				}
+				if readErr != nil {
+					return perrors.WithStack(readErr)
+				}
+				// the package is incomplete
+				if pkg == nil {
+					break
+				}
+				s.UpdateActive()
+				s.addTask(func() {
+					s.listener.OnMessage(s, pkg)
+					s.IncReadPkgNum()
+				})
+				pktBuf.Next(pkgLen)
+			}
+		}
+		if err != nil {
+			if s.IsClosed() || errors.Is(err, io.EOF) {
+				return nil
+			}
+			return perrors.WithStack(err)
+		}
+	}
+}
+
+// addTask runs the task by the task pool of the endpoint if there is one, the task is dropped after the session is
+// closed
+func (s *unixSession) addTask(task func()) {
+	f := func() {
+		if s.IsClosed() {
+			return
+		}
+		task()
+	}
+	if taskPool := s.endPoint.GetTaskPool(); taskPool != nil {
+		taskPool.AddTaskAlways(f)
+		return
+	}
+	f()
+}
+
+func (s *unixSession) stop() {
+	s.once.Do(func() {
+		close(s.done)
+		_ = s.conn.Close()
+		s.base.removeSession(s)
+	})
+}
+
+// Close closes the session, (EventListener)OnClose is invoked once the reading goroutine exits
+func (s *unixSession) Close() {
+	s.stop()
+	logger.Infof("%s closed now", s.sessionToken())
+}
+
+// unixEndPoint is the base of the unix domain socket server and client, which keeps the sessions
+type unixEndPoint struct {
+	id           getty.EndPointID
+	endPointType getty.EndPointType
+	taskPool     gxsync.GenericTaskPool
+
+	lock     sync.Mutex
+	sessions map[*unixSession]struct{}
+	// onSessionClosed is invoked after a session is removed while the endpoint is open
+	onSessionClosed func()
+
+	once sync.Once
+	done chan struct{}
+}
+
+func newUnixEndPoint(endPointType getty.EndPointType, taskPool gxsync.GenericTaskPool) unixEndPoint {
+	return unixEndPoint{
+		id:           unixEndPointID.Add(1),
+		endPointType: endPointType,
+		taskPool:     taskPool,
+		sessions:     make(map[*unixSession]struct{}),
+		done:         make(chan struct{}),
+	}
+}
+
+func (e *unixEndPoint) ID() getty.EndPointID {
+	return e.id
+}
+
+func (e *unixEndPoint) EndPointType() getty.EndPointType {
+	return e.endPointType
+}
+
+func (e *unixEndPoint) GetTaskPool() gxsync.GenericTaskPool {
+	return e.taskPool
+}
+
+func (e *unixEndPoint) IsClosed() bool {
+	select {
+	case <-e.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// addSession keeps the session, it returns false if the endpoint has been closed
+func (e *unixEndPoint) addSession(ss *unixSession) bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.IsClosed() {
+		return false
+	}
+	e.sessions[ss] = struct{}{}
+	return true
+}
+
+func (e *unixEndPoint) removeSession(ss *unixSession) {
+	e.lock.Lock()
+	_, ok := e.sessions[ss]
+	delete(e.sessions, ss)
+	onSessionClosed := e.onSessionClosed
+	e.lock.Unlock()
+
+	if ok && onSessionClosed != nil && !e.IsClosed() {
+		onSessionClosed()
+	}
+}
+
+func (e *unixEndPoint) sessionNum() int {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return len(e.sessions)
+}
+
+// close closes the endpoint and its sessions, it returns false if the endpoint has been closed
+func (e *unixEndPoint) close() bool {
+	closed := false
+	e.once.Do(func() {
+		e.lock.Lock()
+		close(e.done)
+		sessions := make([]*unixSession, 0, len(e.sessions))
+		for ss := range e.sessions {
+			sessions = append(sessions, ss)
+		}
+		e.lock.Unlock()
+
+		for _, ss := range sessions {
+			ss.Close()
+		}
+		closed = true
+	})
+	return closed
+}
+
+// unixServer accepts the sessions over the unix domain socket
+type unixServer struct {
+	unixEndPoint
+	path     string
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+// newUnixServer listens on the unix domain socket, the socket left by the former process is removed first
+func newUnixServer(path string, taskPool gxsync.GenericTaskPool) (*unixServer, error) {
+	if err := common.RemoveStaleUnixSocket(path); err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, perrors.WithStack(err)
+	}
+	return &unixServer{
+		unixEndPoint: newUnixEndPoint(getty.TCP_SERVER, taskPool),
+		path:         path,
+		listener:     listener,
+	}, nil
+}
+
+func (s *unixServer) RunEventLoop(newSession getty.NewSessionCallback) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			conn, err := s.listener.Accept()
+			if err != nil {
+				if s.IsClosed() || errors.Is(err, net.ErrClosed) {
+					logger.Infof("unix server{%s} stops accepting the connections", s.path)
+					return
+				}
+				logger.Warnf("unix server{%s}.Accept() = err:%+v", s.path, err)
+				time.Sleep(unixReconnectInterval)
+				continue
+			}
+			ss := newUnixSession(conn, s, &s.unixEndPoint)
+			if err = newSession(ss); err != nil {
+				logger.Warnf("unix server{%s} rejects the new session, err:%+v", s.path, err)
+				_ = conn.Close()
+				continue
+			}
+			if !s.addSession(ss) {
+				_ = conn.Close()
+				return
+			}
+			ss.run()
+		}
+	}()
+}
+
+// Close stops listening on the unix domain socket and closes the sessions
+func (s *unixServer) Close() {
+	if s.close() {
+		_ = s.listener.Close()
+		s.wg.Wait()
+	}
+}
+
+// unixClient keeps @number sessions to the unix domain socket, the closed sessions are reconnected
+type unixClient struct {
+	unixEndPoint
+	path              string
+	number            int
+	reconnectInterval time.Duration
+	newSession        atomic.Pointer[getty.NewSessionCallback]
+	reconnecting      atomic.Bool
+}
+
+// newUnixClient creates the client of the unix domain socket, the reconnect interval is in nanosecond like getty
+func newUnixClient(path string, number int, reconnectInterval int, taskPool gxsync.GenericTaskPool) *unixClient {
+	c := &unixClient{
+		unixEndPoint:      newUnixEndPoint(getty.TCP_CLIENT, taskPool),
+		path:              path,
+		number:            number,
+		reconnectInterval: time.Duration(reconnectInterval),
+	}
+	if c.reconnectInterval <= 0 {
+		c.reconnectInterval = unixReconnectInterval
+	}
+	c.onSessionClosed = func() {
+		go c.reconnect()
+	}
+	return c
+}
+
+// connect dials a session to the unix domain socket
+func (c *unixClient) connect(newSession getty.NewSessionCallback) error {
+	conn, err := net.DialTimeout("unix", c.path, unixConnectTimeout)
+	if err != nil {
+		return perrors.WithStack(err)
+	}
+	ss := newUnixSession(conn, c, &c.unixEndPoint)
+	if err = newSession(ss); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	if !c.addSession(ss) {
+		_ = conn.Close()
+		return errClientClosed
+	}
+	ss.run()
+	return nil
+}
+
+// RunEventLoop connects the sessions until there are @number ones
+func (c *unixClient) RunEventLoop(newSession getty.NewSessionCallback) {
+	c.newSession.Store(&newSession)
+	c.reconnect()
+}
+
+func (c *unixClient) reconnect() {
+	newSession := c.newSession.Load()
+	if newSession == nil || !c.reconnecting.CompareAndSwap(false, true) {
+		return
+	}
+	defer c.reconnecting.Store(false)
+
+	times := 0
+	for !c.IsClosed() && c.sessionNum() < c.number {
+		err := c.connect(*newSession)
+		if err == nil {
+			times = 0
+			continue
+		}
+		logger.Infof("unix client{%s} connects failed, err:%+v", c.path, err)
+		if times < unixMaxReconnectTimes {
+			times++
+		}
+		select {
+		case <-c.done:
+		case <-time.After(time.Duration(times) * c.reconnectInterval):
+		}
+	}
+}
+
+func (c *unixClient) Close() {
+	c.close()
+}
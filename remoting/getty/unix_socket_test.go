@@ -0,0 +1,188 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package getty
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+import (
+	getty "github.com/apache/dubbo-getty"
+
+	hessian "github.com/apache/dubbo-go-hessian2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common"
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/invocation"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+	"dubbo.apache.org/dubbo-go/v3/remoting"
+)
+
+func initUnixSocketTest(t *testing.T) {
+	t.Setenv(constant.HostIdEnvKey, "node-1")
+	hessian.RegisterPOJO(&User{})
+	remoting.RegistryCodec("dubbo", &DubboTestCodec{})
+
+	sessionParam := GettySessionParam{
+		TcpNoDelay:      true,
+		TcpKeepAlive:    true,
+		KeepAlivePeriod: "120s",
+		TcpRBufSize:     262144,
+		TcpWBufSize:     65536,
+		TcpReadTimeout:  "1s",
+		TcpWriteTimeout: "5s",
+		WaitTimeout:     "1s",
+		MaxMsgLen:       1024000,
+		SessionName:     "unix",
+	}
+	SetClientConf(ClientConfig{
+		ConnectionNum:     2,
+		HeartbeatPeriod:   "5s",
+		SessionTimeout:    "20s",
+		GettySessionParam: sessionParam,
+	})
+	assert.NoError(t, clientConf.CheckValidity())
+	SetServerConfig(ServerConfig{
+		SessionNumber:     700,
+		SessionTimeout:    "20s",
+		GettySessionParam: sessionParam,
+	})
+	assert.NoError(t, srvConf.CheckValidity())
+}
+
+func newUnixSocketServer(t *testing.T, socket string) (*Server, *common.URL) {
+	url, err := common.NewURL("dubbo://127.0.0.1:0/com.example.UnixService?interface=com.example.UnixService&" +
+		"unix-socket=" + socket + "&host-id=node-1")
+	require.NoError(t, err)
+	server := NewServer(url, func(invocation *invocation.RPCInvocation) result.RPCResult {
+		return result.RPCResult{Rest: &User{ID: invocation.Arguments()[0].(string), Name: "unix"}}
+	})
+	server.Start()
+	t.Cleanup(server.Stop)
+	server.SyncUnixSocketParams(url)
+	return server, url
+}
+
+// consumerURL returns the url of the server registered, which is dialed by the consumer
+func consumerURL(t *testing.T, server *Server, provider *common.URL) *common.URL {
+	url := provider.Clone()
+	url.Port = strconv.Itoa(server.tcpServer.(getty.StreamServer).Listener().Addr().(*net.TCPAddr).Port)
+	url.Location = url.Ip + ":" + url.Port
+	return url
+}
+
+func callGetUser(t *testing.T, client *Client, id string) *User {
+	request := remoting.NewRequest("2.0.2")
+	invocation := createInvocation("GetUser", nil, nil, []any{id}, []reflect.Value{reflect.ValueOf(id)})
+	setAttachment(invocation, map[string]string{constant.InterfaceKey: "com.example.UnixService"})
+	request.Data = invocation
+	request.TwoWay = true
+	rsp := remoting.NewPendingResponse(request.ID)
+	rsp.SetResponse(remoting.NewResponse(request.ID, "2.0.2"))
+	rsp.Reply = &User{}
+	remoting.AddPendingResponse(rsp)
+
+	require.NoError(t, client.Request(request, 3*time.Second, rsp))
+	res := rsp.GetCallResponse().(remoting.AsyncCallbackResponse).Reply.(*remoting.Response).Result.(*result.RPCResult)
+	require.NoError(t, res.Err)
+	return res.Rest.(*User)
+}
+
+func TestUnixSocket(t *testing.T) {
+	initUnixSocketTest(t)
+	socket := "unix://" + filepath.Join(t.TempDir(), "dubbo.sock")
+	server, provider := newUnixSocketServer(t, socket)
+	assert.Equal(t, socket, provider.GetParam(constant.UnixSocketKey, ""))
+	assert.Equal(t, "node-1", provider.GetParam(constant.HostIdKey, ""))
+
+	// the consumer on the same host dials the unix socket, even if the tcp address is unreachable
+	url := provider.Clone()
+	url.Port = "1"
+	url.Location = url.Ip + ":1"
+	client := getClient(url)
+	require.NotNil(t, client)
+	defer client.Close()
+	rpcClient, session, err := client.selectSession(client.addr)
+	require.NoError(t, err)
+	assert.IsType(t, &unixClient{}, rpcClient.gettyClient)
+	assert.IsType(t, &net.UnixConn{}, session.Conn())
+	assert.Equal(t, &User{ID: "1", Name: "unix"}, callGetUser(t, client, "1"))
+	assert.Eventually(t, func() bool {
+		return rpcClient.gettyClient.(*unixClient).sessionNum() == 2
+	}, 3*time.Second, 10*time.Millisecond)
+
+	// the closed session is reconnected
+	session.Close()
+	assert.Eventually(t, func() bool {
+		return rpcClient.gettyClient.(*unixClient).sessionNum() == 2
+	}, 3*time.Second, 10*time.Millisecond)
+	assert.Equal(t, &User{ID: "2", Name: "unix"}, callGetUser(t, client, "2"))
+
+	// the consumer on another host dials the tcp address
+	url = consumerURL(t, server, provider)
+	url.SetParam(constant.HostIdKey, "node-2")
+	tcpClient := getClient(url)
+	require.NotNil(t, tcpClient)
+	defer tcpClient.Close()
+	_, session, err = tcpClient.selectSession(tcpClient.addr)
+	require.NoError(t, err)
+	assert.IsType(t, &net.TCPConn{}, session.Conn())
+	assert.Equal(t, &User{ID: "3", Name: "unix"}, callGetUser(t, tcpClient, "3"))
+
+	server.Stop()
+	assert.NoFileExists(t, provider.GetParam(constant.UnixSocketKey, "")[len(constant.UnixSocketPrefix):])
+}
+
+func TestUnixSocketFallback(t *testing.T) {
+	initUnixSocketTest(t)
+	dir := t.TempDir()
+
+	// the params of the socket failing to be listened on are removed
+	server, provider := newUnixSocketServer(t, "unix://"+filepath.Join(dir, "absent", "dubbo.sock"))
+	assert.Empty(t, provider.GetParam(constant.UnixSocketKey, ""))
+	assert.Empty(t, provider.GetParam(constant.HostIdKey, ""))
+	another := provider.Clone()
+	another.SetParam(constant.UnixSocketKey, "unix://"+filepath.Join(dir, "dubbo.sock"))
+	server.SyncUnixSocketParams(another)
+	assert.Empty(t, another.GetParam(constant.UnixSocketKey, ""))
+
+	// the tcp address is dialed if nobody listens on the socket left by the provider
+	stale := filepath.Join(dir, "stale.sock")
+	require.NoError(t, os.WriteFile(stale, nil, 0o600))
+	url := consumerURL(t, server, provider)
+	url.SetParam(constant.UnixSocketKey, "unix://"+stale)
+	url.SetParam(constant.HostIdKey, "node-1")
+	client := getClient(url)
+	require.NotNil(t, client)
+	defer client.Close()
+	rpcClient, session, err := client.selectSession(client.addr)
+	require.NoError(t, err)
+	assert.NotContains(t, reflect.TypeOf(rpcClient.gettyClient).String(), "unixClient")
+	assert.IsType(t, &net.TCPConn{}, session.Conn())
+	assert.Equal(t, &User{ID: "1", Name: "unix"}, callGetUser(t, client, "1"))
+}
@@ -113,6 +113,16 @@ func GetNacosConfig(url *common.URL) ([]nacosConstant.ServerConfig, nacosConstan
 
 // NewNacosClientByURL created
 func NewNacosClientByURL(url *common.URL) (*nacosClient.NacosNamingClient, error) {
+	return newNacosClientByURL(url, true)
+}
+
+// NewUnsharedNacosClientByURL creates the nacos client owned by the caller instead of the one shared by the client
+// name, e.g. the client re-created with the rotated credentials
+func NewUnsharedNacosClientByURL(url *common.URL) (*nacosClient.NacosNamingClient, error) {
+	return newNacosClientByURL(url, false)
+}
+
+func newNacosClientByURL(url *common.URL, share bool) (*nacosClient.NacosNamingClient, error) {
 	scs, cc, err := GetNacosConfig(url)
 	if err != nil {
 		return nil, err
@@ -126,5 +136,5 @@ func NewNacosClientByURL(url *common.URL) (*nacosClient.NacosNamingClient, error
 	if len(namespaceID) > 0 {
 		clientName += namespaceID
 	}
-	return nacosClient.NewNacosNamingClient(clientName, true, scs, cc)
+	return nacosClient.NewNacosNamingClient(clientName, share, scs, cc)
 }
@@ -0,0 +1,414 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remoting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+)
+
+import (
+	"github.com/dubbogo/gost/log/logger"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+)
+
+// Streams are a dubbo-go extension of the dubbo protocol, they only work between dubbo-go consumers and
+// providers since dubbo java doesn't support them. Both sides carry constant.StreamVersionKey, so that a
+// stream fails with ErrStreamUnsupported instead of being served as an unary call by the peer.
+//
+// A stream is opened by a two-way request carrying the constant.StreamTypeKey attachment. After that,
+// the server sends frames as responses with the id of the opening request, and the client sends frames as
+// one-way requests carrying the id of the opening request in the constant.StreamIDKey attachment.
+// The type of frame is carried in the constant.StreamFrameKey attachment.
+const (
+	// StreamFrameData carries a message
+	StreamFrameData = "data"
+	// StreamFrameEnd means the peer will not send messages anymore, it may carry an error
+	StreamFrameEnd = "end"
+	// StreamFrameWindow grants the peer permission to send more messages
+	StreamFrameWindow = "window"
+	// StreamFrameCancel aborts the stream
+	StreamFrameCancel = "cancel"
+)
+
+var (
+	// ErrStreamCanceled is returned when the stream is canceled by the peer
+	ErrStreamCanceled = errors.New("stream canceled")
+	// ErrStreamWindowExceeded is returned when the peer sends more messages than the window, the stream is reset
+	ErrStreamWindowExceeded = errors.New("stream window exceeded")
+	// ErrStreamUnsupported is returned when the peer doesn't support dubbo-go streams, e.g. a dubbo java provider
+	ErrStreamUnsupported = errors.New("the peer doesn't support dubbo-go streams")
+)
+
+// StreamFrame is the unit transferred on a stream. Data and end frames are numbered by Seq since frames
+// may be handled out of order, the other frames take effect immediately and their Seq is -1.
+type StreamFrame struct {
+	Type        string
+	Seq         int64
+	Msg         any
+	Window      int
+	Err         error
+	Attachments map[string]any
+}
+
+// NewStreamFrame parses the frame from attachments of a request or response. A frame without
+// type is regarded as the end frame, which is the case of the final response of the server.
+func NewStreamFrame(attachments map[string]any, msg any, err error) *StreamFrame {
+	frame := &StreamFrame{Type: StreamFrameEnd, Seq: -1, Msg: msg, Err: err, Attachments: attachments}
+	if frameType, ok := attachments[constant.StreamFrameKey].(string); ok && frameType != "" {
+		frame.Type = frameType
+	}
+	if seq, ok := attachments[constant.StreamSeqKey].(string); ok {
+		if n, err := strconv.ParseInt(seq, 10, 64); err == nil {
+			frame.Seq = n
+		}
+	}
+	if frame.Type == StreamFrameWindow {
+		if window, ok := attachments[constant.StreamWindowKey].(string); ok {
+			frame.Window, _ = strconv.Atoi(window)
+		}
+	}
+	return frame
+}
+
+// NewStreamFrameFromResponse parses the frame sent by the server. A response without the stream version
+// comes from a server which doesn't support streams, it ends the stream with ErrStreamUnsupported.
+func NewStreamFrameFromResponse(response *Response) *StreamFrame {
+	res, ok := response.Result.(*result.RPCResult)
+	if !ok {
+		return &StreamFrame{Type: StreamFrameEnd, Seq: -1, Err: response.Error}
+	}
+	if version, _ := res.Attrs[constant.StreamVersionKey].(string); version != constant.StreamVersion {
+		err := ErrStreamUnsupported
+		if res.Err != nil {
+			err = fmt.Errorf("%w: %v", ErrStreamUnsupported, res.Err)
+		}
+		return &StreamFrame{Type: StreamFrameEnd, Seq: -1, Err: err, Attachments: res.Attrs}
+	}
+	msg := res.Rest
+	if ptr, ok := msg.(*any); ok {
+		msg = *ptr
+	}
+	return NewStreamFrame(res.Attrs, msg, res.Err)
+}
+
+// ToAttachments returns the attachments identifying the frame.
+func (f *StreamFrame) ToAttachments() map[string]any {
+	attachments := make(map[string]any, len(f.Attachments)+4)
+	for k, v := range f.Attachments {
+		attachments[k] = v
+	}
+	attachments[constant.StreamVersionKey] = constant.StreamVersion
+	attachments[constant.StreamFrameKey] = f.Type
+	if f.Seq >= 0 {
+		attachments[constant.StreamSeqKey] = strconv.FormatInt(f.Seq, 10)
+	}
+	if f.Type == StreamFrameWindow {
+		attachments[constant.StreamWindowKey] = strconv.Itoa(f.Window)
+	}
+	return attachments
+}
+
+// Stream is a flow controlled stream of messages in both directions. Each side may send at most
+// window messages before the peer grants more by window frames, and the receiver grants the consumed
+// amount once half of the window has been consumed.
+type Stream struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	send   func(frame *StreamFrame) error
+	window int
+	// the stream is terminated once the peer ends, which is true for the client side
+	endTerminates bool
+
+	mu        sync.Mutex
+	changed   chan struct{}
+	inbound   []any
+	consumed  int
+	credits   int
+	sendSeq   int64
+	sendDone  bool
+	recvSeq   int64
+	reordered map[int64]*StreamFrame
+	recvDone  bool
+	recvErr   error
+	trailer   map[string]any
+	closeOnce sync.Once
+}
+
+// NewStream creates a stream, frames are written to the peer by send.
+func NewStream(ctx context.Context, window int, send func(frame *StreamFrame) error) *Stream {
+	if window <= 0 {
+		window = constant.DefaultStreamWindow
+	}
+	s := &Stream{
+		send:      send,
+		window:    window,
+		credits:   window,
+		changed:   make(chan struct{}),
+		reordered: make(map[int64]*StreamFrame),
+	}
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	return s
+}
+
+// Context returns the context of the stream, which is done when the stream is finished or canceled.
+func (s *Stream) Context() context.Context {
+	return s.ctx
+}
+
+// Trailer returns the attachments of the end frame sent by the peer.
+func (s *Stream) Trailer() map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.trailer
+}
+
+// Send sends a message to the peer, it blocks until the peer grants enough window.
+func (s *Stream) Send(msg any) error {
+	s.mu.Lock()
+	for {
+		if s.sendDone {
+			s.mu.Unlock()
+			return errors.New("stream has been closed for sending")
+		}
+		if err := s.ctx.Err(); err != nil {
+			err = s.terminatedErrLocked(err)
+			s.mu.Unlock()
+			return err
+		}
+		if s.credits > 0 {
+			break
+		}
+		s.waitLocked()
+	}
+	s.credits--
+	frame := &StreamFrame{Type: StreamFrameData, Seq: s.sendSeq, Msg: msg}
+	s.sendSeq++
+	s.mu.Unlock()
+	return s.send(frame)
+}
+
+// CloseSend tells the peer that no more messages will be sent.
+func (s *Stream) CloseSend() error {
+	frame := s.EndFrame(nil)
+	if frame == nil {
+		return nil
+	}
+	return s.send(frame)
+}
+
+// EndFrame closes the stream for sending and returns the end frame carrying attachments,
+// it returns nil if the stream has been closed for sending.
+func (s *Stream) EndFrame(attachments map[string]any) *StreamFrame {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sendDone {
+		return nil
+	}
+	s.sendDone = true
+	return &StreamFrame{Type: StreamFrameEnd, Seq: s.sendSeq, Attachments: attachments}
+}
+
+// Recv receives a message from the peer. It returns io.EOF if the peer ended the stream normally,
+// or the error carried by the end frame.
+func (s *Stream) Recv() (any, error) {
+	s.mu.Lock()
+	for len(s.inbound) == 0 {
+		if s.recvDone {
+			err := s.recvErr
+			s.mu.Unlock()
+			if err == nil {
+				err = io.EOF
+			}
+			return nil, err
+		}
+		if err := s.ctx.Err(); err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+		s.waitLocked()
+	}
+	msg := s.inbound[0]
+	s.inbound[0] = nil
+	s.inbound = s.inbound[1:]
+	s.consumed++
+	var update int
+	if s.consumed >= (s.window+1)/2 && !s.recvDone {
+		update = s.consumed
+		s.consumed = 0
+	}
+	s.mu.Unlock()
+
+	if update > 0 {
+		if err := s.send(&StreamFrame{Type: StreamFrameWindow, Seq: -1, Window: update}); err != nil {
+			logger.Warnf("[Stream] failed to send window update: %v", err)
+		}
+	}
+	return msg, nil
+}
+
+// Cancel aborts the stream and notifies the peer.
+func (s *Stream) Cancel() {
+	if !s.remoteEnded() {
+		if err := s.send(&StreamFrame{Type: StreamFrameCancel, Seq: -1}); err != nil {
+			logger.Warnf("[Stream] failed to send cancel frame: %v", err)
+		}
+	}
+	s.OnFrame(&StreamFrame{Type: StreamFrameCancel, Seq: -1})
+}
+
+// Close releases the stream locally without notifying the peer.
+func (s *Stream) Close() {
+	s.closeOnce.Do(s.cancel)
+}
+
+// OnFrame handles the frame sent by the peer. The stream is reset if the peer sends beyond the window.
+func (s *Stream) OnFrame(frame *StreamFrame) {
+	if !s.onFrame(frame) {
+		return
+	}
+	logger.Warnf("[Stream] the peer sent the frame %d beyond the window %d, reset the stream", frame.Seq, s.window)
+	if err := s.send(&StreamFrame{Type: StreamFrameCancel, Seq: -1}); err != nil {
+		logger.Warnf("[Stream] failed to send cancel frame: %v", err)
+	}
+}
+
+// onFrame handles the frame and returns true if the stream is reset since the frame exceeds the window
+func (s *Stream) onFrame(frame *StreamFrame) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch frame.Type {
+	case StreamFrameData, StreamFrameEnd:
+		if frame.Seq < 0 {
+			// only the final response of the server is an end frame without seq
+			if frame.Type == StreamFrameData {
+				logger.Warnf("[Stream] drop the data frame without seq")
+				return false
+			}
+			s.applyLocked(frame)
+			break
+		}
+		if frame.Seq != s.recvSeq {
+			if frame.Seq <= s.recvSeq || s.recvDone {
+				return false
+			}
+			// the peer sends at most window data frames and the end frame ahead of the ones received,
+			// so the reordered frames are bounded by the window
+			if frame.Seq-s.recvSeq > int64(s.window) {
+				s.resetLocked()
+				return true
+			}
+			s.reordered[frame.Seq] = frame
+			return false
+		}
+		for frame != nil {
+			if !s.applyLocked(frame) {
+				s.resetLocked()
+				return true
+			}
+			s.recvSeq++
+			frame = s.reordered[s.recvSeq]
+			delete(s.reordered, s.recvSeq)
+		}
+	case StreamFrameWindow:
+		s.credits += frame.Window
+	case StreamFrameCancel:
+		if !s.recvDone {
+			s.recvDone = true
+			s.recvErr = ErrStreamCanceled
+		}
+		s.closeOnce.Do(s.cancel)
+	default:
+		logger.Warnf("[Stream] unknown frame type %s", frame.Type)
+		return false
+	}
+	s.broadcastLocked()
+	return false
+}
+
+// applyLocked applies the frame in order, it returns false if the data frame exceeds the window
+func (s *Stream) applyLocked(frame *StreamFrame) bool {
+	if s.recvDone {
+		return true
+	}
+	if frame.Type == StreamFrameData {
+		// the messages received but not granted back yet are bounded by the window
+		if len(s.inbound)+s.consumed >= s.window {
+			return false
+		}
+		s.inbound = append(s.inbound, frame.Msg)
+		return true
+	}
+	s.recvDone = true
+	s.recvErr = frame.Err
+	s.trailer = frame.Attachments
+	if s.endTerminates {
+		s.closeOnce.Do(s.cancel)
+	}
+	return true
+}
+
+// resetLocked terminates the stream with ErrStreamWindowExceeded and drops the messages received
+func (s *Stream) resetLocked() {
+	s.reordered = make(map[int64]*StreamFrame)
+	s.inbound = nil
+	s.recvDone = true
+	s.recvErr = ErrStreamWindowExceeded
+	s.closeOnce.Do(s.cancel)
+	s.broadcastLocked()
+}
+
+func (s *Stream) remoteEnded() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.recvDone
+}
+
+// waitLocked waits until the state of stream changes or the stream is done, it must be called with s.mu held.
+func (s *Stream) waitLocked() {
+	changed := s.changed
+	s.mu.Unlock()
+	defer s.mu.Lock()
+	select {
+	case <-changed:
+	case <-s.ctx.Done():
+	}
+}
+
+func (s *Stream) broadcastLocked() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *Stream) terminatedErrLocked(err error) error {
+	if !s.recvDone {
+		return err
+	}
+	if s.recvErr != nil {
+		return s.recvErr
+	}
+	return io.EOF
+}
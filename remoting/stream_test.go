@@ -0,0 +1,209 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remoting
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/common/constant"
+	"dubbo.apache.org/dubbo-go/v3/protocol/result"
+)
+
+func TestStreamFrameAttachments(t *testing.T) {
+	frame := &StreamFrame{Type: StreamFrameWindow, Seq: -1, Window: 8}
+	parsed := NewStreamFrame(frame.ToAttachments(), nil, nil)
+	assert.Equal(t, StreamFrameWindow, parsed.Type)
+	assert.Equal(t, 8, parsed.Window)
+	assert.EqualValues(t, -1, parsed.Seq)
+
+	frame = &StreamFrame{Type: StreamFrameData, Seq: 3}
+	parsed = NewStreamFrame(frame.ToAttachments(), "msg", nil)
+	assert.Equal(t, StreamFrameData, parsed.Type)
+	assert.EqualValues(t, 3, parsed.Seq)
+	assert.Equal(t, "msg", parsed.Msg)
+
+	// the final response of server is regarded as the end frame
+	parsed = NewStreamFrame(map[string]any{constant.InterfaceKey: "foo"}, nil, nil)
+	assert.Equal(t, StreamFrameEnd, parsed.Type)
+}
+
+func TestStreamFrameFromResponseUnsupported(t *testing.T) {
+	// a server which doesn't support streams, e.g. dubbo java, serves the opening request as an unary call
+	response := NewResponse(1, "2.0.2")
+	response.Result = &result.RPCResult{Rest: "unary", Attrs: map[string]any{constant.Dubbo: "2.0.2"}}
+	frame := NewStreamFrameFromResponse(response)
+	assert.Equal(t, StreamFrameEnd, frame.Type)
+	assert.ErrorIs(t, frame.Err, ErrStreamUnsupported)
+
+	response.Result = &result.RPCResult{Err: errors.New("no such method")}
+	frame = NewStreamFrameFromResponse(response)
+	assert.ErrorIs(t, frame.Err, ErrStreamUnsupported)
+	assert.ErrorContains(t, frame.Err, "no such method")
+
+	data := &StreamFrame{Type: StreamFrameData, Seq: 0}
+	response.Result = &result.RPCResult{Rest: "msg", Attrs: data.ToAttachments()}
+	frame = NewStreamFrameFromResponse(response)
+	assert.Equal(t, StreamFrameData, frame.Type)
+	assert.Equal(t, "msg", frame.Msg)
+}
+
+func TestStreamFlowControl(t *testing.T) {
+	var sent []*StreamFrame
+	stream := NewStream(context.Background(), 2, func(frame *StreamFrame) error {
+		sent = append(sent, frame)
+		return nil
+	})
+	assert.NoError(t, stream.Send("a"))
+	assert.NoError(t, stream.Send("b"))
+
+	// the window is used up
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	blocked := NewStream(ctx, 1, func(frame *StreamFrame) error { return nil })
+	assert.NoError(t, blocked.Send("a"))
+	assert.ErrorIs(t, blocked.Send("b"), context.DeadlineExceeded)
+
+	done := make(chan error)
+	go func() {
+		done <- stream.Send("c")
+	}()
+	stream.OnFrame(&StreamFrame{Type: StreamFrameWindow, Seq: -1, Window: 1})
+	assert.NoError(t, <-done)
+	assert.Len(t, sent, 3)
+	assert.EqualValues(t, 2, sent[2].Seq)
+}
+
+func TestStreamReorder(t *testing.T) {
+	var windows int
+	stream := NewStream(context.Background(), 4, func(frame *StreamFrame) error {
+		if frame.Type == StreamFrameWindow {
+			windows += frame.Window
+		}
+		return nil
+	})
+	stream.OnFrame(&StreamFrame{Type: StreamFrameEnd, Seq: 3})
+	stream.OnFrame(&StreamFrame{Type: StreamFrameData, Seq: 1, Msg: "b"})
+	stream.OnFrame(&StreamFrame{Type: StreamFrameData, Seq: 2, Msg: "c"})
+	stream.OnFrame(&StreamFrame{Type: StreamFrameData, Seq: 0, Msg: "a"})
+
+	for _, want := range []string{"a", "b", "c"} {
+		msg, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, want, msg)
+	}
+	_, err := stream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+	// the window is not granted after the peer ended
+	assert.Zero(t, windows)
+}
+
+func TestStreamCancel(t *testing.T) {
+	var sent []*StreamFrame
+	stream := NewStream(context.Background(), 4, func(frame *StreamFrame) error {
+		sent = append(sent, frame)
+		return nil
+	})
+	stream.Cancel()
+	assert.Len(t, sent, 1)
+	assert.Equal(t, StreamFrameCancel, sent[0].Type)
+
+	_, err := stream.Recv()
+	assert.ErrorIs(t, err, ErrStreamCanceled)
+	assert.ErrorIs(t, stream.Send("a"), ErrStreamCanceled)
+	assert.Error(t, stream.Context().Err())
+}
+
+func TestStreamWindowExceeded(t *testing.T) {
+	var sent []*StreamFrame
+	stream := NewStream(context.Background(), 2, func(frame *StreamFrame) error {
+		sent = append(sent, frame)
+		return nil
+	})
+	// the end frame right after the window is allowed
+	stream.OnFrame(&StreamFrame{Type: StreamFrameEnd, Seq: 2})
+	assert.Empty(t, sent)
+
+	// the frame beyond the window resets the stream
+	stream.OnFrame(&StreamFrame{Type: StreamFrameData, Seq: 3, Msg: "d"})
+	assert.Len(t, sent, 1)
+	assert.Equal(t, StreamFrameCancel, sent[0].Type)
+	assert.Empty(t, stream.reordered)
+	_, err := stream.Recv()
+	assert.ErrorIs(t, err, ErrStreamWindowExceeded)
+	assert.ErrorIs(t, stream.Send("a"), ErrStreamWindowExceeded)
+
+	// the frames after reset are dropped
+	stream.OnFrame(&StreamFrame{Type: StreamFrameData, Seq: 1, Msg: "b"})
+	assert.Empty(t, stream.reordered)
+}
+
+func TestStreamWindowExceededInOrder(t *testing.T) {
+	var sent []*StreamFrame
+	stream := NewStream(context.Background(), 2, func(frame *StreamFrame) error {
+		sent = append(sent, frame)
+		return nil
+	})
+	// the data frames without seq are dropped
+	stream.OnFrame(&StreamFrame{Type: StreamFrameData, Seq: -1, Msg: "x"})
+	assert.Empty(t, stream.inbound)
+
+	stream.OnFrame(&StreamFrame{Type: StreamFrameData, Seq: 0, Msg: "a"})
+	stream.OnFrame(&StreamFrame{Type: StreamFrameData, Seq: 1, Msg: "b"})
+	msg, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, "a", msg)
+	// the consumed message is granted back, so one more message is allowed
+	assert.Len(t, sent, 1)
+	assert.Equal(t, StreamFrameWindow, sent[0].Type)
+	stream.OnFrame(&StreamFrame{Type: StreamFrameData, Seq: 2, Msg: "c"})
+	assert.Len(t, sent, 1)
+
+	// the peer ignoring the window is reset
+	stream.OnFrame(&StreamFrame{Type: StreamFrameData, Seq: 3, Msg: "d"})
+	assert.Len(t, sent, 2)
+	assert.Equal(t, StreamFrameCancel, sent[1].Type)
+	assert.Empty(t, stream.inbound)
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, ErrStreamWindowExceeded)
+}
+
+func TestStreamWindowExceededWithoutGrant(t *testing.T) {
+	var sent []*StreamFrame
+	stream := NewStream(context.Background(), 4, func(frame *StreamFrame) error {
+		sent = append(sent, frame)
+		return nil
+	})
+	// window+1 in-order frames without any grant
+	for i := 0; i <= 4; i++ {
+		stream.OnFrame(&StreamFrame{Type: StreamFrameData, Seq: int64(i), Msg: i})
+	}
+	assert.Len(t, sent, 1)
+	assert.Equal(t, StreamFrameCancel, sent[0].Type)
+	_, err := stream.Recv()
+	assert.ErrorIs(t, err, ErrStreamWindowExceeded)
+}
@@ -40,6 +40,7 @@ import (
 	"dubbo.apache.org/dubbo-go/v3/common"
 	"dubbo.apache.org/dubbo-go/v3/common/constant"
 	"dubbo.apache.org/dubbo-go/v3/common/extension"
+	"dubbo.apache.org/dubbo-go/v3/common/threadpool"
 	"dubbo.apache.org/dubbo-go/v3/config"
 	"dubbo.apache.org/dubbo-go/v3/global"
 	"dubbo.apache.org/dubbo-go/v3/graceful_shutdown"
@@ -73,6 +74,9 @@ func (svcOpts *ServiceOptions) check() error {
 			panic(err)
 		}
 	}
+	if srv.ThreadPool != "" && !threadpool.Supported(srv.ThreadPool) {
+		return fmt.Errorf("[ServiceConfig] Unknown threadpool %s for service %s, please check your configuration", srv.ThreadPool, srv.Interface)
+	}
 
 	if srv.TpsLimitInterval != "" {
 		tpsLimitInterval, err := strconv.ParseInt(srv.TpsLimitInterval, 0, 0)
@@ -222,6 +226,8 @@ func (svcOpts *ServiceOptions) export(info *common.ServiceInfo) error {
 			common.WithParamsValue(constant.IDLMode, isIDL),
 		)
 
+		setProtocolThreadPool(ivkURL, protocolConf)
+		common.SetUnixSocketParams(ivkURL, protocolConf.UnixSocket)
 		if info != nil {
 			ivkURL.SetAttribute(constant.ServiceInfoKey, info)
 		}
@@ -301,6 +307,21 @@ func loadProtocol(protocolIds []string, protocols map[string]*global.ProtocolCon
 	return returnProtocols
 }
 
+// setProtocolThreadPool sets the thread pool configuration of the protocol which isn't configured by the service
+func setProtocolThreadPool(ivkURL *common.URL, protocolConf *global.ProtocolConfig) {
+	for key, value := range map[string]string{
+		constant.ThreadPoolKey:                protocolConf.ThreadPool,
+		constant.ThreadsKey:                   protocolConf.Threads,
+		constant.CoreThreadsKey:               protocolConf.CoreThreads,
+		constant.QueuesKey:                    protocolConf.Queues,
+		constant.ThreadPoolRejectedHandlerKey: protocolConf.ThreadPoolRejectedHandler,
+	} {
+		if len(value) > 0 && len(ivkURL.GetParam(key, "")) == 0 {
+			ivkURL.SetParam(key, value)
+		}
+	}
+}
+
 // Unexport will call unexport of all exporters service config exported
 func (svcOpts *ServiceOptions) Unexport() {
 	if !svcOpts.exported.Load() {
@@ -364,6 +385,7 @@ func (svcOpts *ServiceOptions) getUrlMap() url.Values {
 	urlMap.Set(constant.AppVersionKey, app.Version)
 	urlMap.Set(constant.OwnerKey, app.Owner)
 	urlMap.Set(constant.EnvironmentKey, app.Environment)
+	common.SetLocalityParams(urlMap)
 	//issue #2864  nacos client add weight
 	urlMap.Set(constant.WeightKey, strconv.FormatInt(svcOpts.Provider.Weight, 10))
 
@@ -399,6 +421,13 @@ func (svcOpts *ServiceOptions) getUrlMap() url.Values {
 	urlMap.Set(constant.ExecuteLimitKey, svcConf.ExecuteLimit)
 	urlMap.Set(constant.ExecuteRejectedExecutionHandlerKey, svcConf.ExecuteLimitRejectedHandler)
 
+	// executor filter
+	urlMap.Set(constant.ThreadPoolKey, svcConf.ThreadPool)
+	urlMap.Set(constant.ThreadsKey, svcConf.Threads)
+	urlMap.Set(constant.CoreThreadsKey, svcConf.CoreThreads)
+	urlMap.Set(constant.QueuesKey, svcConf.Queues)
+	urlMap.Set(constant.ThreadPoolRejectedHandlerKey, svcConf.ThreadPoolRejectedHandler)
+
 	// auth filter
 	urlMap.Set(constant.ServiceAuthKey, svcConf.Auth)
 	urlMap.Set(constant.ParameterSignatureEnableKey, svcConf.ParamSign)
@@ -419,6 +448,14 @@ func (svcOpts *ServiceOptions) getUrlMap() url.Values {
 
 		urlMap.Set(constant.ExecuteLimitKey, v.ExecuteLimit)
 		urlMap.Set(constant.ExecuteRejectedExecutionHandlerKey, v.ExecuteLimitRejectedHandler)
+
+		urlMap.Set(prefix+constant.ThreadPoolKey, v.ThreadPool)
+		urlMap.Set(prefix+constant.ThreadsKey, v.Threads)
+		urlMap.Set(prefix+constant.CoreThreadsKey, v.CoreThreads)
+		urlMap.Set(prefix+constant.QueuesKey, v.Queues)
+		urlMap.Set(prefix+constant.ThreadPoolRejectedHandlerKey, v.ThreadPoolRejectedHandler)
+
+		urlMap.Set(prefix+constant.RequiredScopesKey, v.Scopes)
 	}
 
 	return urlMap
@@ -147,6 +147,18 @@ func WithServerLoadBalanceP2C() ServerOption {
 	}
 }
 
+func WithServerLoadBalanceMaglev() ServerOption {
+	return func(opts *ServerOptions) {
+		opts.Provider.Loadbalance = constant.LoadBalanceKeyMaglev
+	}
+}
+
+func WithServerLoadBalanceLocality() ServerOption {
+	return func(opts *ServerOptions) {
+		opts.Provider.Loadbalance = constant.LoadBalanceKeyLocality
+	}
+}
+
 func WithServerLoadBalance(lb string) ServerOption {
 	return func(opts *ServerOptions) {
 		opts.Provider.Loadbalance = lb
@@ -681,6 +693,18 @@ func WithLoadBalanceP2C() ServiceOption {
 	}
 }
 
+func WithLoadBalanceMaglev() ServiceOption {
+	return func(opts *ServiceOptions) {
+		opts.Service.Loadbalance = constant.LoadBalanceKeyMaglev
+	}
+}
+
+func WithLoadBalanceLocality() ServiceOption {
+	return func(opts *ServiceOptions) {
+		opts.Service.Loadbalance = constant.LoadBalanceKeyLocality
+	}
+}
+
 func WithLoadBalance(lb string) ServiceOption {
 	return func(opts *ServiceOptions) {
 		opts.Service.Loadbalance = lb
@@ -848,6 +872,39 @@ func WithExecuteLimitRejectedHandler(exeRejHandler string) ServiceOption {
 	}
 }
 
+// WithThreadPool specifies the thread pool of the service, it's one of fixed, cached and eager.
+func WithThreadPool(threadPool string) ServiceOption {
+	return func(opts *ServiceOptions) {
+		opts.Service.ThreadPool = threadPool
+	}
+}
+
+func WithThreads(threads int) ServiceOption {
+	return func(opts *ServiceOptions) {
+		opts.Service.Threads = strconv.Itoa(threads)
+	}
+}
+
+// WithCoreThreads is kept for the configurations of dubbo java, it takes no effect since the thread pools
+// don't own threads.
+func WithCoreThreads(coreThreads int) ServiceOption {
+	return func(opts *ServiceOptions) {
+		opts.Service.CoreThreads = strconv.Itoa(coreThreads)
+	}
+}
+
+func WithQueues(queues int) ServiceOption {
+	return func(opts *ServiceOptions) {
+		opts.Service.Queues = strconv.Itoa(queues)
+	}
+}
+
+func WithThreadPoolRejectedHandler(handler string) ServiceOption {
+	return func(opts *ServiceOptions) {
+		opts.Service.ThreadPoolRejectedHandler = handler
+	}
+}
+
 func WithAuth(auth string) ServiceOption {
 	return func(opts *ServiceOptions) {
 		opts.Service.Auth = auth
@@ -860,6 +917,7 @@ func WithParamSign(paramSign string) ServiceOption {
 	}
 }
 
+// WithTag sets the tags the service serves, which is a comma separated list like gray,feature-x
 func WithTag(tag string) ServiceOption {
 	return func(opts *ServiceOptions) {
 		opts.Service.Tag = tag
@@ -18,7 +18,18 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
 )
 
 import (
@@ -26,8 +37,13 @@ import (
 )
 
 import (
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/generic"
 	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/client"
+	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/common"
 	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/json_register"
+	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/protocol"
+	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/triple"
+	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/metadata"
 )
 
 // callCmd represents the call command
@@ -51,6 +67,14 @@ var (
 	sendObjFilePath string
 	recvObjFilePath string
 	timeout         int
+	data            string
+	argTypes        string
+	serialization   string
+	protoset        string
+	protoFiles      []string
+	importPaths     []string
+	attachments     []string
+	registryAddr    string
 )
 
 func init() {
@@ -66,19 +90,242 @@ func init() {
 	callCmd.Flags().StringVarP(&sendObjFilePath, "sendObj", "", "", "json file path to define transfer struct")
 	callCmd.Flags().StringVarP(&recvObjFilePath, "recvObj", "", "", "json file path to define receive struct")
 	callCmd.Flags().IntVarP(&timeout, "timeout", "", 3000, "request timeout (ms)")
+	callCmd.Flags().StringVarP(&data, "data", "", "", "JSON payload, @file reads it from the file")
+	callCmd.Flags().StringVarP(&argTypes, "types", "", "", "comma separated java types of arguments for dubbo and hessian2 calls")
+	callCmd.Flags().StringVarP(&serialization, "serialization", "", triple.SerializationProtobuf,
+		"serialization of triple calls: protobuf, json or hessian2")
+	callCmd.Flags().StringVarP(&protoset, "protoset", "", "", "descriptor set file of the triple service")
+	callCmd.Flags().StringArrayVarP(&protoFiles, "protoFile", "", nil, ".proto file of the triple service, compiled by protoc")
+	callCmd.Flags().StringArrayVarP(&importPaths, "importPath", "", nil, "import path of .proto files")
+	callCmd.Flags().StringArrayVarP(&attachments, "atta", "", nil, "attachment in the form of key=value, sent as header by triple")
+	callCmd.Flags().StringVarP(&registryAddr, "registry", "", "",
+		"registry address to resolve the provider, e.g. zookeeper://127.0.0.1:2181")
 }
 
 func call(_ *cobra.Command, _ []string) {
-	checkParam()
-	reqPkg := json_register.RegisterStructFromFile(sendObjFilePath)
-	recvPkg := json_register.RegisterStructFromFile(recvObjFilePath)
+	if method == "" {
+		log.Fatalln("-method value not fond")
+	}
+	if registryAddr != "" {
+		resolveProvider()
+	}
+	switch {
+	case protocolName == "tri" || protocolName == "triple":
+		callTriple()
+	case data != "":
+		callGeneric()
+	default:
+		checkParam()
+		reqPkg := json_register.RegisterStructFromFile(sendObjFilePath)
+		recvPkg := json_register.RegisterStructFromFile(recvObjFilePath)
+
+		t, err := client.NewTelnetClient(host, port, protocolName, InterfaceID, version, group, method, reqPkg, timeout)
+		if err != nil {
+			panic(err)
+		}
+		t.ProcessRequests(recvPkg)
+		t.Destroy()
+	}
+}
+
+// callGeneric calls the dubbo service by generic invocation with the JSON payload
+func callGeneric() {
+	args, err := parseArgs()
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	types, err := common.ArgTypes(args, splitTypes())
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	c, err := client.NewGenericClient(host, port, protocolName, timeout)
+	if err != nil {
+		log.Fatalf("Error: connect to %s:%d: %v", host, port, err)
+	}
+	defer c.Destroy()
+
+	start := time.Now()
+	rsp, err := c.Invoke(&protocol.Request{
+		InterfaceID: InterfaceID,
+		Version:     version,
+		Group:       group,
+		Method:      method,
+		Attachments: parseAttachments(),
+	}, types, args)
+	elapsed := time.Since(start).Milliseconds()
+	if err != nil {
+		log.Fatalf("After %dms, Error: %v", elapsed, err)
+	}
+	log.Printf("After %dms, Got Rsp:", elapsed)
+	printJSON(rsp)
+}
 
-	t, err := client.NewTelnetClient(host, port, protocolName, InterfaceID, version, group, method, reqPkg, timeout)
+// callTriple calls the triple service, the response messages are printed as they arrive.
+func callTriple() {
+	codec, streaming, err := tripleCodec()
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	var payload []byte
+	if sendObjFilePath != "" && serialization == triple.SerializationHessian2 {
+		reqPkg := json_register.RegisterStructFromFile(sendObjFilePath)
+		payload, err = codec.(*triple.Hessian2Codec).MarshalArgs([]any{reqPkg})
+	} else {
+		if payload, err = common.ReadPayload(data); err == nil {
+			payload, err = codec.Marshal(payload)
+		}
+	}
 	if err != nil {
-		panic(err)
+		log.Fatalf("Error: %v", err)
+	}
+
+	// the responses of server streams are received until the server finishes the call or Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	start := time.Now()
+	stream, err := newTripleClient().Call(ctx, InterfaceID, method, codec.Name(), payload, streaming)
+	if err != nil {
+		log.Fatalf("After %dms, Error: %v", time.Since(start).Milliseconds(), err)
+	}
+	defer stream.Close()
+	for i := 0; ; i++ {
+		msg, err := stream.Recv()
+		elapsed := time.Since(start).Milliseconds()
+		if errors.Is(err, io.EOF) {
+			log.Printf("After %dms, Got %d messages", elapsed, i)
+			return
+		}
+		if err != nil && ctx.Err() != nil {
+			log.Printf("After %dms, Interrupted, Got %d messages", elapsed, i)
+			return
+		}
+		if err != nil {
+			log.Fatalf("After %dms, Error: %v", elapsed, err)
+		}
+		rsp, err := codec.Unmarshal(msg)
+		if err != nil {
+			log.Fatalf("Error: decode response: %v", err)
+		}
+		log.Printf("After %dms, Got Rsp #%d:", elapsed, i)
+		fmt.Println(rsp)
+	}
+}
+
+// tripleCodec returns the codec of the method and whether the method is server streaming, the method
+// descriptor is resolved from --protoset, --protoFile or the server reflection service in order.
+func tripleCodec() (triple.Codec, bool, error) {
+	if serialization == triple.SerializationHessian2 {
+		return triple.NewHessian2Codec(splitTypes()), false, nil
+	}
+	if serialization != triple.SerializationProtobuf && serialization != triple.SerializationJSON {
+		return nil, false, fmt.Errorf("unknown serialization %s", serialization)
+	}
+	var (
+		source generic.DescriptorSource
+		err    error
+	)
+	switch {
+	case protoset != "":
+		source, err = triple.LoadProtoset(protoset)
+	case len(protoFiles) > 0:
+		source, err = triple.CompileProtoFiles(importPaths, protoFiles)
+	default:
+		source, err = triple.NewReflectionSource(host, port)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Millisecond)
+	defer cancel()
+	md, err := triple.FindMethod(ctx, source, InterfaceID, method)
+	if err != nil {
+		if protoset == "" && len(protoFiles) == 0 && serialization == triple.SerializationJSON {
+			log.Printf("Warning: %v, the payload is sent as it is", err)
+			return triple.NewJSONCodec(), false, nil
+		}
+		return nil, false, err
+	}
+	return triple.NewProtoCodec(md, source, serialization == triple.SerializationJSON), md.IsStreamingServer(), nil
+}
+
+func newTripleClient() *triple.Client {
+	return triple.NewClient(host, port, group, version, parseAttachments(), time.Duration(timeout)*time.Millisecond)
+}
+
+func parseArgs() ([]any, error) {
+	payload, err := common.ReadPayload(data)
+	if err != nil {
+		return nil, err
+	}
+	return common.ParseJSONArgs(payload)
+}
+
+func splitTypes() []string {
+	if argTypes == "" {
+		return nil
+	}
+	return strings.Split(argTypes, ",")
+}
+
+func parseAttachments() map[string]string {
+	res := make(map[string]string, len(attachments))
+	for _, atta := range attachments {
+		k, v, ok := strings.Cut(atta, "=")
+		if !ok {
+			log.Fatalf("Error: invalid attachment %s, it should be in the form of key=value", atta)
+		}
+		res[k] = v
+	}
+	return res
+}
+
+func printJSON(v any) {
+	out, err := json.MarshalIndent(common.StringKeys(v), "", "  ")
+	if err != nil {
+		log.Printf("%+v", v)
+		return
+	}
+	fmt.Println(string(out))
+}
+
+// resolveProvider replaces the target host and port by a provider of the interface registered
+// in the registry, whose protocol matches the target protocol.
+func resolveProvider() {
+	registryURL, err := url.Parse(registryAddr)
+	if err != nil || registryURL.Scheme == "" {
+		log.Fatalf("Error: invalid registry address %s", registryAddr)
+	}
+	factory, ok := metadata.GetFactory(registryURL.Scheme)
+	if !ok {
+		log.Fatalf("Error: Registry type '%s' is not supported", registryURL.Scheme)
+	}
+	providers, err := factory("dubbogo-cli", []string{registryURL.Host}).GetProviders(InterfaceID)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	wantProtocol := protocolName
+	if wantProtocol == "triple" {
+		wantProtocol = "tri"
+	}
+	for _, provider := range providers {
+		providerURL, err := url.Parse(provider)
+		if err != nil || providerURL.Scheme != wantProtocol {
+			continue
+		}
+		query := providerURL.Query()
+		if (group != "" && query.Get("group") != group) || (version != "" && query.Get("version") != version) {
+			continue
+		}
+		providerPort, err := strconv.Atoi(providerURL.Port())
+		if err != nil {
+			continue
+		}
+		host, port = providerURL.Hostname(), providerPort
+		log.Printf("Resolved provider %s:%d from %s", host, port, registryAddr)
+		return
 	}
-	t.ProcessRequests(recvPkg)
-	t.Destroy()
+	log.Fatalf("Error: no %s provider of %s is found in %s", protocolName, InterfaceID, registryAddr)
 }
 
 func checkParam() {
@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+import (
+	"github.com/spf13/cobra"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/config"
+	schema "dubbo.apache.org/dubbo-go/v3/tools/dubbo-go-schema"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the dubbo-go config files",
+}
+
+// configLintCmd represents the config lint command
+var configLintCmd = &cobra.Command{
+	Use:   "lint [files]",
+	Short: "Validate the dubbo-go config files",
+	Long: `Loads the config files with the active profile merged, validates them against the JSON schema of
+dubbo-go and the validate tags of the config structs, and reports unknown keys, invalid values, missing required
+values and the registry/protocol ids referenced but not configured in file:line:column format. It exits with status 1 if any problem is found.`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  lintConfig,
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configLintCmd)
+	configLintCmd.Flags().String("profile", "", "active profile to merge, default is dubbo.profiles.active of the file")
+	configLintCmd.Flags().String("schema", "", "JSON schema of the config, default is the embedded dubbo-go.json")
+}
+
+func lintConfig(cmd *cobra.Command, files []string) {
+	profile, _ := cmd.Flags().GetString("profile")
+	schemaFile, _ := cmd.Flags().GetString("schema")
+
+	jsonSchema := schema.Schema
+	if schemaFile != "" {
+		var err error
+		if jsonSchema, err = os.ReadFile(schemaFile); err != nil {
+			log.Fatalf("Error: read %s: %v", schemaFile, err)
+		}
+	}
+	opts := []config.LintOption{config.WithLintProfile(profile), config.WithLintSchema(jsonSchema)}
+	problems := 0
+	for _, file := range files {
+		diagnostics, err := config.Lint(file, opts...)
+		if err != nil {
+			log.Fatalf("Error: lint %s: %v", file, err)
+		}
+		for _, d := range diagnostics {
+			fmt.Println(d)
+		}
+		problems += len(diagnostics)
+	}
+	if problems > 0 {
+		fmt.Printf("%d problems found\n", problems)
+		os.Exit(1)
+	}
+}
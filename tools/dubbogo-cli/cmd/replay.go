@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+import (
+	"github.com/spf13/cobra"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/filter/traffic_record"
+	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/client"
+	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/common"
+	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/protocol"
+	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/replay"
+)
+
+// replayCmd represents the replay command
+var replayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Replay the traffic captured by the traffic_record filter",
+	Long: `Reads the traffic captured by the traffic_record filter, re-issues every invocation to the target
+by generic invocations and reports the differences between the captured and replayed responses.`,
+	Run: replayTraffic,
+}
+
+func init() {
+	rootCmd.AddCommand(replayCmd)
+	replayCmd.Flags().String("file", "", "file captured by the traffic_record filter")
+	replayCmd.Flags().String("h", "localhost", "target server host")
+	replayCmd.Flags().Int("p", 20000, "target server port")
+	replayCmd.Flags().String("proto", "", "transfer protocol, the captured one of each invocation by default")
+	replayCmd.Flags().String("i", "", "only replay the invocations of the interface")
+	replayCmd.Flags().String("method", "", "only replay the invocations of the method")
+	replayCmd.Flags().Bool("atta", true, "send the captured attachments")
+	replayCmd.Flags().Int("timeout", 3000, "request timeout (ms)")
+}
+
+func replayTraffic(cmd *cobra.Command, _ []string) {
+	file, _ := cmd.Flags().GetString("file")
+	host, _ := cmd.Flags().GetString("h")
+	port, _ := cmd.Flags().GetInt("p")
+	proto, _ := cmd.Flags().GetString("proto")
+	interfaceID, _ := cmd.Flags().GetString("i")
+	method, _ := cmd.Flags().GetString("method")
+	withAttachments, _ := cmd.Flags().GetBool("atta")
+	timeout, _ := cmd.Flags().GetInt("timeout")
+
+	if file == "" {
+		log.Fatalln("--file value not found")
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		log.Fatalf("Error: open %s: %v", file, err)
+	}
+	records, err := traffic_record.ReadRecords(f)
+	_ = f.Close()
+	if err != nil {
+		log.Fatalf("Error: read %s: %v", file, err)
+	}
+
+	// the clients of the protocols, the invocations are replayed by the protocols they were captured with
+	clients := make(map[string]*client.GenericClient)
+
+	var replayed, mismatched, skipped int
+	for i, record := range records {
+		if (interfaceID != "" && record.Interface != interfaceID) || (method != "" && record.Method != method) {
+			continue
+		}
+		recordProto := proto
+		if recordProto == "" {
+			recordProto = record.Protocol
+		}
+		if recordProto == "" {
+			recordProto = "dubbo"
+		}
+		if !common.HasProtocol(recordProto) {
+			skipped++
+			log.Printf("#%d %s.%s SKIPPED, the %s protocol isn't supported by replay", i, record.Interface, record.Method, recordProto)
+			continue
+		}
+		c, ok := clients[recordProto]
+		if !ok {
+			if c, err = client.NewGenericClient(host, port, recordProto, timeout); err != nil {
+				log.Fatalf("Error: connect to %s:%d: %v", host, port, err)
+			}
+			clients[recordProto] = c
+		}
+		replayed++
+		req := &protocol.Request{
+			InterfaceID: record.Interface,
+			Version:     record.Version,
+			Group:       record.Group,
+			Method:      record.Method,
+		}
+		if withAttachments {
+			req.Attachments = record.Attachments
+		}
+
+		start := time.Now()
+		rsp, err := c.Invoke(req, record.ParameterTypes, replay.TypedArguments(record))
+		elapsed := time.Since(start).Milliseconds()
+
+		var diffs []string
+		switch {
+		case err != nil && record.Error == "":
+			diffs = []string{"error: " + err.Error()}
+		case err == nil && record.Error != "":
+			diffs = []string{"expected error: " + record.Error}
+		case err == nil:
+			if diffs, err = replay.Diff(record.Result, rsp); err != nil {
+				diffs = []string{"can not compare responses: " + err.Error()}
+			}
+		}
+		if len(diffs) == 0 {
+			log.Printf("#%d %s.%s OK, %dms (captured %dms)", i, record.Interface, record.Method, elapsed, record.Elapsed)
+			continue
+		}
+		mismatched++
+		log.Printf("#%d %s.%s MISMATCH, %dms (captured %dms)", i, record.Interface, record.Method, elapsed, record.Elapsed)
+		for _, d := range diffs {
+			log.Printf("    %s", d)
+		}
+	}
+
+	for _, c := range clients {
+		c.Destroy()
+	}
+
+	log.Printf("replayed %d invocations, %d mismatched, %d skipped", replayed, mismatched, skipped)
+	if mismatched > 0 || skipped > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package client
+
+import (
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+
+	perrors "github.com/pkg/errors"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/common"
+	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/protocol"
+)
+
+// GenericClient invokes methods of the target by generic invocations, so it doesn't require
+// the definition of request and response structs.
+type GenericClient struct {
+	conn             *net.TCPConn
+	proto            protocol.Protocol
+	responseTimeout  time.Duration
+	sequence         uint64
+	pendingResponses *sync.Map
+	buffer           []byte
+}
+
+// NewGenericClient creates a new tcp connection to the target
+func NewGenericClient(host string, port int, protocolName string, timeout int) (*GenericClient, error) {
+	tcpAddr := net.JoinHostPort(host, strconv.Itoa(port))
+	conn, err := net.DialTCP("tcp", nil, resolveTCPAddr(tcpAddr))
+	if err != nil {
+		return nil, err
+	}
+	return &GenericClient{
+		conn:             conn,
+		proto:            common.GetProtocol(protocolName),
+		responseTimeout:  time.Duration(timeout) * time.Millisecond,
+		pendingResponses: &sync.Map{},
+	}, nil
+}
+
+// Invoke calls @method with @args whose java types are @types, and returns the generalized response.
+func (c *GenericClient) Invoke(req *protocol.Request, types []string, args []any) (any, error) {
+	params := make([]hessian.Object, 0, len(args))
+	for _, arg := range args {
+		params = append(params, arg)
+	}
+	attachments := make(map[string]string, len(req.Attachments)+1)
+	for k, v := range req.Attachments {
+		attachments[k] = v
+	}
+	attachments["generic"] = "true"
+
+	c.sequence++
+	genericReq := &protocol.Request{
+		ID:          c.sequence,
+		InterfaceID: req.InterfaceID,
+		Version:     req.Version,
+		Group:       req.Group,
+		Method:      "$invoke",
+		Params:      []any{req.Method, types, params},
+		Attachments: attachments,
+	}
+	data, err := c.proto.Write(genericReq)
+	if err != nil {
+		return nil, err
+	}
+
+	reply := new(any)
+	c.pendingResponses.Store(genericReq.ID, reply)
+	defer c.pendingResponses.Delete(genericReq.ID)
+
+	if err = c.conn.SetDeadline(time.Now().Add(c.responseTimeout)); err != nil {
+		return nil, perrors.WithStack(err)
+	}
+	if _, err = c.conn.Write(data); err != nil {
+		return nil, perrors.WithStack(err)
+	}
+	return c.readResponse()
+}
+
+// readResponse reads from the connection until a whole package is received
+func (c *GenericClient) readResponse() (any, error) {
+	buf := make([]byte, defaultBufferSize)
+	for {
+		if len(c.buffer) > 0 {
+			rsp, length, err := c.proto.Read(c.buffer, c.pendingResponses)
+			if length > 0 {
+				c.buffer = c.buffer[length:]
+			}
+			if err != nil || length > 0 {
+				if reply, ok := rsp.(*any); ok {
+					rsp = *reply
+				}
+				return rsp, err
+			}
+		}
+		n, err := c.conn.Read(buf)
+		if err != nil {
+			return nil, perrors.WithStack(err)
+		}
+		c.buffer = append(c.buffer, buf[:n]...)
+	}
+}
+
+// Destroy closes the tcp conn
+func (c *GenericClient) Destroy() {
+	c.conn.Close()
+}
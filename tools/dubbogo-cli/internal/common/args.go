@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ReadPayload returns the payload given by the command line, "@path" reads the payload from the file.
+func ReadPayload(data string) ([]byte, error) {
+	if strings.HasPrefix(data, "@") {
+		return os.ReadFile(data[1:])
+	}
+	return []byte(data), nil
+}
+
+// ParseJSONArgs parses the arguments of a method from JSON. A JSON array is regarded as
+// the list of arguments, any other value as the only argument.
+func ParseJSONArgs(payload []byte) ([]any, error) {
+	payload = bytes.TrimSpace(payload)
+	if len(payload) == 0 {
+		return []any{}, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid JSON payload: %w", err)
+	}
+	v = convertNumbers(v)
+	if args, ok := v.([]any); ok && payload[0] == '[' {
+		return args, nil
+	}
+	return []any{v}, nil
+}
+
+// convertNumbers converts json.Number into int64 if it is integral, or float64 otherwise
+func convertNumbers(v any) any {
+	switch val := v.(type) {
+	case json.Number:
+		if n, err := val.Int64(); err == nil {
+			return n
+		}
+		f, _ := val.Float64()
+		return f
+	case map[string]any:
+		for k, item := range val {
+			val[k] = convertNumbers(item)
+		}
+	case []any:
+		for i, item := range val {
+			val[i] = convertNumbers(item)
+		}
+	}
+	return v
+}
+
+// ArgTypes returns the java types of args, types given explicitly take precedence.
+func ArgTypes(args []any, types []string) ([]string, error) {
+	if len(types) > 0 {
+		if len(types) != len(args) {
+			return nil, fmt.Errorf("%d types are given for %d arguments", len(types), len(args))
+		}
+		return types, nil
+	}
+	types = make([]string, len(args))
+	for i, arg := range args {
+		types[i] = JavaType(arg)
+	}
+	return types, nil
+}
+
+// JavaType returns the java type of values decoded from JSON
+func JavaType(v any) string {
+	switch v.(type) {
+	case string:
+		return "java.lang.String"
+	case int64:
+		return "long"
+	case float64:
+		return "double"
+	case bool:
+		return "boolean"
+	case map[string]any:
+		return "java.util.Map"
+	case []any:
+		return "java.util.List"
+	default:
+		return "java.lang.Object"
+	}
+}
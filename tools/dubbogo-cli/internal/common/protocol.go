@@ -28,6 +28,11 @@ func SetProtocol(name string, v func() protocol.Protocol) {
 	protocols[name] = v
 }
 
+// HasProtocol checks whether the protocol extension with @name is set
+func HasProtocol(name string) bool {
+	return protocols[name] != nil
+}
+
 // GetProtocol finds the protocol extension with @name
 func GetProtocol(name string) protocol.Protocol {
 	if protocols[name] == nil {
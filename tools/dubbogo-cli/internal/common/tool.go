@@ -37,3 +37,33 @@ func PrintInterface(v any) {
 	}
 	fmt.Println("")
 }
+
+// StringKeys converts maps decoded by hessian, whose keys are any, into map[string]any
+func StringKeys(v any) any {
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Map:
+		m := make(map[string]any, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			m[fmt.Sprint(iter.Key().Interface())] = StringKeys(iter.Value().Interface())
+		}
+		return m
+	case reflect.Slice, reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return v
+		}
+		s := make([]any, value.Len())
+		for i := range s {
+			s[i] = StringKeys(value.Index(i).Interface())
+		}
+		return s
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return StringKeys(value.Elem().Interface())
+	default:
+		return v
+	}
+}
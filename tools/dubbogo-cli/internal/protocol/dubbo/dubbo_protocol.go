@@ -69,6 +69,9 @@ func (p RpcClientPackageHandler) Read(data []byte, pendingRsp *sync.Map) (any, i
 	if !ok {
 		log.Println("error: dubbboRsp.Body assertion err:")
 	}
+	if pkg.Err != nil {
+		return nil, hessian.HEADER_LENGTH + pkg.Header.BodyLen, pkg.Err
+	}
 
 	return dubboRsp.Reply, hessian.HEADER_LENGTH + pkg.Header.BodyLen, nil
 }
@@ -104,6 +107,9 @@ func parseReq2DubboPkg(req *protocol.Request) (*DubboPackage, bool) {
 	atta := make(map[string]string)
 	atta["async"] = "false"
 	atta["interface"] = req.InterfaceID
+	for k, v := range req.Attachments {
+		atta[k] = v
+	}
 	p.Body = hessian.NewRequest(req.Params, atta)
 	p.Header.Type = hessian.PackageRequest_TwoWay
 	p.Header.ID = int64(req.ID)
@@ -33,4 +33,5 @@ type Request struct {
 	Group       string
 	Method      string
 	Params      any
+	Attachments map[string]string
 }
@@ -0,0 +1,133 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package replay prepares the traffic captured by the traffic_record filter of dubbo-go for replaying,
+// and compares the replayed responses with the captured ones.
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/filter"
+	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/common"
+)
+
+// TypedArguments converts the numbers of the record decoded from JSON into the types expected by the provider
+func TypedArguments(r *filter.TrafficRecord) []any {
+	args := make([]any, len(r.Arguments))
+	for i, arg := range r.Arguments {
+		args[i] = arg
+		if i >= len(r.ParameterTypes) {
+			continue
+		}
+		number, ok := arg.(float64)
+		if !ok {
+			continue
+		}
+		switch r.ParameterTypes[i] {
+		case "int", "java.lang.Integer":
+			args[i] = int32(number)
+		case "long", "java.lang.Long":
+			args[i] = int64(number)
+		case "short", "java.lang.Short":
+			args[i] = int16(number)
+		case "byte", "java.lang.Byte":
+			args[i] = int8(number)
+		case "float", "java.lang.Float":
+			args[i] = float32(number)
+		}
+	}
+	return args
+}
+
+// Diff compares the captured response with the replayed one, it returns the differences
+// in the form of "path: captured != replayed". Both are normalized into JSON values first.
+func Diff(want, got any) ([]string, error) {
+	w, err := normalize(want)
+	if err != nil {
+		return nil, err
+	}
+	g, err := normalize(got)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []string
+	diff("$", w, g, &diffs)
+	return diffs, nil
+}
+
+// normalize converts the value into the form decoded from JSON
+func normalize(v any) (any, error) {
+	data, err := json.Marshal(common.StringKeys(v))
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	err = json.Unmarshal(data, &normalized)
+	return normalized, err
+}
+
+func diff(path string, want, got any, diffs *[]string) {
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := make(map[string]struct{}, len(w)+len(g))
+		for k := range w {
+			keys[k] = struct{}{}
+		}
+		for k := range g {
+			keys[k] = struct{}{}
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			diff(path+"."+k, w[k], g[k], diffs)
+		}
+		return
+	case []any:
+		g, ok := got.([]any)
+		if !ok || len(g) != len(w) {
+			break
+		}
+		for i := range w {
+			diff(fmt.Sprintf("%s[%d]", path, i), w[i], g[i], diffs)
+		}
+		return
+	}
+	if !reflect.DeepEqual(want, got) {
+		*diffs = append(*diffs, fmt.Sprintf("%s: %v != %v", path, format(want), format(got)))
+	}
+}
+
+func format(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package replay
+
+import (
+	"testing"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/filter"
+)
+
+func TestTypedArguments(t *testing.T) {
+	record := &filter.TrafficRecord{
+		ParameterTypes: []string{"java.lang.String", "int", "long", "double"},
+		Arguments:      []any{"A001", float64(1), float64(2), float64(3), "extra"},
+	}
+	assert.Equal(t, []any{"A001", int32(1), int64(2), float64(3), "extra"}, TypedArguments(record))
+}
+
+func TestDiff(t *testing.T) {
+	captured := map[string]any{"name": "Alex", "age": float64(18), "tags": []any{"a", "b"}}
+	replayed := map[any]any{"name": "Alex", "age": int32(18), "tags": []any{"a", "b"}}
+	diffs, err := Diff(captured, replayed)
+	assert.NoError(t, err)
+	assert.Empty(t, diffs)
+
+	replayed = map[any]any{"name": "Bob", "tags": []any{"a", "c"}, "extra": true}
+	diffs, err = Diff(captured, replayed)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"$.age: 18 != null",
+		"$.extra: null != true",
+		`$.name: "Alex" != "Bob"`,
+		`$.tags[1]: "b" != "c"`,
+	}, diffs)
+}
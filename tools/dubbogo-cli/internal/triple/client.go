@@ -0,0 +1,194 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package triple calls triple services with the gRPC wire format, which is served by every
+// triple server, without depending on generated stubs.
+package triple
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+import (
+	"golang.org/x/net/http2"
+)
+
+const (
+	headerGroup   = "tri-service-group"
+	headerVersion = "tri-service-version"
+
+	grpcContentTypePrefix = "application/grpc+"
+	envelopeHeaderLength  = 5
+	flagCompressed        = 0b00000001
+	// maxMessageSize is the max size of a response message
+	maxMessageSize = 64 * 1024 * 1024
+)
+
+// Client sends requests to a triple server over h2c
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	timeout    time.Duration
+	header     http.Header
+}
+
+// NewClient creates a client of the server listening on host:port. Group, version and attachments
+// are sent as headers of every request.
+func NewClient(host string, port int, group, version string, attachments map[string]string, timeout time.Duration) *Client {
+	header := make(http.Header, len(attachments)+2)
+	for k, v := range attachments {
+		header.Set(k, v)
+	}
+	if group != "" {
+		header.Set(headerGroup, group)
+	}
+	if version != "" {
+		header.Set(headerVersion, version)
+	}
+	return &Client{
+		baseURL: "http://" + net.JoinHostPort(host, strconv.Itoa(port)),
+		httpClient: &http.Client{
+			Transport: &http2.Transport{
+				AllowHTTP: true,
+				DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, network, addr)
+				},
+			},
+		},
+		timeout: timeout,
+		header:  header,
+	}
+}
+
+// Stream is the response of a call, it yields one message for unary methods
+// and any number of messages for server streaming methods.
+type Stream struct {
+	resp   *http.Response
+	cancel context.CancelFunc
+}
+
+// Call sends a single request message to service/method with the codec, and returns the response stream.
+// The timeout bounds the whole call of unary methods, while the responses of server streaming methods
+// are received until the server finishes the call or ctx is done, the timeout only bounds the headers.
+func (c *Client) Call(ctx context.Context, service, method, codec string, payload []byte, streaming bool) (*Stream, error) {
+	var cancel context.CancelFunc
+	if streaming {
+		ctx, cancel = context.WithCancel(ctx)
+		// the stream is canceled if the headers don't arrive in time, the timer is stopped once they arrive
+		timer := time.AfterFunc(c.timeout, cancel)
+		defer timer.Stop()
+	} else {
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+	}
+	body := make([]byte, envelopeHeaderLength, envelopeHeaderLength+len(payload))
+	binary.BigEndian.PutUint32(body[1:], uint32(len(payload)))
+	body = append(body, payload...)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/"+service+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	for k, v := range c.header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", grpcContentTypePrefix+codec)
+	req.Header.Set("Te", "trailers")
+	if !streaming {
+		req.Header.Set("Grpc-Timeout", strconv.FormatInt(c.timeout.Milliseconds(), 10)+"m")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("unexpected http status %s", resp.Status)
+	}
+	// a trailers-only response carries the status in headers
+	if err = statusError(resp.Header); err != nil {
+		resp.Body.Close()
+		cancel()
+		return nil, err
+	}
+	return &Stream{resp: resp, cancel: cancel}, nil
+}
+
+// Recv returns the next message of the response. It returns io.EOF once the server finishes
+// the call normally, or the error carried by the grpc-status trailer.
+func (s *Stream) Recv() ([]byte, error) {
+	var prefix [envelopeHeaderLength]byte
+	if _, err := io.ReadFull(s.resp.Body, prefix[:]); err != nil {
+		if err != io.EOF {
+			return nil, err
+		}
+		if err = statusError(s.resp.Trailer); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+	if prefix[0]&flagCompressed != 0 {
+		return nil, fmt.Errorf("compressed response is not supported")
+	}
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if size > maxMessageSize {
+		return nil, fmt.Errorf("response message size %d exceeds the limit %d", size, maxMessageSize)
+	}
+	msg := make([]byte, size)
+	if _, err := io.ReadFull(s.resp.Body, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Header returns the response headers sent by the server
+func (s *Stream) Header() http.Header {
+	return s.resp.Header
+}
+
+// Close releases the stream
+func (s *Stream) Close() {
+	s.resp.Body.Close()
+	s.cancel()
+}
+
+// statusError converts the grpc-status carried by header into an error
+func statusError(header http.Header) error {
+	code := header.Get("Grpc-Status")
+	if code == "" || code == "0" {
+		return nil
+	}
+	msg, err := url.PathUnescape(header.Get("Grpc-Message"))
+	if err != nil {
+		msg = header.Get("Grpc-Message")
+	}
+	return fmt.Errorf("grpc status %s: %s", code, msg)
+}
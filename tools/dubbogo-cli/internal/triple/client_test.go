@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package triple
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// startSlowServer starts a server sending a message every interval, three messages in total
+func startSlowServer(t *testing.T, interval time.Duration) (string, int) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+		w.Header().Set("Trailer", "Grpc-Status")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		for i := 0; i < 3; i++ {
+			select {
+			case <-time.After(interval):
+			case <-r.Context().Done():
+				return
+			}
+			msg := make([]byte, envelopeHeaderLength+1)
+			binary.BigEndian.PutUint32(msg[1:], 1)
+			msg[envelopeHeaderLength] = byte(i)
+			_, _ = w.Write(msg)
+			w.(http.Flusher).Flush()
+		}
+		w.Header().Set("Grpc-Status", "0")
+	})
+	server := httptest.NewServer(h2c.NewHandler(handler, &http2.Server{}))
+	t.Cleanup(server.Close)
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	p, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return host, p
+}
+
+func TestCallTimeout(t *testing.T) {
+	host, port := startSlowServer(t, 50*time.Millisecond)
+	c := NewClient(host, port, "", "", nil, 80*time.Millisecond)
+
+	// the stream outlives the timeout
+	stream, err := c.Call(context.Background(), "greet.GreetService", "GreetStream", "proto", nil, true)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		msg, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, []byte{byte(i)}, msg)
+	}
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+	stream.Close()
+
+	// the unary call is bounded by the timeout
+	stream, err = c.Call(context.Background(), "greet.GreetService", "Greet", "proto", nil, false)
+	require.NoError(t, err)
+	defer stream.Close()
+	_, err = stream.Recv()
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
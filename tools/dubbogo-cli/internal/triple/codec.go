@@ -0,0 +1,238 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package triple
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/internal/common"
+)
+
+const (
+	SerializationProtobuf = "protobuf"
+	SerializationJSON     = "json"
+	SerializationHessian2 = "hessian2"
+
+	// field numbers of TripleRequestWrapper
+	wrapperSerializeType = 1
+	wrapperArgs          = 2
+	wrapperArgTypes      = 3
+)
+
+// Codec encodes JSON payloads into requests and decodes responses into JSON text
+type Codec interface {
+	// Name is the codec name carried by content-type
+	Name() string
+	Marshal(payload []byte) ([]byte, error)
+	Unmarshal(data []byte) (string, error)
+}
+
+// TypeResolver resolves the types of messages and extensions, e.g. google.protobuf.Any
+type TypeResolver interface {
+	protoregistry.MessageTypeResolver
+	protoregistry.ExtensionTypeResolver
+}
+
+// NewProtoCodec creates a codec of the IDL method described by md, the types are resolved by types.
+// If asJSON is true, messages are transferred in protobuf JSON, otherwise in protobuf binary.
+func NewProtoCodec(md protoreflect.MethodDescriptor, types TypeResolver, asJSON bool) Codec {
+	return &protoCodec{md: md, types: types, asJSON: asJSON}
+}
+
+type protoCodec struct {
+	md     protoreflect.MethodDescriptor
+	types  TypeResolver
+	asJSON bool
+}
+
+func (c *protoCodec) Name() string {
+	if c.asJSON {
+		return "json"
+	}
+	return "proto"
+}
+
+func (c *protoCodec) Marshal(payload []byte) ([]byte, error) {
+	msg := dynamicpb.NewMessage(c.md.Input())
+	if len(bytes.TrimSpace(payload)) > 0 {
+		if err := (protojson.UnmarshalOptions{Resolver: c.types}).Unmarshal(payload, msg); err != nil {
+			return nil, fmt.Errorf("invalid payload of %s: %w", c.md.Input().FullName(), err)
+		}
+	}
+	if c.asJSON {
+		return protojson.MarshalOptions{Resolver: c.types}.Marshal(msg)
+	}
+	return proto.Marshal(msg)
+}
+
+func (c *protoCodec) Unmarshal(data []byte) (string, error) {
+	msg := dynamicpb.NewMessage(c.md.Output())
+	var err error
+	if c.asJSON {
+		err = protojson.UnmarshalOptions{Resolver: c.types, DiscardUnknown: true}.Unmarshal(data, msg)
+	} else {
+		err = proto.UnmarshalOptions{Resolver: c.types}.Unmarshal(data, msg)
+	}
+	if err != nil {
+		return "", err
+	}
+	out, err := protojson.MarshalOptions{Resolver: c.types, Multiline: true, Indent: "  "}.Marshal(msg)
+	return string(out), err
+}
+
+// NewJSONCodec creates a codec sending the payload as it is, for methods without descriptors
+func NewJSONCodec() Codec {
+	return jsonCodec{}
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
+
+func (jsonCodec) Marshal(payload []byte) ([]byte, error) {
+	if !json.Valid(payload) {
+		return nil, errors.New("invalid JSON payload")
+	}
+	return payload, nil
+}
+
+func (jsonCodec) Unmarshal(data []byte) (string, error) {
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		return string(data), nil
+	}
+	return out.String(), nil
+}
+
+// NewHessian2Codec creates a codec of non-IDL methods, the arguments are serialized by hessian2 and
+// wrapped by TripleRequestWrapper. The java types of arguments are guessed from JSON if types is empty.
+func NewHessian2Codec(types []string) *Hessian2Codec {
+	return &Hessian2Codec{types: types}
+}
+
+// Hessian2Codec is the codec of non-IDL methods
+type Hessian2Codec struct {
+	types []string
+}
+
+func (c *Hessian2Codec) Name() string {
+	return SerializationHessian2
+}
+
+func (c *Hessian2Codec) Marshal(payload []byte) ([]byte, error) {
+	args, err := common.ParseJSONArgs(payload)
+	if err != nil {
+		return nil, err
+	}
+	return c.MarshalArgs(args)
+}
+
+// MarshalArgs wraps args, which may be POJOs registered to hessian.
+func (c *Hessian2Codec) MarshalArgs(args []any) ([]byte, error) {
+	types, err := common.ArgTypes(args, c.types)
+	if err != nil {
+		return nil, err
+	}
+	data := protowire.AppendTag(nil, wrapperSerializeType, protowire.BytesType)
+	data = protowire.AppendString(data, SerializationHessian2)
+	for _, arg := range args {
+		encoder := hessian.NewEncoder()
+		if err = encoder.Encode(arg); err != nil {
+			return nil, err
+		}
+		data = protowire.AppendTag(data, wrapperArgs, protowire.BytesType)
+		data = protowire.AppendBytes(data, encoder.Buffer())
+	}
+	for _, typ := range types {
+		data = protowire.AppendTag(data, wrapperArgTypes, protowire.BytesType)
+		data = protowire.AppendString(data, typ)
+	}
+	return data, nil
+}
+
+func (c *Hessian2Codec) Unmarshal(data []byte) (string, error) {
+	var results []any
+	err := rangeBytesFields(data, func(num protowire.Number, value []byte) error {
+		if num != wrapperArgs {
+			return nil
+		}
+		res, err := hessian.NewDecoder(value).Decode()
+		if err != nil {
+			return err
+		}
+		results = append(results, res)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	var res any
+	if len(results) == 1 {
+		res = results[0]
+	} else if len(results) > 1 {
+		res = results
+	}
+	out, err := json.MarshalIndent(common.StringKeys(res), "", "  ")
+	return string(out), err
+}
+
+// rangeBytesFields calls f with the length-delimited fields of a message and skips the others
+func rangeBytesFields(data []byte, f func(num protowire.Number, value []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, data)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			data = data[n:]
+			continue
+		}
+		value, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		if err := f(num, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
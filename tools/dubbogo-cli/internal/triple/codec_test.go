@@ -0,0 +1,132 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package triple
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+import (
+	hessian "github.com/apache/dubbo-go-hessian2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func greetFile() *descriptorpb.FileDescriptorProto {
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("greet.proto"),
+		Package: proto.String("greet"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("GreetRequest"),
+			Field: []*descriptorpb.FieldDescriptorProto{{
+				Name:     proto.String("name"),
+				JsonName: proto.String("name"),
+				Number:   proto.Int32(1),
+				Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			}},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("GreetService"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Greet"),
+				InputType:  proto.String(".greet.GreetRequest"),
+				OutputType: proto.String(".greet.GreetRequest"),
+			}, {
+				Name:            proto.String("Upload"),
+				InputType:       proto.String(".greet.GreetRequest"),
+				OutputType:      proto.String(".greet.GreetRequest"),
+				ClientStreaming: proto.Bool(true),
+			}},
+		}},
+	}
+}
+
+func TestProtoCodec(t *testing.T) {
+	data, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{greetFile()}})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "greet.protoset")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	source, err := LoadProtoset(path)
+	require.NoError(t, err)
+	_, err = FindMethod(context.Background(), source, "greet.GreetService", "Upload")
+	assert.Error(t, err)
+	_, err = FindMethod(context.Background(), source, "greet.GreetService", "Unknown")
+	assert.Error(t, err)
+	md, err := FindMethod(context.Background(), source, "greet.GreetService", "Greet")
+	require.NoError(t, err)
+
+	codec := NewProtoCodec(md, source, false)
+	assert.Equal(t, "proto", codec.Name())
+	req, err := codec.Marshal([]byte(`{"name": "dubbo"}`))
+	require.NoError(t, err)
+	rsp, err := codec.Unmarshal(req)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "dubbo"}`, rsp)
+	_, err = codec.Marshal([]byte(`{"unknown": 1}`))
+	assert.Error(t, err)
+
+	codec = NewProtoCodec(md, source, true)
+	req, err = codec.Marshal([]byte(`{"name": "dubbo"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name": "dubbo"}`, string(req))
+}
+
+func TestHessian2Codec(t *testing.T) {
+	codec := NewHessian2Codec(nil)
+	req, err := codec.Marshal([]byte(`["dubbo", 1, {"k": 1.5}]`))
+	require.NoError(t, err)
+
+	var (
+		args  []any
+		types []string
+	)
+	err = rangeBytesFields(req, func(num protowire.Number, value []byte) error {
+		switch num {
+		case wrapperArgs:
+			arg, err := hessian.NewDecoder(value).Decode()
+			args = append(args, arg)
+			return err
+		case wrapperArgTypes:
+			types = append(types, string(value))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"java.lang.String", "long", "java.util.Map"}, types)
+	assert.Equal(t, "dubbo", args[0])
+	assert.EqualValues(t, 1, args[1])
+
+	// responses are wrapped in the same way
+	rsp, err := codec.Unmarshal(req)
+	require.NoError(t, err)
+	assert.JSONEq(t, `["dubbo", 1, {"k": 1.5}]`, rsp)
+
+	_, err = NewHessian2Codec([]string{"int"}).Marshal([]byte(`[1, 2]`))
+	assert.Error(t, err)
+}
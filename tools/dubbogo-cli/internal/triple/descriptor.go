@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package triple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+)
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+import (
+	"dubbo.apache.org/dubbo-go/v3/protocol/triple/generic"
+)
+
+// LoadProtoset loads the descriptor set generated by protoc --include_imports --descriptor_set_out
+func LoadProtoset(path string) (generic.DescriptorSource, error) {
+	return generic.NewFileDescriptorSetSourceFromFile(path)
+}
+
+// CompileProtoFiles compiles the .proto files by protoc, which must be found in PATH.
+func CompileProtoFiles(importPaths, protoFiles []string) (generic.DescriptorSource, error) {
+	protoc, err := exec.LookPath("protoc")
+	if err != nil {
+		return nil, errors.New("protoc is required to compile .proto files, or use --protoset instead")
+	}
+	dir, err := os.MkdirTemp("", "dubbogo-cli")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	out := filepath.Join(dir, "descriptor.pb")
+	args := []string{"--include_imports", "--descriptor_set_out=" + out}
+	for _, path := range importPaths {
+		args = append(args, "--proto_path="+path)
+	}
+	if len(importPaths) == 0 {
+		for _, file := range protoFiles {
+			args = append(args, "--proto_path="+filepath.Dir(file))
+		}
+	}
+	args = append(args, protoFiles...)
+	if output, err := exec.Command(protoc, args...).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("protoc failed: %v\n%s", err, output)
+	}
+	return LoadProtoset(out)
+}
+
+// NewReflectionSource returns the source resolving the descriptors by the server reflection service
+// of the server listening on host:port.
+func NewReflectionSource(host string, port int) (generic.DescriptorSource, error) {
+	cli, err := generic.NewClient(net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return nil, err
+	}
+	return cli.DescriptorSource(), nil
+}
+
+// FindMethod finds the method descriptor of service/method
+func FindMethod(ctx context.Context, source generic.DescriptorSource, service, method string) (protoreflect.MethodDescriptor, error) {
+	sd, err := source.FindService(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, fmt.Errorf("method %s is not found in service %s", method, service)
+	}
+	if md.IsStreamingClient() {
+		return nil, fmt.Errorf("client streaming method %s is not supported", method)
+	}
+	return md, nil
+}
@@ -25,6 +25,7 @@ import (
 type MetaData interface {
 	ShowRegistryCenterChildren() (map[string][]string, error)
 	ShowMetadataCenterChildren() (map[string][]string, error)
+	GetProviders(interfaceName string) ([]string, error)
 }
 
 // Factory metaData factory function
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -31,7 +32,6 @@ import (
 
 import (
 	"dubbo.apache.org/dubbo-go/v3/common"
-	"dubbo.apache.org/dubbo-go/v3/metadata/definition"
 	"dubbo.apache.org/dubbo-go/v3/tools/dubbogo-cli/metadata"
 )
 
@@ -147,6 +147,24 @@ func (z *ZookeeperMetadataReport) ShowRegistryCenterChildren() (map[string][]str
 	return methodsMap, nil
 }
 
+// GetProviders returns the urls of providers registered for the interface
+func (z *ZookeeperMetadataReport) GetProviders(interfaceName string) ([]string, error) {
+	nodes, err := z.GetChildren(interfaceName + "/providers")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get providers of %s: %v", interfaceName, err)
+	}
+	providers := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		providerURL, err := url.QueryUnescape(node)
+		if err != nil {
+			log.Printf("Failed to unescape provider %s: %v", node, err)
+			continue
+		}
+		providers = append(providers, providerURL)
+	}
+	return providers, nil
+}
+
 // ShowMetadataCenterChildren shows children list from the metadata center
 func (z *ZookeeperMetadataReport) ShowMetadataCenterChildren() (map[string][]string, error) {
 	methodsMap := map[string][]string{}
@@ -184,7 +202,12 @@ func (z *ZookeeperMetadataReport) searchMetadataProvider(path string, methods *[
 				return
 			}
 
-			var serviceDefinition definition.FullServiceDefinition
+			// the service definition reported by the providers, only the method names are needed
+			var serviceDefinition struct {
+				Methods []struct {
+					Name string
+				}
+			}
 			err = json.Unmarshal(content, &serviceDefinition)
 			if err != nil {
 				fmt.Printf("Json Unmarshal fail: %v\n", err)